@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"github.com/RHsyseng/operator-utils/pkg/logs"
+	"github.com/RHsyseng/operator-utils/pkg/utils/kubernetes"
+	"github.com/ghodss/yaml"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/render"
+	"github.com/kiegroup/kie-cloud-operator/tools/util"
+	oimagev1 "github.com/openshift/api/image/v1"
+	imagev1 "github.com/openshift/client-go/image/clientset/versioned/typed/image/v1"
+	"github.com/operator-framework/operator-sdk/pkg/log/zap"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientv1 "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+var log = logs.GetLogger("cmd")
+
+func main() {
+	// Add the zap logger flag set to the CLI. The flag set must
+	// be added before calling pflag.Parse().
+	pflag.CommandLine.AddFlagSet(zap.FlagSet())
+
+	// Add flags registered by imported packages (e.g. glog and
+	// controller-runtime)
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+
+	file := pflag.StringP("file", "f", "", "KieApp YAML file to render")
+	productVersion := pflag.String("version", "", "Product version to render, overrides the version set in the KieApp")
+	namespace := pflag.StringP("namespace", "n", "", "Namespace of the KieApp, overrides the namespace set in the KieApp")
+	diff := pflag.Bool("diff", false, "Compare the rendered resources with the ones deployed in the namespace")
+	pflag.Parse()
+
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "Usage: kieapp-render -f <kieapp.yaml> [--version <version>] [--namespace <namespace>] [--diff]")
+		pflag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	cr, err := loadKieApp(*file)
+	if err != nil {
+		log.Error("Failed to load KieApp. ", err)
+		os.Exit(1)
+	}
+	if *productVersion != "" {
+		cr.Spec.Version = *productVersion
+	}
+	if *namespace != "" {
+		cr.Namespace = *namespace
+	}
+	if cr.Namespace == "" {
+		cr.Namespace = "default"
+	}
+
+	if *diff {
+		err = diffKieApp(cr)
+	} else {
+		err = renderKieApp(cr)
+	}
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+}
+
+func loadKieApp(file string) (*api.KieApp, error) {
+	yamlBytes, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	cr := &api.KieApp{}
+	if err := yaml.Unmarshal(yamlBytes, cr); err != nil {
+		return nil, err
+	}
+	if cr.Kind != "" && cr.Kind != "KieApp" {
+		return nil, fmt.Errorf("%s does not contain a KieApp but a %s", file, cr.Kind)
+	}
+	return cr, nil
+}
+
+// renderKieApp prints the resources for the KieApp as multi-document YAML, using the embedded configs
+// and an in-memory service so that no cluster is required
+func renderKieApp(cr *api.KieApp) error {
+	service, err := render.NewOfflineService()
+	if err != nil {
+		return err
+	}
+	reconciler := kieapp.Reconciler{Service: service}
+	resources, err := reconciler.RenderResources(cr)
+	if err != nil {
+		return err
+	}
+	for _, res := range resources {
+		fmt.Println("---")
+		if err := util.MarshallObject(res, os.Stdout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffKieApp prints the resources that would be added, updated or removed in the namespace for the KieApp.
+// Nothing is persisted, as all write requests are sent to the cluster as dry runs.
+func diffKieApp(cr *api.KieApp) error {
+	service, err := newDryRunService()
+	if err != nil {
+		return err
+	}
+	deployed := &api.KieApp{}
+	err = service.Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, deployed)
+	if err == nil {
+		cr.ObjectMeta = deployed.ObjectMeta
+		cr.Status = deployed.Status
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	reconciler := kieapp.Reconciler{Service: service}
+	requested, err := reconciler.RenderResources(cr)
+	if err != nil {
+		return err
+	}
+	drift, err := reconciler.DriftResources(cr, requested)
+	if err != nil {
+		return err
+	}
+	prefixes := map[api.DriftAction]string{api.DriftCreate: "+", api.DriftUpdate: "~", api.DriftDelete: "-"}
+	var lines []string
+	for _, resourceDrift := range drift {
+		line := fmt.Sprintf("%s %s/%s", prefixes[resourceDrift.Action], resourceDrift.Kind, resourceDrift.Name)
+		if len(resourceDrift.Fields) > 0 {
+			line = fmt.Sprintf("%s (%s)", line, strings.Join(resourceDrift.Fields, ", "))
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// dryRunService is a PlatformService backed by the current kubeconfig that never persists any change
+type dryRunService struct {
+	client      clientv1.Client
+	imageClient *imagev1.ImageV1Client
+	scheme      *runtime.Scheme
+}
+
+var _ kubernetes.PlatformService = &dryRunService{}
+
+func newDryRunService() (*dryRunService, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	scheme, err := render.NewScheme()
+	if err != nil {
+		return nil, err
+	}
+	client, err := clientv1.New(cfg, clientv1.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	imageClient, err := imagev1.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &dryRunService{
+		client:      clientv1.NewDryRunClient(client),
+		imageClient: imageClient,
+		scheme:      scheme,
+	}, nil
+}
+
+func (service *dryRunService) Create(ctx context.Context, obj runtime.Object, opts ...clientv1.CreateOption) error {
+	return service.client.Create(ctx, obj, opts...)
+}
+
+func (service *dryRunService) Delete(ctx context.Context, obj runtime.Object, opts ...clientv1.DeleteOption) error {
+	return service.client.Delete(ctx, obj, opts...)
+}
+
+func (service *dryRunService) Get(ctx context.Context, key clientv1.ObjectKey, obj runtime.Object) error {
+	return service.client.Get(ctx, key, obj)
+}
+
+func (service *dryRunService) List(ctx context.Context, list runtime.Object, opts ...clientv1.ListOption) error {
+	return service.client.List(ctx, list, opts...)
+}
+
+func (service *dryRunService) Update(ctx context.Context, obj runtime.Object, opts ...clientv1.UpdateOption) error {
+	return service.client.Update(ctx, obj, opts...)
+}
+
+func (service *dryRunService) Patch(ctx context.Context, obj runtime.Object, patch clientv1.Patch, opts ...clientv1.PatchOption) error {
+	return service.client.Patch(ctx, obj, patch, opts...)
+}
+
+func (service *dryRunService) DeleteAllOf(ctx context.Context, obj runtime.Object, opts ...clientv1.DeleteAllOfOption) error {
+	return service.client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (service *dryRunService) GetCached(ctx context.Context, key clientv1.ObjectKey, obj runtime.Object) error {
+	return service.client.Get(ctx, key, obj)
+}
+
+func (service *dryRunService) ImageStreamTags(namespace string) imagev1.ImageStreamTagInterface {
+	return &dryRunImageStreamTags{service.imageClient.ImageStreamTags(namespace)}
+}
+
+func (service *dryRunService) GetScheme() *runtime.Scheme {
+	return service.scheme
+}
+
+func (service *dryRunService) Status() clientv1.StatusWriter {
+	return service.client.Status()
+}
+
+func (service *dryRunService) IsMockService() bool {
+	return false
+}
+
+// dryRunImageStreamTags sends all ImageStreamTag write requests to the cluster as dry runs
+type dryRunImageStreamTags struct {
+	imagev1.ImageStreamTagInterface
+}
+
+func (tags *dryRunImageStreamTags) Create(ctx context.Context, imageStreamTag *oimagev1.ImageStreamTag, opts metav1.CreateOptions) (*oimagev1.ImageStreamTag, error) {
+	opts.DryRun = []string{metav1.DryRunAll}
+	return tags.ImageStreamTagInterface.Create(ctx, imageStreamTag, opts)
+}
+
+func (tags *dryRunImageStreamTags) Update(ctx context.Context, imageStreamTag *oimagev1.ImageStreamTag, opts metav1.UpdateOptions) (*oimagev1.ImageStreamTag, error) {
+	opts.DryRun = []string{metav1.DryRunAll}
+	return tags.ImageStreamTagInterface.Update(ctx, imageStreamTag, opts)
+}
+
+func (tags *dryRunImageStreamTags) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	opts.DryRun = []string{metav1.DryRunAll}
+	return tags.ImageStreamTagInterface.Delete(ctx, name, opts)
+}
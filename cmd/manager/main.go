@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -19,6 +20,7 @@ import (
 	"github.com/kiegroup/kie-cloud-operator/pkg/apis"
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller"
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/webhook"
 	"github.com/kiegroup/kie-cloud-operator/version"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	kubemetrics "github.com/operator-framework/operator-sdk/pkg/kube-metrics"
@@ -30,6 +32,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
@@ -60,7 +63,10 @@ func main() {
 	// controller-runtime)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 
-	flag.Parse()
+	// Add the flags tuning the reconciliation of the KieApps
+	controller.Options.AddFlags(pflag.CommandLine)
+
+	pflag.Parse()
 
 	printVersion()
 
@@ -87,11 +93,20 @@ func main() {
 
 	// Create a new Cmd to provide shared dependencies and start components
 	syncPeriod := time.Duration(2) * time.Hour
-	mgr, err := manager.New(cfg, manager.Options{
+	options := manager.Options{
 		Namespace:          namespace,
 		SyncPeriod:         &syncPeriod,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
-	})
+	}
+	// Watch a comma-separated list of namespaces with a cache per namespace, instead of the whole cluster
+	if strings.Contains(namespace, ",") {
+		operatorNamespace, _ := k8sutil.GetOperatorNamespace()
+		namespaces := getWatchNamespaces(namespace, operatorNamespace)
+		log.Infof("Watching namespaces %v", namespaces)
+		options.Namespace = ""
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		log.Error("Error getting Manager. ", err)
 		os.Exit(1)
@@ -111,6 +126,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup the admission webhooks
+	if err := webhook.AddToManager(mgr); err != nil {
+		log.Error("Error adding webhooks to Manager. ", err)
+		os.Exit(1)
+	}
+
 	// Add the Metrics Service
 	addMetrics(ctx, cfg)
 
@@ -131,6 +152,20 @@ func main() {
 	}
 }
 
+// getWatchNamespaces returns the namespaces of a comma-separated list, along with the namespace of the operator, so
+// that its ConfigMaps can be read from the cache
+func getWatchNamespaces(namespace, operatorNamespace string) []string {
+	var namespaces []string
+	found := map[string]bool{}
+	for _, ns := range append(strings.Split(namespace, ","), operatorNamespace) {
+		if ns = strings.TrimSpace(ns); len(ns) > 0 && !found[ns] {
+			found[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 // addMetrics will create the Services and Service Monitors to allow the operator export the metrics by using
 // the Prometheus operator
 func addMetrics(ctx context.Context, cfg *rest.Config) {
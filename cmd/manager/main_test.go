@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWatchNamespaces(t *testing.T) {
+	assert.Equal(t, []string{"team-a", "team-b", "operators"}, getWatchNamespaces("team-a, team-b,", "operators"))
+	assert.Equal(t, []string{"team-a", "operators"}, getWatchNamespaces("team-a,operators", "operators"), "Namespaces should be watched once")
+	assert.Equal(t, []string{"team-a", "team-b"}, getWatchNamespaces("team-a,team-b", ""), "The namespace of the operator is unknown when run locally")
+}
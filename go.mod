@@ -21,17 +21,21 @@ require (
 	github.com/operator-framework/operator-sdk v0.19.2
 	github.com/pavel-v-chernykh/keystore-go v2.1.0+incompatible
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_golang v1.6.0
 	github.com/prometheus/common v0.10.0
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.6.1
 	github.com/tidwall/gjson v1.4.0
 	github.com/tidwall/sjson v1.0.4
 	golang.org/x/mod v0.2.0
+	golang.org/x/time v0.0.0-20200416051211-89c76fbcd5d1
+	gopkg.in/yaml.v3 v3.0.0-20200605160147-a5ece683394c
 	k8s.io/api v0.18.6
 	k8s.io/apiextensions-apiserver v0.18.6
 	k8s.io/apimachinery v0.18.6
 	k8s.io/client-go v12.0.0+incompatible
 	sigs.k8s.io/controller-runtime v0.6.2
+	sigs.k8s.io/structured-merge-diff/v3 v3.0.0
 )
 
 replace (
@@ -6,11 +6,15 @@ import (
 	oimagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // KieAppSpec defines the desired state of KieApp
@@ -31,7 +35,30 @@ type KieAppSpec struct {
 	Version      string            `json:"version,omitempty"`
 	CommonConfig CommonConfig      `json:"commonConfig,omitempty"`
 	Auth         *KieAppAuthObject `json:"auth,omitempty"`
-}
+	// +kubebuilder:validation:Enum:=openshift;kubernetes
+	// The platform the application is deployed to. Defaults to 'openshift'. On 'kubernetes', Deployments and Ingresses are used instead of DeploymentConfigs and Routes, and builds are skipped.
+	Platform PlatformType `json:"platform,omitempty"`
+	// Base domain of the ingress hosts on the 'kubernetes' platform, e.g. apps.example.com. The ingresses without a route host are served on <ingress name>-<namespace>.<ingressDomain>. Required on 'kubernetes'.
+	IngressDomain string `json:"ingressDomain,omitempty"`
+	// Set true to pause the reconciliation. The drift of the deployed objects is still reported in the status, but no object is written until it is unset.
+	Paused bool `json:"paused,omitempty"`
+	// Labels added to all the generated objects and their pods, e.g. a cost center. They don't replace the labels set by the operator.
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+	// Annotations added to all the generated objects, replacing the ones of the templates with the same name.
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+	// A product version upgraded from, as recorded in the upgrade history, to roll back to, e.g. when the components of the upgraded version fail readiness. The environment and images of that version are restored from the snapshot taken before the upgrade, and upgrades are suspended until it is unset.
+	RollbackTo string `json:"rollbackTo,omitempty"`
+}
+
+// PlatformType describes the platform the application objects are generated for
+type PlatformType string
+
+const (
+	// PlatformOpenShift OpenShift DeploymentConfigs, Routes, BuildConfigs and ImageStreams
+	PlatformOpenShift PlatformType = "openshift"
+	// PlatformKubernetes Kubernetes Deployments and Ingresses with plain image references
+	PlatformKubernetes PlatformType = "kubernetes"
+)
 
 // EnvironmentType describes a possible application environment
 type EnvironmentType string
@@ -100,6 +127,10 @@ type KieAppObjects struct {
 	Servers          []KieServerSet          `json:"servers,omitempty"`
 	SmartRouter      *SmartRouterObject      `json:"smartRouter,omitempty"`
 	ProcessMigration *ProcessMigrationObject `json:"processMigration,omitempty"`
+	// Scheduling of the datagrid pods of the HA environments
+	Datagrid *SchedulingObject `json:"datagrid,omitempty"`
+	// Scheduling of the AMQ broker pods of the HA environments
+	Broker *SchedulingObject `json:"broker,omitempty"`
 }
 
 // KieAppUpgrades KIE App product upgrade flags
@@ -108,6 +139,31 @@ type KieAppUpgrades struct {
 	Enabled bool `json:"enabled,omitempty"`
 	// Set true to enable automatic minor product version upgrades, it is disabled by default. Requires spec.upgrades.enabled to be true.
 	Minor bool `json:"minor,omitempty"`
+	// +kubebuilder:validation:Enum:=Automatic;Manual
+	// Set to 'Manual' to only upgrade once the 'kieapp.app.kiegroup.org/approved-version' annotation is set to the upgraded version. Defaults to 'Automatic'.
+	Approval UpgradeApprovalType `json:"approval,omitempty"`
+	// Maintenance window the upgrades are applied in. Upgrades are applied at any time if not set.
+	Window *UpgradeWindow `json:"window,omitempty"`
+}
+
+// UpgradeApprovalType describes how the upgrades of a KieApp are approved
+type UpgradeApprovalType string
+
+const (
+	// UpgradeApprovalAutomatic upgrades are applied as soon as available
+	UpgradeApprovalAutomatic UpgradeApprovalType = "Automatic"
+	// UpgradeApprovalManual upgrades are applied once the upgraded version is approved with an annotation
+	UpgradeApprovalManual UpgradeApprovalType = "Manual"
+)
+
+// UpgradeWindow a recurring maintenance window
+type UpgradeWindow struct {
+	// +kubebuilder:validation:Required
+	// Cron schedule of the start of the window, in UTC, with minute, hour, day of month, month and day of week fields, e.g. '0 2 * * 6' for Saturdays at 2:00.
+	Schedule string `json:"schedule"`
+	// +kubebuilder:validation:Required
+	// Duration of the window, e.g. '4h'. At most 7 days.
+	Duration string `json:"duration"`
 }
 
 // KieServerSet KIE Server configuration for a single set, or for multiple sets if deployments is set to >1
@@ -126,6 +182,8 @@ type KieServerSet struct {
 	Database     *DatabaseObject  `json:"database,omitempty"`
 	Jms          *KieAppJmsObject `json:"jms,omitempty"`
 	Jvm          *JvmObject       `json:"jvm,omitempty"`
+	// Autoscaling of the Server sets, replacing their fixed number of replicas
+	Autoscaling *AutoscalingObject `json:"autoscaling,omitempty"`
 }
 
 // ConsoleObject configuration of the RHPAM workbench
@@ -144,6 +202,26 @@ type SmartRouterObject struct {
 	Protocol string `json:"protocol,omitempty"`
 	// If enabled, Business Central will use the external smartrouter route to communicate with it. Note that, valid SSL certificates should be used.
 	UseExternalRoute bool `json:"useExternalRoute,omitempty"`
+	// Autoscaling of the smart router, replacing its fixed number of replicas
+	Autoscaling *AutoscalingObject `json:"autoscaling,omitempty"`
+}
+
+// AutoscalingObject configuration of the HorizontalPodAutoscaler created for a component.
+// If no metric target is set, the average CPU utilization is targeted at 80%.
+type AutoscalingObject struct {
+	// +kubebuilder:validation:Minimum:=1
+	// Lower limit for the number of replicas, defaults to 1.
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=1
+	// Upper limit for the number of replicas.
+	MaxReplicas int32 `json:"maxReplicas"`
+	// Target average CPU utilization, as a percentage of the requested CPU.
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+	// Target average memory utilization, as a percentage of the requested memory.
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+	// Additional metrics to scale on, e.g. pods or external metrics.
+	Metrics []autoscalingv2beta2.MetricSpec `json:"metrics,omitempty"`
 }
 
 // KieAppJmsObject messaging specification to be used by the KieApp
@@ -175,6 +253,8 @@ type KieAppJmsObject struct {
 	Username string `json:"username,omitempty"`
 	// AMQ broker password to connect do the AMQ, generated if empty.
 	Password string `json:"password,omitempty"`
+	// Secret key holding the AMQ broker password, used when password is empty.
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret,omitempty"`
 	// AMQ broker broker comma separated queues, if empty the values from default queues will be used.
 	AMQQueues string `json:"amqQueues,omitempty"` // It will receive the default value for the Executor, Request, Response, Signal and Audit queues.
 	// The name of a secret containing AMQ SSL related files.
@@ -189,6 +269,8 @@ type KieAppJmsObject struct {
 	AMQKeystorePassword string `json:"amqKeystorePassword,omitempty"`
 	// Not intended to be set by the user, if will be set to true if all required SSL parameters are set.
 	AMQEnableSSL bool `json:"amqEnableSSL,omitempty"` // flag will be set to true if all AMQ SSL parameters are correctly set.
+	// Scheduling of the AMQ broker pods, defaults to the scheduling of the KIE Server.
+	SchedulingObject `json:",inline"`
 }
 
 // JvmObject JVM specification to be used by the KieApp
@@ -224,6 +306,30 @@ type JvmObject struct {
 // KieAppObject generic object definition
 type KieAppObject struct {
 	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Sources of environment variables of the main container, e.g. ConfigMaps or Secrets.
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Additional containers of the pods, e.g. sidecars, merged with the containers of the component with the same name.
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Additional init containers of the pods, merged with the init containers of the component with the same name.
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Additional volumes of the pods, replacing the volumes of the component with the same name.
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// Additional volume mounts of the main container.
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Security context of the pods.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Security context of the main container.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 	// Replicas to set for the DeploymentConfig
 	Replicas  *int32                       `json:"replicas,omitempty"`
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
@@ -237,6 +343,82 @@ type KieAppObject struct {
 	ImageTag string `json:"imageTag,omitempty"`
 	// The storageClassName to use
 	StorageClassName string `json:"storageClassName,omitempty"`
+	SchedulingObject `json:",inline"`
+	// PodDisruptionBudget of the pods, created when more than one replica is deployed.
+	PodDisruptionBudget *PodDisruptionBudgetObject `json:"podDisruptionBudget,omitempty"`
+	// Probes of the main container, overriding the default ones.
+	Probes         *ProbesObject `json:"probes,omitempty"`
+	MetadataObject `json:",inline"`
+	// Host, path and TLS configuration of the route, or ingress on Kubernetes.
+	Route *RouteObject `json:"route,omitempty"`
+}
+
+// RouteObject configuration of the route exposing a component. The generated keystore and the SSO hostname
+// follow the host when set.
+type RouteObject struct {
+	// Hostname of the route, generated by the cluster unless set.
+	Host string `json:"host,omitempty"`
+	// Path of the route, not supported with passthrough termination.
+	Path string `json:"path,omitempty"`
+	// TLS termination of the route, passthrough by default, edge for Process Migration which only supports edge.
+	// +kubebuilder:validation:Enum:=edge;reencrypt;passthrough
+	Termination routev1.TLSTerminationType `json:"termination,omitempty"`
+	// Name of a kubernetes.io/tls Secret with the tls.crt, tls.key and optional ca.crt of the route. The certificate
+	// is also served by the pods, instead of a generated one, unless a keystoreSecret is set.
+	TLSSecret string `json:"tlsSecret,omitempty"`
+}
+
+// MetadataObject labels and annotations added to the objects of a component. The labels don't replace the ones set by
+// the operator, the annotations replace the ones of the templates with the same name.
+type MetadataObject struct {
+	// Labels of the pods.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+	// Annotations of the pods, e.g. sidecar.istio.io/inject.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+	// Annotations of the services.
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+	// Annotations of the routes, or ingresses on Kubernetes.
+	RouteAnnotations map[string]string `json:"routeAnnotations,omitempty"`
+}
+
+// ProbesObject probes of the main container of a component. The fields set replace those of the default probes.
+type ProbesObject struct {
+	// Liveness probe of the main container.
+	Liveness *corev1.Probe `json:"liveness,omitempty"`
+	// Readiness probe of the main container.
+	Readiness *corev1.Probe `json:"readiness,omitempty"`
+	// Startup probe of the main container, checking the same endpoint as the liveness probe unless set.
+	// Defaulted for the KIE Servers deploying many KIE containers.
+	Startup *corev1.Probe `json:"startup,omitempty"`
+}
+
+// SchedulingObject scheduling constraints of the pods of a component
+type SchedulingObject struct {
+	// Node labels the pods must be scheduled on.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Tolerations of the pods, e.g. to be scheduled on tainted nodes.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Affinity of the pods, replacing the default pod anti-affinity of the HA environments.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Priority class of the pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Topology spread constraints of the pods.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// PodDisruptionBudgetObject configuration of the PodDisruptionBudget created for a component.
+// If neither is set, one pod at a time can be disrupted.
+type PodDisruptionBudgetObject struct {
+	// Number or percentage of pods that must remain available during a disruption.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// Number or percentage of pods that can be unavailable during a disruption.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // KieAppBuildObject Data to define how to build an application from source
@@ -312,6 +494,8 @@ type SSOAuthConfig struct {
 	// +kubebuilder:validation:Format:=password
 	// RH-SSO Realm Admin Password used to create the Client
 	AdminPassword string `json:"adminPassword,omitempty"`
+	// Secret key holding the RH-SSO Realm Admin Password, used when adminPassword is empty.
+	AdminPasswordSecret *corev1.SecretKeySelector `json:"adminPasswordSecret,omitempty"`
 	// RH-SSO Realm Admin Username used to create the Client if it doesn't exist
 	AdminUser string `json:"adminUser,omitempty"`
 	// +kubebuilder:validation:Required
@@ -344,6 +528,8 @@ type LDAPAuthConfig struct {
 	// +kubebuilder:validation:Format:=password
 	// LDAP Credentials used for authentication
 	BindCredential string `json:"bindCredential,omitempty"`
+	// Secret key holding the LDAP Credentials, used when bindCredential is empty.
+	BindCredentialSecret *corev1.SecretKeySelector `json:"bindCredentialSecret,omitempty"`
 	// +kubebuilder:validation:Required
 	// LDAP endpoint to connect for authentication. For failover set two or more LDAP endpoints separated by space
 	URL string `json:"url"`
@@ -446,6 +632,8 @@ type InternalDatabaseObject struct {
 	Size string `json:"size,omitempty"`
 	// The storageClassName to use for database pvc's.
 	StorageClassName string `json:"storageClassName,omitempty"`
+	// Scheduling of the database pods, defaults to the scheduling of the component using the database.
+	SchedulingObject `json:",inline"`
 }
 
 // CommonExtDBObjectRequiredURL common configuration definition of an external database
@@ -471,9 +659,10 @@ type CommonExternalDatabaseObject struct {
 	// +kubebuilder:validation:Required
 	// External database username
 	Username string `json:"username"`
-	// +kubebuilder:validation:Required
-	// External database password
-	Password string `json:"password"`
+	// External database password, required unless passwordSecret is set.
+	Password string `json:"password,omitempty"`
+	// Secret key holding the external database password, used when password is empty.
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret,omitempty"`
 	// Sets xa-pool/min-pool-size for the configured datasource.
 	MinPoolSize string `json:"minPoolSize,omitempty"`
 	// Sets xa-pool/max-pool-size for the configured datasource.
@@ -532,19 +721,23 @@ type Environment struct {
 }
 
 type CustomObject struct {
-	Omit                   bool                           `json:"omit,omitempty"`
-	PersistentVolumeClaims []corev1.PersistentVolumeClaim `json:"persistentVolumeClaims,omitempty"`
-	ServiceAccounts        []corev1.ServiceAccount        `json:"serviceAccounts,omitempty"`
-	Secrets                []corev1.Secret                `json:"secrets,omitempty"`
-	Roles                  []rbacv1.Role                  `json:"roles,omitempty"`
-	RoleBindings           []rbacv1.RoleBinding           `json:"roleBindings,omitempty"`
-	DeploymentConfigs      []oappsv1.DeploymentConfig     `json:"deploymentConfigs,omitempty"`
-	StatefulSets           []appsv1.StatefulSet           `json:"statefulSets,omitempty"`
-	BuildConfigs           []buildv1.BuildConfig          `json:"buildConfigs,omitempty"`
-	ImageStreams           []oimagev1.ImageStream         `json:"imageStreams,omitempty"`
-	Services               []corev1.Service               `json:"services,omitempty"`
-	Routes                 []routev1.Route                `json:"routes,omitempty"`
-	ConfigMaps             []corev1.ConfigMap             `json:"configMaps,omitempty"`
+	Omit                     bool                                         `json:"omit,omitempty"`
+	PersistentVolumeClaims   []corev1.PersistentVolumeClaim               `json:"persistentVolumeClaims,omitempty"`
+	ServiceAccounts          []corev1.ServiceAccount                      `json:"serviceAccounts,omitempty"`
+	Secrets                  []corev1.Secret                              `json:"secrets,omitempty"`
+	Roles                    []rbacv1.Role                                `json:"roles,omitempty"`
+	RoleBindings             []rbacv1.RoleBinding                         `json:"roleBindings,omitempty"`
+	DeploymentConfigs        []oappsv1.DeploymentConfig                   `json:"deploymentConfigs,omitempty"`
+	Deployments              []appsv1.Deployment                          `json:"deployments,omitempty"`
+	StatefulSets             []appsv1.StatefulSet                         `json:"statefulSets,omitempty"`
+	BuildConfigs             []buildv1.BuildConfig                        `json:"buildConfigs,omitempty"`
+	ImageStreams             []oimagev1.ImageStream                       `json:"imageStreams,omitempty"`
+	Services                 []corev1.Service                             `json:"services,omitempty"`
+	Routes                   []routev1.Route                              `json:"routes,omitempty"`
+	Ingresses                []networkingv1beta1.Ingress                  `json:"ingresses,omitempty"`
+	ConfigMaps               []corev1.ConfigMap                           `json:"configMaps,omitempty"`
+	HorizontalPodAutoscalers []autoscalingv2beta2.HorizontalPodAutoscaler `json:"horizontalPodAutoscalers,omitempty"`
+	PodDisruptionBudgets     []policyv1beta1.PodDisruptionBudget          `json:"podDisruptionBudgets,omitempty"`
 }
 
 type OpenShiftObject interface {
@@ -680,16 +873,26 @@ type CommonConfig struct {
 	ApplicationName string `json:"applicationName,omitempty"`
 	// The password to use for keystore generation.
 	KeyStorePassword string `json:"keyStorePassword,omitempty"`
+	// Secret key holding the password to use for keystore generation, used when keyStorePassword is empty.
+	KeyStorePasswordSecret *corev1.SecretKeySelector `json:"keyStorePasswordSecret,omitempty"`
 	// The user to use for the admin.
 	AdminUser string `json:"adminUser,omitempty"`
 	// The password to use for the adminUser.
 	AdminPassword string `json:"adminPassword,omitempty"`
+	// Secret key holding the password to use for the adminUser, used when adminPassword is empty.
+	AdminPasswordSecret *corev1.SecretKeySelector `json:"adminPasswordSecret,omitempty"`
 	// The password to use for databases.
 	DBPassword string `json:"dbPassword,omitempty"`
+	// Secret key holding the password to use for databases, used when dbPassword is empty.
+	DBPasswordSecret *corev1.SecretKeySelector `json:"dbPasswordSecret,omitempty"`
 	// The password to use for amq user.
 	AMQPassword string `json:"amqPassword,omitempty"`
+	// Secret key holding the password to use for amq user, used when amqPassword is empty.
+	AMQPasswordSecret *corev1.SecretKeySelector `json:"amqPasswordSecret,omitempty"`
 	// The password to use for amq cluster user.
 	AMQClusterPassword string `json:"amqClusterPassword,omitempty"`
+	// Secret key holding the password to use for amq cluster user, used when amqClusterPassword is empty.
+	AMQClusterPasswordSecret *corev1.SecretKeySelector `json:"amqClusterPasswordSecret,omitempty"`
 }
 
 // VersionConfigs ...
@@ -733,8 +936,12 @@ type ProcessMigrationObject struct {
 	// The image to use for Process Instance Migration e.g. rhpam-process-migration-rhel8, this param is optional for custom image.
 	Image string `json:"image,omitempty"`
 	// The image tag to use for Process Instance Migration e.g. 7.9.0, this param is optional for custom image.
-	ImageTag string                         `json:"imageTag,omitempty"`
-	Database ProcessMigrationDatabaseObject `json:"database,omitempty"`
+	ImageTag         string                         `json:"imageTag,omitempty"`
+	Database         ProcessMigrationDatabaseObject `json:"database,omitempty"`
+	SchedulingObject `json:",inline"`
+	MetadataObject   `json:",inline"`
+	// Host, path and TLS configuration of the route, or ingress on Kubernetes.
+	Route *RouteObject `json:"route,omitempty"`
 }
 
 // ProcessMigrationTemplate ...
@@ -16,8 +16,27 @@ const (
 	ProvisioningConditionType ConditionType = "Provisioning"
 	// FailedConditionType - the kieapp is in a failed state
 	FailedConditionType ConditionType = "Failed"
+	// DeletingConditionType - the kieapp is being deleted and its resources cleaned up
+	DeletingConditionType ConditionType = "Deleting"
+	// PausedConditionType - the reconciliation of the kieapp is paused, no resource is written
+	PausedConditionType ConditionType = "Paused"
+	// ReadyConditionType - all the kieapp components are deployed and ready
+	ReadyConditionType ConditionType = "Ready"
+	// ConsoleReadyConditionType - the console pods are ready
+	ConsoleReadyConditionType ConditionType = "ConsoleReady"
+	// SmartRouterReadyConditionType - the smart router pods are ready
+	SmartRouterReadyConditionType ConditionType = "SmartRouterReady"
+	// ProcessMigrationReadyConditionType - the process migration pods are ready
+	ProcessMigrationReadyConditionType ConditionType = "ProcessMigrationReady"
+	// DatabaseReadyConditionType - the database pods deployed by the operator are ready
+	DatabaseReadyConditionType ConditionType = "DatabaseReady"
+	// BrokerReadyConditionType - the AMQ broker pods deployed by the operator are ready
+	BrokerReadyConditionType ConditionType = "BrokerReady"
 )
 
+// KieServerReadyConditionFormat - format of the condition type for the readiness of each KieServerSet
+const KieServerReadyConditionFormat = "KieServer%sReady"
+
 // ReasonType - type of reason
 type ReasonType string
 
@@ -28,6 +47,16 @@ const (
 	ConfigurationErrorReason ReasonType = "ConfigurationError"
 	// MissingDependenciesReason - Dependencies does not exist or cannot be found
 	MissingDependenciesReason ReasonType = "MissingDependencies"
+	// NotReadyReason - The component pods are not available or not ready
+	NotReadyReason ReasonType = "NotReady"
+	// RolloutFailedReason - The component rollout did not complete
+	RolloutFailedReason ReasonType = "RolloutFailed"
+	// ComponentsNotReadyReason - One or more components are not ready
+	ComponentsNotReadyReason ReasonType = "ComponentsNotReady"
+	// CleanupFailedReason - Unable to clean up the resources on deletion
+	CleanupFailedReason ReasonType = "CleanupFailed"
+	// UpgradeConflictReason - Customized configuration conflicts with the configuration of the upgraded version
+	UpgradeConflictReason ReasonType = "UpgradeConflict"
 	// UnknownReason - Unable to determine the error
 	UnknownReason ReasonType = "Unknown"
 )
@@ -50,4 +79,138 @@ type KieAppStatus struct {
 	Phase       ConditionType        `json:"phase,omitempty"`
 	Applied     KieAppSpec           `json:"applied,omitempty"`
 	Version     string               `json:"version,omitempty"`
+	// Fields of the deployed resources owned by another field manager, which the operator did not overwrite
+	Conflicts []ResourceConflict `json:"conflicts,omitempty"`
+	// Deployed resources that differ from the requested ones, with the fields that differ
+	Drift []ResourceDrift `json:"drift,omitempty"`
+	// Customizations of the configuration of the applied version that conflict with the upgraded version
+	UpgradeConflicts []ConfigConflict `json:"upgradeConflicts,omitempty"`
+	// Product version upgrades and rollbacks, the most recent last
+	UpgradeHistory []UpgradeRecord `json:"upgradeHistory,omitempty"`
+	// Upgrade of the product version waiting for approval or for the upgrade window
+	UpgradeAvailable *UpgradeAvailable `json:"upgradeAvailable,omitempty"`
+	// Product versions left to upgrade through, the next one first
+	UpgradePath []string `json:"upgradePath,omitempty"`
+}
+
+// ResourceConflict - A resource that could not be applied because some of its fields are owned by another field manager
+type ResourceConflict struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// DriftAction - The change needed for a deployed resource to match the requested one
+type DriftAction string
+
+const (
+	// DriftCreate - The requested resource is not deployed
+	DriftCreate DriftAction = "Create"
+	// DriftUpdate - The deployed resource differs from the requested one
+	DriftUpdate DriftAction = "Update"
+	// DriftDelete - The deployed resource is no longer requested
+	DriftDelete DriftAction = "Delete"
+)
+
+// ResourceDrift - A deployed resource that differs from the requested one
+type ResourceDrift struct {
+	Kind   string      `json:"kind"`
+	Name   string      `json:"name"`
+	Action DriftAction `json:"action"`
+	// Paths of the requested fields that differ in the deployed resource, for the updated ones
+	Fields []string `json:"fields,omitempty"`
+}
+
+// ConfigConflict - A field of a configuration file customized in the ConfigMap of the applied version and changed
+// differently in the upgraded version
+type ConfigConflict struct {
+	// ConfigMap holding the customized file
+	ConfigMap string `json:"configMap"`
+	File      string `json:"file"`
+	// YAML path of the conflicting field, empty when the whole file conflicts
+	Path string `json:"path,omitempty"`
+	// Value shipped with the applied version
+	Original string `json:"original,omitempty"`
+	// Value customized in the ConfigMap
+	Customized string `json:"customized,omitempty"`
+	// Value of the upgraded version
+	Upgraded string `json:"upgraded,omitempty"`
+}
+
+// UpgradePhase - The phase of a product version upgrade
+type UpgradePhase string
+
+const (
+	// UpgradeInProgress - The upgraded version is being rolled out
+	UpgradeInProgress UpgradePhase = "InProgress"
+	// UpgradeCompleted - The components of the upgraded version are deployed and ready
+	UpgradeCompleted UpgradePhase = "Completed"
+	// UpgradeFailed - The rollout of a component of the upgraded version failed
+	UpgradeFailed UpgradePhase = "Failed"
+	// UpgradeRolledBack - The version upgraded from was restored with rollbackTo
+	UpgradeRolledBack UpgradePhase = "RolledBack"
+)
+
+// UpgradeRecord - An upgrade of the product version of the KieApp
+type UpgradeRecord struct {
+	FromVersion string       `json:"fromVersion"`
+	ToVersion   string       `json:"toVersion"`
+	Phase       UpgradePhase `json:"phase"`
+	StartTime   metav1.Time  `json:"startTime,omitempty"`
+	// Time the upgrade completed, failed or was rolled back
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+	// Secret holding the applied spec and the resources of the version upgraded from, taken before the upgrade
+	Snapshot string `json:"snapshot,omitempty"`
+	Message  string `json:"message,omitempty"`
+	// Migrations of the database schemas of the KIE Servers to the upgraded version
+	DatabaseMigrations []DatabaseMigration `json:"databaseMigrations,omitempty"`
+}
+
+// DatabaseMigrationPhase - The phase of the migration of the database schema of a KIE Server
+type DatabaseMigrationPhase string
+
+const (
+	// DatabaseMigrationRunning - The upgrade scripts are being applied, the KIE Server is not rolled out yet
+	DatabaseMigrationRunning DatabaseMigrationPhase = "Running"
+	// DatabaseMigrationSucceeded - The upgrade scripts were applied
+	DatabaseMigrationSucceeded DatabaseMigrationPhase = "Succeeded"
+	// DatabaseMigrationFailed - The upgrade scripts could not be applied, the KIE Server is not rolled out
+	DatabaseMigrationFailed DatabaseMigrationPhase = "Failed"
+	// DatabaseMigrationSkipped - The database can't be migrated by the operator and must be migrated manually
+	DatabaseMigrationSkipped DatabaseMigrationPhase = "Skipped"
+)
+
+// DatabaseMigration - The migration of the database schema of a KIE Server during an upgrade
+type DatabaseMigration struct {
+	// KIE Server whose database is migrated
+	Server string `json:"server"`
+	// Job applying the upgrade scripts of the upgraded version
+	Job            string                 `json:"job,omitempty"`
+	Phase          DatabaseMigrationPhase `json:"phase"`
+	StartTime      metav1.Time            `json:"startTime,omitempty"`
+	CompletionTime metav1.Time            `json:"completionTime,omitempty"`
+	Message        string                 `json:"message,omitempty"`
+}
+
+// UpgradeAvailable - An upgrade of the product version that is not applied yet
+type UpgradeAvailable struct {
+	// Version the KieApp will be upgraded to
+	Version string `json:"version"`
+	// Why the upgrade is not applied yet
+	Message string `json:"message,omitempty"`
+	// Fields of the configuration changed by the upgrade
+	ConfigDiff []ConfigDiff `json:"configDiff,omitempty"`
+}
+
+// ConfigDiff - A field of a configuration file changed by an upgrade
+type ConfigDiff struct {
+	// ConfigMap of the upgraded version holding the file
+	ConfigMap string `json:"configMap"`
+	File      string `json:"file"`
+	// YAML path of the changed field, empty when the whole file changes
+	Path string `json:"path,omitempty"`
+	// Value of the applied version
+	Applied string `json:"applied,omitempty"`
+	// Value of the upgraded version
+	Upgraded string `json:"upgraded,omitempty"`
 }
@@ -10,9 +10,13 @@ import (
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	apiappsv1 "k8s.io/api/apps/v1"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/api/core/v1"
+	v1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -34,8 +38,8 @@ func (in *AppConstants) DeepCopy() *AppConstants {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthTemplate) DeepCopyInto(out *AuthTemplate) {
 	*out = *in
-	out.SSO = in.SSO
-	out.LDAP = in.LDAP
+	in.SSO.DeepCopyInto(&out.SSO)
+	in.LDAP.DeepCopyInto(&out.LDAP)
 	in.RoleMapper.DeepCopyInto(&out.RoleMapper)
 	return
 }
@@ -50,6 +54,44 @@ func (in *AuthTemplate) DeepCopy() *AuthTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingObject) DeepCopyInto(out *AutoscalingObject) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilization != nil {
+		in, out := &in.TargetCPUUtilization, &out.TargetCPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilization != nil {
+		in, out := &in.TargetMemoryUtilization, &out.TargetMemoryUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2beta2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingObject.
+func (in *AutoscalingObject) DeepCopy() *AutoscalingObject {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildTemplate) DeepCopyInto(out *BuildTemplate) {
 	*out = *in
@@ -71,6 +113,31 @@ func (in *BuildTemplate) DeepCopy() *BuildTemplate {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonConfig) DeepCopyInto(out *CommonConfig) {
 	*out = *in
+	if in.KeyStorePasswordSecret != nil {
+		in, out := &in.KeyStorePasswordSecret, &out.KeyStorePasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminPasswordSecret != nil {
+		in, out := &in.AdminPasswordSecret, &out.AdminPasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DBPasswordSecret != nil {
+		in, out := &in.DBPasswordSecret, &out.DBPasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AMQPasswordSecret != nil {
+		in, out := &in.AMQPasswordSecret, &out.AMQPasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AMQClusterPasswordSecret != nil {
+		in, out := &in.AMQClusterPasswordSecret, &out.AMQClusterPasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -87,7 +154,7 @@ func (in *CommonConfig) DeepCopy() *CommonConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonExtDBObjectRequiredURL) DeepCopyInto(out *CommonExtDBObjectRequiredURL) {
 	*out = *in
-	out.CommonExternalDatabaseObject = in.CommonExternalDatabaseObject
+	in.CommonExternalDatabaseObject.DeepCopyInto(&out.CommonExternalDatabaseObject)
 	return
 }
 
@@ -104,7 +171,7 @@ func (in *CommonExtDBObjectRequiredURL) DeepCopy() *CommonExtDBObjectRequiredURL
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonExtDBObjectURL) DeepCopyInto(out *CommonExtDBObjectURL) {
 	*out = *in
-	out.CommonExternalDatabaseObject = in.CommonExternalDatabaseObject
+	in.CommonExternalDatabaseObject.DeepCopyInto(&out.CommonExternalDatabaseObject)
 	return
 }
 
@@ -121,6 +188,11 @@ func (in *CommonExtDBObjectURL) DeepCopy() *CommonExtDBObjectURL {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonExternalDatabaseObject) DeepCopyInto(out *CommonExternalDatabaseObject) {
 	*out = *in
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -151,6 +223,38 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigConflict) DeepCopyInto(out *ConfigConflict) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigConflict.
+func (in *ConfigConflict) DeepCopy() *ConfigConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigDiff) DeepCopyInto(out *ConfigDiff) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigDiff.
+func (in *ConfigDiff) DeepCopy() *ConfigDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConsoleObject) DeepCopyInto(out *ConsoleObject) {
 	*out = *in
@@ -247,6 +351,13 @@ func (in *CustomObject) DeepCopyInto(out *CustomObject) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Deployments != nil {
+		in, out := &in.Deployments, &out.Deployments
+		*out = make([]apiappsv1.Deployment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.StatefulSets != nil {
 		in, out := &in.StatefulSets, &out.StatefulSets
 		*out = make([]apiappsv1.StatefulSet, len(*in))
@@ -282,6 +393,13 @@ func (in *CustomObject) DeepCopyInto(out *CustomObject) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Ingresses != nil {
+		in, out := &in.Ingresses, &out.Ingresses
+		*out = make([]v1beta1.Ingress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ConfigMaps != nil {
 		in, out := &in.ConfigMaps, &out.ConfigMaps
 		*out = make([]v1.ConfigMap, len(*in))
@@ -289,6 +407,20 @@ func (in *CustomObject) DeepCopyInto(out *CustomObject) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HorizontalPodAutoscalers != nil {
+		in, out := &in.HorizontalPodAutoscalers, &out.HorizontalPodAutoscalers
+		*out = make([]v2beta2.HorizontalPodAutoscaler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodDisruptionBudgets != nil {
+		in, out := &in.PodDisruptionBudgets, &out.PodDisruptionBudgets
+		*out = make([]policyv1beta1.PodDisruptionBudget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -302,14 +434,32 @@ func (in *CustomObject) DeepCopy() *CustomObject {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseMigration) DeepCopyInto(out *DatabaseMigration) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseMigration.
+func (in *DatabaseMigration) DeepCopy() *DatabaseMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseObject) DeepCopyInto(out *DatabaseObject) {
 	*out = *in
-	out.InternalDatabaseObject = in.InternalDatabaseObject
+	in.InternalDatabaseObject.DeepCopyInto(&out.InternalDatabaseObject)
 	if in.ExternalConfig != nil {
 		in, out := &in.ExternalConfig, &out.ExternalConfig
 		*out = new(ExternalDatabaseObject)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -327,7 +477,7 @@ func (in *DatabaseObject) DeepCopy() *DatabaseObject {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseTemplate) DeepCopyInto(out *DatabaseTemplate) {
 	*out = *in
-	out.InternalDatabaseObject = in.InternalDatabaseObject
+	in.InternalDatabaseObject.DeepCopyInto(&out.InternalDatabaseObject)
 	return
 }
 
@@ -347,7 +497,7 @@ func (in *EnvTemplate) DeepCopyInto(out *EnvTemplate) {
 	if in.CommonConfig != nil {
 		in, out := &in.CommonConfig, &out.CommonConfig
 		*out = new(CommonConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	in.Console.DeepCopyInto(&out.Console)
 	if in.Servers != nil {
@@ -450,7 +600,7 @@ func (in *EnvironmentConstants) DeepCopy() *EnvironmentConstants {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDatabaseObject) DeepCopyInto(out *ExternalDatabaseObject) {
 	*out = *in
-	out.CommonExtDBObjectURL = in.CommonExtDBObjectURL
+	in.CommonExtDBObjectURL.DeepCopyInto(&out.CommonExtDBObjectURL)
 	return
 }
 
@@ -521,6 +671,7 @@ func (in *ImageObjRef) DeepCopy() *ImageObjRef {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InternalDatabaseObject) DeepCopyInto(out *InternalDatabaseObject) {
 	*out = *in
+	in.SchedulingObject.DeepCopyInto(&out.SchedulingObject)
 	return
 }
 
@@ -639,12 +790,12 @@ func (in *KieAppAuthObject) DeepCopyInto(out *KieAppAuthObject) {
 	if in.SSO != nil {
 		in, out := &in.SSO, &out.SSO
 		*out = new(SSOAuthConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.LDAP != nil {
 		in, out := &in.LDAP, &out.LDAP
 		*out = new(LDAPAuthConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RoleMapper != nil {
 		in, out := &in.RoleMapper, &out.RoleMapper
@@ -704,6 +855,12 @@ func (in *KieAppJmsObject) DeepCopyInto(out *KieAppJmsObject) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.SchedulingObject.DeepCopyInto(&out.SchedulingObject)
 	return
 }
 
@@ -760,6 +917,51 @@ func (in *KieAppObject) DeepCopyInto(out *KieAppObject) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraContainers != nil {
+		in, out := &in.ExtraContainers, &out.ExtraContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(int32)
@@ -770,6 +972,23 @@ func (in *KieAppObject) DeepCopyInto(out *KieAppObject) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.SchedulingObject.DeepCopyInto(&out.SchedulingObject)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetObject)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(ProbesObject)
+		(*in).DeepCopyInto(*out)
+	}
+	in.MetadataObject.DeepCopyInto(&out.MetadataObject)
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(RouteObject)
+		**out = **in
+	}
 	return
 }
 
@@ -804,6 +1023,16 @@ func (in *KieAppObjects) DeepCopyInto(out *KieAppObjects) {
 		*out = new(ProcessMigrationObject)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Datagrid != nil {
+		in, out := &in.Datagrid, &out.Datagrid
+		*out = new(SchedulingObject)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Broker != nil {
+		in, out := &in.Broker, &out.Broker
+		*out = new(SchedulingObject)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -842,13 +1071,27 @@ func (in *KieAppSpec) DeepCopyInto(out *KieAppSpec) {
 		**out = **in
 	}
 	in.Objects.DeepCopyInto(&out.Objects)
-	out.Upgrades = in.Upgrades
-	out.CommonConfig = in.CommonConfig
+	in.Upgrades.DeepCopyInto(&out.Upgrades)
+	in.CommonConfig.DeepCopyInto(&out.CommonConfig)
 	if in.Auth != nil {
 		in, out := &in.Auth, &out.Auth
 		*out = new(KieAppAuthObject)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -874,6 +1117,40 @@ func (in *KieAppStatus) DeepCopyInto(out *KieAppStatus) {
 	}
 	in.Deployments.DeepCopyInto(&out.Deployments)
 	in.Applied.DeepCopyInto(&out.Applied)
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]ResourceConflict, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]ResourceDrift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpgradeConflicts != nil {
+		in, out := &in.UpgradeConflicts, &out.UpgradeConflicts
+		*out = make([]ConfigConflict, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpgradeHistory != nil {
+		in, out := &in.UpgradeHistory, &out.UpgradeHistory
+		*out = make([]UpgradeRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpgradeAvailable != nil {
+		in, out := &in.UpgradeAvailable, &out.UpgradeAvailable
+		*out = new(UpgradeAvailable)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradePath != nil {
+		in, out := &in.UpgradePath, &out.UpgradePath
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -890,6 +1167,11 @@ func (in *KieAppStatus) DeepCopy() *KieAppStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KieAppUpgrades) DeepCopyInto(out *KieAppUpgrades) {
 	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(UpgradeWindow)
+		**out = **in
+	}
 	return
 }
 
@@ -958,6 +1240,11 @@ func (in *KieServerSet) DeepCopyInto(out *KieServerSet) {
 		*out = new(JvmObject)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingObject)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -974,6 +1261,11 @@ func (in *KieServerSet) DeepCopy() *KieServerSet {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LDAPAuthConfig) DeepCopyInto(out *LDAPAuthConfig) {
 	*out = *in
+	if in.BindCredentialSecret != nil {
+		in, out := &in.BindCredentialSecret, &out.BindCredentialSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -987,6 +1279,50 @@ func (in *LDAPAuthConfig) DeepCopy() *LDAPAuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataObject) DeepCopyInto(out *MetadataObject) {
+	*out = *in
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RouteAnnotations != nil {
+		in, out := &in.RouteAnnotations, &out.RouteAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataObject.
+func (in *MetadataObject) DeepCopy() *MetadataObject {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjRef) DeepCopyInto(out *ObjRef) {
 	*out = *in
@@ -1020,14 +1356,71 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetObject) DeepCopyInto(out *PodDisruptionBudgetObject) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetObject.
+func (in *PodDisruptionBudgetObject) DeepCopy() *PodDisruptionBudgetObject {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbesObject) DeepCopyInto(out *ProbesObject) {
+	*out = *in
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbesObject.
+func (in *ProbesObject) DeepCopy() *ProbesObject {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbesObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessMigrationDatabaseObject) DeepCopyInto(out *ProcessMigrationDatabaseObject) {
 	*out = *in
-	out.InternalDatabaseObject = in.InternalDatabaseObject
+	in.InternalDatabaseObject.DeepCopyInto(&out.InternalDatabaseObject)
 	if in.ExternalConfig != nil {
 		in, out := &in.ExternalConfig, &out.ExternalConfig
 		*out = new(CommonExtDBObjectRequiredURL)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -1046,6 +1439,13 @@ func (in *ProcessMigrationDatabaseObject) DeepCopy() *ProcessMigrationDatabaseOb
 func (in *ProcessMigrationObject) DeepCopyInto(out *ProcessMigrationObject) {
 	*out = *in
 	in.Database.DeepCopyInto(&out.Database)
+	in.SchedulingObject.DeepCopyInto(&out.SchedulingObject)
+	in.MetadataObject.DeepCopyInto(&out.MetadataObject)
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(RouteObject)
+		**out = **in
+	}
 	return
 }
 
@@ -1116,6 +1516,43 @@ func (in *Replicas) DeepCopy() *Replicas {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceConflict) DeepCopyInto(out *ResourceConflict) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceConflict.
+func (in *ResourceConflict) DeepCopy() *ResourceConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceDrift) DeepCopyInto(out *ResourceDrift) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDrift.
+func (in *ResourceDrift) DeepCopy() *ResourceDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleMapperAuthConfig) DeepCopyInto(out *RoleMapperAuthConfig) {
 	*out = *in
@@ -1154,6 +1591,22 @@ func (in *RoleMapperTemplate) DeepCopy() *RoleMapperTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteObject) DeepCopyInto(out *RouteObject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteObject.
+func (in *RouteObject) DeepCopy() *RouteObject {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSOAuthClient) DeepCopyInto(out *SSOAuthClient) {
 	*out = *in
@@ -1173,6 +1626,11 @@ func (in *SSOAuthClient) DeepCopy() *SSOAuthClient {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSOAuthConfig) DeepCopyInto(out *SSOAuthConfig) {
 	*out = *in
+	if in.AdminPasswordSecret != nil {
+		in, out := &in.AdminPasswordSecret, &out.AdminPasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1186,6 +1644,48 @@ func (in *SSOAuthConfig) DeepCopy() *SSOAuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingObject) DeepCopyInto(out *SchedulingObject) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingObject.
+func (in *SchedulingObject) DeepCopy() *SchedulingObject {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerTemplate) DeepCopyInto(out *ServerTemplate) {
 	*out = *in
@@ -1213,6 +1713,11 @@ func (in *ServerTemplate) DeepCopy() *ServerTemplate {
 func (in *SmartRouterObject) DeepCopyInto(out *SmartRouterObject) {
 	*out = *in
 	in.KieAppObject.DeepCopyInto(&out.KieAppObject)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingObject)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1258,6 +1763,68 @@ func (in *TemplateConstants) DeepCopy() *TemplateConstants {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeAvailable) DeepCopyInto(out *UpgradeAvailable) {
+	*out = *in
+	if in.ConfigDiff != nil {
+		in, out := &in.ConfigDiff, &out.ConfigDiff
+		*out = make([]ConfigDiff, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeAvailable.
+func (in *UpgradeAvailable) DeepCopy() *UpgradeAvailable {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeAvailable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRecord) DeepCopyInto(out *UpgradeRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.DatabaseMigrations != nil {
+		in, out := &in.DatabaseMigrations, &out.DatabaseMigrations
+		*out = make([]DatabaseMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRecord.
+func (in *UpgradeRecord) DeepCopy() *UpgradeRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeWindow) DeepCopyInto(out *UpgradeWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeWindow.
+func (in *UpgradeWindow) DeepCopy() *UpgradeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VersionConfigs) DeepCopyInto(out *VersionConfigs) {
 	*out = *in
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/mod/semver"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -181,6 +182,7 @@ func GetRole(operatorName string) *rbacv1.Role {
 				},
 				Resources: []string{
 					"configmaps",
+					"events",
 					"pods",
 					"services",
 					"services/finalizers",
@@ -230,6 +232,15 @@ func GetRole(operatorName string) *rbacv1.Role {
 				},
 				Verbs: Verbs,
 			},
+			{
+				APIGroups: []string{
+					networkingv1beta1.SchemeGroupVersion.Group,
+				},
+				Resources: []string{
+					"ingresses",
+				},
+				Verbs: Verbs,
+			},
 			{
 				APIGroups: []string{
 					buildv1.SchemeGroupVersion.Group,
@@ -1,12 +1,16 @@
 package controller
 
 import (
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 // AddToManagerFuncs is a list of functions to add all Controllers to the Manager
 var AddToManagerFuncs []func(manager.Manager) error
 
+// Options tune the KieApp controller, e.g. from the flags of the operator
+var Options = kieapp.DefaultOptions()
+
 // AddToManager adds all Controllers to the Manager
 func AddToManager(mgr manager.Manager) error {
 	for _, functions := range AddToManagerFuncs {
@@ -0,0 +1,48 @@
+package kieapp
+
+import (
+	"reflect"
+
+	"github.com/RHsyseng/operator-utils/pkg/resource"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+)
+
+// setAutoscaledReplicas sets the requested replicas of the DeploymentConfigs and Deployments targeted by a requested
+// HorizontalPodAutoscaler to the deployed ones, so that the operator does not revert the replicas set by the autoscaler.
+// Targets scaled down to zero are still reverted, as the autoscaler never scales them to zero.
+func setAutoscaledReplicas(requested []resource.KubernetesResource, deployed map[reflect.Type][]resource.KubernetesResource) {
+	autoscaled := map[string]bool{}
+	for _, res := range requested {
+		if hpa, ok := res.(*autoscalingv2beta2.HorizontalPodAutoscaler); ok {
+			autoscaled[hpa.Spec.ScaleTargetRef.Kind+"/"+hpa.Spec.ScaleTargetRef.Name] = true
+		}
+	}
+	if len(autoscaled) == 0 {
+		return
+	}
+	for _, res := range requested {
+		switch target := res.(type) {
+		case *oappsv1.DeploymentConfig:
+			if !autoscaled["DeploymentConfig/"+target.Name] {
+				continue
+			}
+			for _, deployedRes := range deployed[reflect.TypeOf(oappsv1.DeploymentConfig{})] {
+				if dc := deployedRes.(*oappsv1.DeploymentConfig); dc.Name == target.Name && dc.Spec.Replicas != 0 {
+					target.Spec.Replicas = dc.Spec.Replicas
+				}
+			}
+		case *appsv1.Deployment:
+			if !autoscaled["Deployment/"+target.Name] {
+				continue
+			}
+			for _, deployedRes := range deployed[reflect.TypeOf(appsv1.Deployment{})] {
+				if deployment := deployedRes.(*appsv1.Deployment); deployment.Name == target.Name && deployment.Spec.Replicas != nil && *deployment.Spec.Replicas != 0 {
+					target.Spec.Replicas = defaults.Pint32(*deployment.Spec.Replicas)
+				}
+			}
+		}
+	}
+}
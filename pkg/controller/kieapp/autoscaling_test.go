@@ -0,0 +1,76 @@
+package kieapp
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileAutoscaling(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "autoscaling")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+		Objects: api.KieAppObjects{
+			Servers: []api.KieServerSet{{Autoscaling: &api.AutoscalingObject{MaxReplicas: 3}}},
+		},
+	}
+	service := test.MockService()
+	assert.Nil(t, service.Create(context.TODO(), cr))
+	reconciler := Reconciler{Service: service}
+	for i := 0; i < 3; i++ {
+		_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		assert.Nil(t, err)
+	}
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	assert.Nil(t, service.Get(context.TODO(), getNamespacedName("testns", "autoscaling-kieserver"), hpa))
+	assert.Equal(t, "autoscaling-kieserver", hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, "autoscaling", hpa.OwnerReferences[0].Name, "The HPA should be owned by the KieApp")
+
+	// The HPA scales the KIE Server, which has no available replica yet
+	dc := &oappsv1.DeploymentConfig{}
+	assert.Nil(t, service.Get(context.TODO(), getNamespacedName("testns", "autoscaling-kieserver"), dc))
+	dc.Spec.Replicas = 3
+	dc.Status.AvailableReplicas = 0
+	assert.Nil(t, service.Update(context.TODO(), dc))
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "autoscaling-kieserver",
+			Namespace:       "testns",
+			Labels:          map[string]string{constants.KieServerCMLabel: "USED"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DeploymentConfig", Name: "autoscaling-kieserver"}},
+		},
+	}
+	assert.Nil(t, service.Create(context.TODO(), cm))
+
+	_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.Nil(t, service.Get(context.TODO(), getNamespacedName("testns", "autoscaling-kieserver"), dc))
+	assert.Equal(t, int32(3), dc.Spec.Replicas, "The replicas set by the HPA should be retained")
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Empty(t, cr.Status.Drift, "The replicas set by the HPA should not be reported as drift")
+	assert.Nil(t, service.Get(context.TODO(), getNamespacedName("testns", "autoscaling-kieserver"), cm))
+	assert.Equal(t, "USED", cm.Labels[constants.KieServerCMLabel], "An autoscaled KIE Server should not be detached")
+
+	// Scaling the set to zero removes the HPA and detaches the KIE Server
+	cr.Spec.Objects.Servers[0].Replicas = defaults.Pint32(0)
+	assert.Nil(t, service.Update(context.TODO(), cr))
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.NotNil(t, service.Get(context.TODO(), getNamespacedName("testns", "autoscaling-kieserver"), hpa), "The HPA should be removed")
+	assert.Nil(t, service.Get(context.TODO(), getNamespacedName("testns", "autoscaling-kieserver"), dc))
+	assert.Equal(t, int32(0), dc.Spec.Replicas)
+	assert.Nil(t, service.Get(context.TODO(), getNamespacedName("testns", "autoscaling-kieserver"), cm))
+	assert.Equal(t, "DETACHED", cm.Labels[constants.KieServerCMLabel])
+}
@@ -15,6 +15,110 @@ const (
 	PriorVersion2 = "7.8.0"
 )
 
+const (
+	// EventCreated reason of the event recorded when a resource is created
+	EventCreated = "Created"
+	// EventUpdated reason of the event recorded when a resource is updated
+	EventUpdated = "Updated"
+	// EventDeleted reason of the event recorded when a resource is deleted
+	EventDeleted = "Deleted"
+	// EventCreateFailed reason of the event recorded when a resource can't be created
+	EventCreateFailed = "CreateFailed"
+	// EventUpdateFailed reason of the event recorded when a resource can't be updated
+	EventUpdateFailed = "UpdateFailed"
+	// EventDeleteFailed reason of the event recorded when a resource can't be deleted
+	EventDeleteFailed = "DeleteFailed"
+	// EventKeystoreGenerated reason of the event recorded when a keystore secret is generated
+	EventKeystoreGenerated = "KeystoreGenerated"
+	// EventKeystoreRegenerated reason of the event recorded when an existing keystore secret is no longer valid
+	EventKeystoreRegenerated = "KeystoreRegenerated"
+	// EventUpgradeStarted reason of the event recorded when the product version is upgraded
+	EventUpgradeStarted = "UpgradeStarted"
+	// EventUpgradeCompleted reason of the event recorded when the upgraded version is deployed
+	EventUpgradeCompleted = "UpgradeCompleted"
+	// EventUpgradeAvailable reason of the event recorded when an upgrade waits for approval or for the upgrade window
+	EventUpgradeAvailable = "UpgradeAvailable"
+	// EventUpgradeFailed reason of the event recorded when the rollout of the upgraded version fails
+	EventUpgradeFailed = "UpgradeFailed"
+	// EventUpgradeRolledBack reason of the event recorded when the version upgraded from is restored
+	EventUpgradeRolledBack = "UpgradeRolledBack"
+	// EventDatabaseMigrationStarted reason of the event recorded when the database of a KIE Server is migrated to the upgraded version
+	EventDatabaseMigrationStarted = "DatabaseMigrationStarted"
+	// EventDatabaseMigrationSkipped reason of the event recorded when the database of a KIE Server must be migrated manually
+	EventDatabaseMigrationSkipped = "DatabaseMigrationSkipped"
+	// EventApplyConflict reason of the event recorded when a field of a resource is owned by another manager
+	EventApplyConflict = "ApplyConflict"
+	// EventPaused reason of the event recorded when the reconciliation of a KieApp is paused
+	EventPaused = "Paused"
+	// EventResumed reason of the event recorded when the reconciliation of a paused KieApp is resumed
+	EventResumed = "Resumed"
+)
+
+const (
+	// FieldManager name of the field manager the operator applies its resources with
+	FieldManager = "kie-cloud-operator"
+)
+
+const (
+	// WebhookPort port the admission webhooks are served on
+	WebhookPort = 9443
+	// WebhookCertDir directory where OLM mounts the serving certificate of the webhooks
+	WebhookCertDir = "/apiserver.local.config/certificates"
+	// WebhookCertName name of the serving certificate mounted by OLM
+	WebhookCertName = "apiserver.crt"
+	// WebhookKeyName name of the serving key mounted by OLM
+	WebhookKeyName = "apiserver.key"
+	// ValidatingWebhookPath path of the KieApp validating webhook
+	ValidatingWebhookPath = "/validate-app-kiegroup-org-v2-kieapp"
+	// MutatingWebhookPath path of the KieApp defaulting webhook
+	MutatingWebhookPath = "/mutate-app-kiegroup-org-v2-kieapp"
+)
+
+const (
+	// CredentialsSecret is the format of the name of the Secret holding the credentials of a KieApp
+	CredentialsSecret = "%s-credentials"
+	// CredentialsAdminPassword key of the adminUser password in the credentials Secret
+	CredentialsAdminPassword = "admin-password"
+	// CredentialsKeyStorePassword key of the keystore password in the credentials Secret
+	CredentialsKeyStorePassword = "keystore-password"
+	// CredentialsDBPassword key of the databases password in the credentials Secret
+	CredentialsDBPassword = "db-password"
+	// CredentialsAMQPassword key of the amq user password in the credentials Secret
+	CredentialsAMQPassword = "amq-password"
+	// CredentialsAMQClusterPassword key of the amq cluster user password in the credentials Secret
+	CredentialsAMQClusterPassword = "amq-cluster-password"
+	// CredentialsSSOAdminPassword key of the RH-SSO Realm Admin Password in the credentials Secret
+	CredentialsSSOAdminPassword = "sso-admin-password"
+	// CredentialsLDAPBindCredential key of the LDAP bind credential in the credentials Secret
+	CredentialsLDAPBindCredential = "ldap-bind-credential"
+	// CredentialsJmsPassword is the format of the key of the AMQ broker password of a KieServer in the credentials Secret
+	CredentialsJmsPassword = "%s-jms-password"
+	// CredentialsExternalDBPassword is the format of the key of the external database password of a KieServer in the credentials Secret
+	CredentialsExternalDBPassword = "%s-db-password"
+	// CredentialsProcessMigrationDBPassword key of the external database password of the Process Migration in the credentials Secret
+	CredentialsProcessMigrationDBPassword = "process-migration-db-password"
+)
+
+const (
+	// UpgradeSnapshotSecret is the format of the name of the Secret holding the snapshot of a KieApp taken before upgrading from a version
+	UpgradeSnapshotSecret = "%s-upgrade-%s"
+	// UpgradeSnapshotApplied key of the applied spec, without credentials, in the upgrade snapshot Secret
+	UpgradeSnapshotApplied = "applied.yaml"
+	// UpgradeSnapshotResources key of the deployed resources in the upgrade snapshot Secret
+	UpgradeSnapshotResources = "resources.yaml"
+	// MaxUpgradeHistory is the number of upgrades kept in the status of a KieApp
+	MaxUpgradeHistory = 10
+	// DatabaseMigrationJob is the format of the name of the Job migrating the database of a KIE Server to a version
+	DatabaseMigrationJob = "%s-migration-%s"
+	// DatabaseMigrationScripts is the directory of the KIE Server image holding the upgrade scripts of its database
+	// schema, by database type and version upgraded from, e.g. mysql/7.8.1/*.sql
+	DatabaseMigrationScripts = "/opt/kie/upgrade-scripts"
+	// DatabaseMigrationBackoffLimit is the number of retries of a failed database migration Job
+	DatabaseMigrationBackoffLimit = 2
+	// DatabaseMigrationDeadlineSeconds is the time after which a database migration Job that didn't complete fails
+	DatabaseMigrationDeadlineSeconds = 3600
+)
+
 // SupportedVersions - product versions this operator supports
 var SupportedVersions = []string{CurrentVersion, PriorVersion1, PriorVersion2}
 
@@ -65,10 +169,62 @@ const (
 	// OpUIEnv is an environment variable indicating whether the UI should be deployed
 	// Default behavior is to deploy the UI, unless this variable is provided with a false value
 	OpUIEnv = "OPERATOR_UI"
+	// MaxConcurrentReconcilesEnv is an environment variable of the number of KieApps reconciled concurrently
+	MaxConcurrentReconcilesEnv = "MAX_CONCURRENT_RECONCILES"
+	// RateLimiterBaseDelayEnv is an environment variable of the delay before a failed KieApp is reconciled again
+	RateLimiterBaseDelayEnv = "RECONCILE_BASE_DELAY"
+	// RateLimiterMaxDelayEnv is an environment variable of the maximum delay the backoff of a failing KieApp grows to
+	RateLimiterMaxDelayEnv = "RECONCILE_MAX_DELAY"
+	// RateLimiterQPSEnv is an environment variable of the overall number of reconciliations queued per second
+	RateLimiterQPSEnv = "RECONCILE_QPS"
+	// RateLimiterBurstEnv is an environment variable of the overall number of reconciliations queued in a burst
+	RateLimiterBurstEnv = "RECONCILE_BURST"
+	// RouteRequeueIntervalEnv is an environment variable of the delay before checking the hostnames of the created routes
+	RouteRequeueIntervalEnv = "ROUTE_REQUEUE_INTERVAL"
+	// KeystoreRequeueIntervalEnv is an environment variable of the delay before retrying to generate the keystores
+	KeystoreRequeueIntervalEnv = "KEYSTORE_REQUEUE_INTERVAL"
+	// CreateRequeueIntervalEnv is an environment variable of the delay before checking an object the operator created
+	CreateRequeueIntervalEnv = "CREATE_REQUEUE_INTERVAL"
+	// KieAppSelectorEnv is an environment variable of the label selector of the KieApps handled by the operator
+	KieAppSelectorEnv = "KIEAPP_SELECTOR"
 	// TrialEnvSuffix is the suffix for trial environments
 	TrialEnvSuffix = "trial"
 	// DefaultKieDeployments default number of Kie Server deployments
 	DefaultKieDeployments = 1
+	// DefaultTargetCPUUtilization default average CPU utilization targeted by autoscaling, when no metric is set
+	DefaultTargetCPUUtilization = 80
+	// TopologyZoneLabel the node label of the zone pods are spread across in HA environments
+	TopologyZoneLabel = "topology.kubernetes.io/zone"
+	// StartupProbeContainers minimum number of KIE containers deployed by a KIE Server for its startup probe to be defaulted
+	StartupProbeContainers = 3
+	// StartupProbeFailuresPerContainer failures of the default startup probe of a KIE Server allowed for each of its KIE containers
+	StartupProbeFailuresPerContainer = 6
+	// StartupProbePeriodSeconds period of the default startup probe of a KIE Server
+	StartupProbePeriodSeconds = 10
+	// KieServerContainerDeploymentEnv environment variable listing the KIE containers deployed by a KIE Server
+	KieServerContainerDeploymentEnv = "KIE_SERVER_CONTAINER_DEPLOYMENT"
+	// DefaultQueueExecutor default JNDI name of the JMS executor queue
+	DefaultQueueExecutor = "queue/KIE.SERVER.EXECUTOR"
+	// DefaultQueueRequest default JNDI name of the JMS request queue
+	DefaultQueueRequest = "queue/KIE.SERVER.REQUEST"
+	// DefaultQueueResponse default JNDI name of the JMS response queue
+	DefaultQueueResponse = "queue/KIE.SERVER.RESPONSE"
+	// DefaultQueueSignal default JNDI name of the JMS signal queue
+	DefaultQueueSignal = "queue/KIE.SERVER.SIGNAL"
+	// DefaultQueueAudit default JNDI name of the JMS audit queue
+	DefaultQueueAudit = "queue/KIE.SERVER.AUDIT"
+	// KieAppFinalizer is the finalizer used to clean up resources not garbage collected with the KieApp
+	KieAppFinalizer = "kieapp.app.kiegroup.org/cleanup"
+	// KieAppOwnerAnnotation marks the non-owned resources created on behalf of a KieApp
+	KieAppOwnerAnnotation = "kieapp.app.kiegroup.org/owner"
+	// PausedAnnotation pauses the reconciliation of the KieApp when set to true
+	PausedAnnotation = "kieapp.app.kiegroup.org/paused"
+	// ApprovedVersionAnnotation approves the upgrade of a KieApp with manual upgrade approval to the version it is set to
+	ApprovedVersionAnnotation = "kieapp.app.kiegroup.org/approved-version"
+	// IngressSSLPassthroughAnnotation lets the ingress controller pass TLS traffic through to the service, like a passthrough Route
+	IngressSSLPassthroughAnnotation = "nginx.ingress.kubernetes.io/ssl-passthrough"
+	// IngressBackendProtocolAnnotation sets the protocol the ingress controller uses to reach the service
+	IngressBackendProtocolAnnotation = "nginx.ingress.kubernetes.io/backend-protocol"
 	// KeystoreSecret is the default format for keystore secret names
 	KeystoreSecret = "%s-app-secret"
 	// KeystoreVolumeSuffix Suffix for the keystore volumes and volumeMounts name
@@ -77,6 +233,8 @@ const (
 	KeystoreAlias = "jboss"
 	// KeystoreName used when creating Secret
 	KeystoreName = "keystore.jks"
+	// TLSCACertKey is the key of the CA certificate in a kubernetes.io/tls Secret
+	TLSCACertKey = "ca.crt"
 	// DatabaseVolumeSuffix Suffix to use for any database volume and volumeMounts
 	DatabaseVolumeSuffix = "pvol"
 	// DefaultDatabaseSize Default Database Persistence size
@@ -47,7 +47,7 @@ func configureAuth(cr *api.KieApp, envTemplate *api.EnvTemplate) (err error) {
 	} else if cr.Spec.Auth.SSO != nil {
 		err = configureSSO(cr, envTemplate)
 	} else if cr.Spec.Auth.LDAP != nil {
-		err = configureLDAP(cr.Spec.Auth.LDAP, envTemplate)
+		err = configureLDAP(cr.Status.Applied.Auth.LDAP, envTemplate)
 	}
 	if cr.Spec.Auth.RoleMapper != nil {
 		configureRoleMapper(cr.Spec.Auth.RoleMapper, envTemplate)
@@ -199,8 +199,7 @@ func TestAuthSSOConfig(t *testing.T) {
 		{Name: "SSO_PRINCIPAL_ATTRIBUTE", Value: "preferred_username"},
 		{Name: "SSO_DISABLE_SSL_CERTIFICATE_VALIDATION", Value: "false"},
 		{Name: "SSO_USERNAME"},
-		{Name: "SSO_PASSWORD"},
-		{Name: "SSO_PASSWORD"},
+		getCredentialsEnv("SSO_PASSWORD", "test", constants.CredentialsSSOAdminPassword),
 	}
 	for _, expectedEnv := range expectedEnvs {
 		assert.Contains(t, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Env, expectedEnv, "Console should contain env %v", expectedEnv)
@@ -276,7 +275,7 @@ func TestAuthSSOConfigWithClients(t *testing.T) {
 		{Name: "SSO_PRINCIPAL_ATTRIBUTE", Value: "preferred_username"},
 		{Name: "SSO_DISABLE_SSL_CERTIFICATE_VALIDATION", Value: "false"},
 		{Name: "SSO_USERNAME"},
-		{Name: "SSO_PASSWORD"},
+		getCredentialsEnv("SSO_PASSWORD", "test", constants.CredentialsSSOAdminPassword),
 	}
 	for _, expectedEnv := range expectedEnvs {
 		assert.Contains(t, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Env, expectedEnv, "Console does not contain env %v", expectedEnv)
@@ -357,7 +356,7 @@ func TestAuthLDAPConfig(t *testing.T) {
 	expectedEnvs := []corev1.EnvVar{
 		{Name: "AUTH_LDAP_URL", Value: "ldaps://ldap.example.com"},
 		{Name: "AUTH_LDAP_BIND_DN", Value: "cn=admin,dc=example,dc=com"},
-		{Name: "AUTH_LDAP_BIND_CREDENTIAL"},
+		getCredentialsEnv("AUTH_LDAP_BIND_CREDENTIAL", "test", constants.CredentialsLDAPBindCredential),
 	}
 	for _, expectedEnv := range expectedEnvs {
 		assert.Contains(t, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Env, expectedEnv, "Console does not contain env %v", expectedEnv)
@@ -528,7 +527,7 @@ func TestAuthRoleMapperConfig(t *testing.T) {
 		expectedEnvs := []corev1.EnvVar{
 			{Name: "AUTH_LDAP_URL", Value: "ldaps://ldap.example.com"},
 			{Name: "AUTH_LDAP_BIND_DN", Value: "cn=admin,dc=example,dc=com"},
-			{Name: "AUTH_LDAP_BIND_CREDENTIAL"},
+			getCredentialsEnv("AUTH_LDAP_BIND_CREDENTIAL", "test", constants.CredentialsLDAPBindCredential),
 			{Name: "AUTH_ROLE_MAPPER_ROLES_PROPERTIES", Value: item.expectedPath},
 			{Name: "AUTH_ROLE_MAPPER_REPLACE_ROLE", Value: strconv.FormatBool(item.roleMapper.ReplaceRole)},
 		}
@@ -0,0 +1,114 @@
+package defaults
+
+import (
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setAutoscalers adds a HorizontalPodAutoscaler for each KIE Server and smart router with autoscaling configured
+func setAutoscalers(env api.Environment, cr *api.KieApp) api.Environment {
+	for index := range env.Servers {
+		serverSet, kieName := GetServerSet(cr, index)
+		if serverSet.Autoscaling != nil {
+			env.Servers[index] = setAutoscaler(env.Servers[index], kieName, serverSet.Autoscaling)
+		}
+	}
+	if smartRouter := cr.Status.Applied.Objects.SmartRouter; smartRouter != nil && smartRouter.Autoscaling != nil {
+		env.SmartRouter = setAutoscaler(env.SmartRouter, cr.Status.Applied.CommonConfig.ApplicationName+"-smartrouter", smartRouter.Autoscaling)
+	}
+	return env
+}
+
+// setAutoscaler adds a HorizontalPodAutoscaler targeting the named DeploymentConfig or Deployment of the object,
+// whose replicas are kept within the autoscaling limits. Objects scaled to zero are not autoscaled, so that they can be detached.
+func setAutoscaler(object api.CustomObject, name string, autoscaling *api.AutoscalingObject) api.CustomObject {
+	minReplicas := int32(1)
+	if autoscaling.MinReplicas != nil {
+		minReplicas = *autoscaling.MinReplicas
+	}
+	for index := range object.DeploymentConfigs {
+		dc := &object.DeploymentConfigs[index]
+		if dc.Name != name || dc.Spec.Replicas == 0 {
+			continue
+		}
+		dc.Spec.Replicas = limitReplicas(dc.Spec.Replicas, minReplicas, autoscaling.MaxReplicas)
+		target := autoscalingv2beta2.CrossVersionObjectReference{APIVersion: oappsv1.GroupVersion.String(), Kind: "DeploymentConfig", Name: name}
+		object.HorizontalPodAutoscalers = append(object.HorizontalPodAutoscalers, getAutoscaler(dc.ObjectMeta, target, minReplicas, autoscaling))
+	}
+	for index := range object.Deployments {
+		deployment := &object.Deployments[index]
+		if deployment.Name != name || deployment.Spec.Replicas == nil || *deployment.Spec.Replicas == 0 {
+			continue
+		}
+		deployment.Spec.Replicas = Pint32(limitReplicas(*deployment.Spec.Replicas, minReplicas, autoscaling.MaxReplicas))
+		target := autoscalingv2beta2.CrossVersionObjectReference{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment", Name: name}
+		object.HorizontalPodAutoscalers = append(object.HorizontalPodAutoscalers, getAutoscaler(deployment.ObjectMeta, target, minReplicas, autoscaling))
+	}
+	return object
+}
+
+func getAutoscaler(targetMeta metav1.ObjectMeta, target autoscalingv2beta2.CrossVersionObjectReference, minReplicas int32, autoscaling *api.AutoscalingObject) autoscalingv2beta2.HorizontalPodAutoscaler {
+	labels := map[string]string{}
+	for key, value := range targetMeta.Labels {
+		labels[key] = value
+	}
+	return autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   targetMeta.Name,
+			Labels: labels,
+		},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: target,
+			MinReplicas:    Pint32(minReplicas),
+			MaxReplicas:    autoscaling.MaxReplicas,
+			Metrics:        getAutoscalerMetrics(autoscaling),
+		},
+	}
+}
+
+// getAutoscalerMetrics returns the CPU and memory utilization targets followed by the additional metrics,
+// or the default CPU utilization target if none is set
+func getAutoscalerMetrics(autoscaling *api.AutoscalingObject) []autoscalingv2beta2.MetricSpec {
+	var metrics []autoscalingv2beta2.MetricSpec
+	if autoscaling.TargetCPUUtilization != nil {
+		metrics = append(metrics, getUtilizationMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilization))
+	}
+	if autoscaling.TargetMemoryUtilization != nil {
+		metrics = append(metrics, getUtilizationMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilization))
+	}
+	for _, metric := range autoscaling.Metrics {
+		metrics = append(metrics, *metric.DeepCopy())
+	}
+	if len(metrics) == 0 {
+		metrics = append(metrics, getUtilizationMetric(corev1.ResourceCPU, constants.DefaultTargetCPUUtilization))
+	}
+	return metrics
+}
+
+func getUtilizationMetric(resourceName corev1.ResourceName, utilization int32) autoscalingv2beta2.MetricSpec {
+	return autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name: resourceName,
+			Target: autoscalingv2beta2.MetricTarget{
+				Type:               autoscalingv2beta2.UtilizationMetricType,
+				AverageUtilization: Pint32(utilization),
+			},
+		},
+	}
+}
+
+func limitReplicas(replicas, minReplicas, maxReplicas int32) int32 {
+	if replicas < minReplicas {
+		return minReplicas
+	}
+	if replicas > maxReplicas {
+		return maxReplicas
+	}
+	return replicas
+}
@@ -0,0 +1,108 @@
+package defaults
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAutoscaling(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamProduction,
+			Objects: api.KieAppObjects{
+				Servers: []api.KieServerSet{
+					{
+						Name:        "scaled",
+						Deployments: Pint(2),
+						Autoscaling: &api.AutoscalingObject{
+							MinReplicas:             Pint32(3),
+							MaxReplicas:             5,
+							TargetMemoryUtilization: Pint32(70),
+							Metrics: []autoscalingv2beta2.MetricSpec{{
+								Type: autoscalingv2beta2.PodsMetricSourceType,
+								Pods: &autoscalingv2beta2.PodsMetricSource{Metric: autoscalingv2beta2.MetricIdentifier{Name: "requests"}},
+							}},
+						},
+					},
+					{
+						Name:         "detached",
+						KieAppObject: api.KieAppObject{Replicas: Pint32(0)},
+						Autoscaling:  &api.AutoscalingObject{MaxReplicas: 2},
+					},
+					{Name: "fixed"},
+				},
+				SmartRouter: &api.SmartRouterObject{
+					Autoscaling: &api.AutoscalingObject{MaxReplicas: 2},
+				},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	assert.Len(t, env.Servers, 4)
+	for index, name := range []string{"scaled", "scaled-2"} {
+		server := env.Servers[index]
+		assert.Equal(t, int32(3), server.DeploymentConfigs[0].Spec.Replicas, "Replicas should be raised to the minimum")
+		assert.Len(t, server.HorizontalPodAutoscalers, 1)
+		hpa := server.HorizontalPodAutoscalers[0]
+		assert.Equal(t, name, hpa.Name)
+		assert.Equal(t, server.DeploymentConfigs[0].Labels, hpa.Labels)
+		assert.Equal(t, autoscalingv2beta2.CrossVersionObjectReference{APIVersion: "apps.openshift.io/v1", Kind: "DeploymentConfig", Name: name}, hpa.Spec.ScaleTargetRef)
+		assert.Equal(t, Pint32(3), hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		assert.Len(t, hpa.Spec.Metrics, 2)
+		assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[0].Resource.Name)
+		assert.Equal(t, Pint32(70), hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+		assert.Equal(t, autoscalingv2beta2.PodsMetricSourceType, hpa.Spec.Metrics[1].Type)
+	}
+	assert.Equal(t, "detached", env.Servers[2].DeploymentConfigs[0].Name)
+	assert.Equal(t, int32(0), env.Servers[2].DeploymentConfigs[0].Spec.Replicas)
+	assert.Empty(t, env.Servers[2].HorizontalPodAutoscalers, "Server sets scaled to zero should not be autoscaled")
+	assert.Empty(t, env.Servers[3].HorizontalPodAutoscalers)
+
+	assert.Len(t, env.SmartRouter.HorizontalPodAutoscalers, 1)
+	hpa := env.SmartRouter.HorizontalPodAutoscalers[0]
+	assert.Equal(t, "test-smartrouter", hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, Pint32(1), hpa.Spec.MinReplicas)
+	assert.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name, "CPU utilization should be targeted by default")
+	assert.Equal(t, Pint32(80), hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+}
+
+func TestAutoscalingKubernetes(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment:   api.RhpamTrial,
+			Platform:      api.PlatformKubernetes,
+			IngressDomain: "apps.example.com",
+			Objects: api.KieAppObjects{
+				Servers: []api.KieServerSet{{
+					KieAppObject: api.KieAppObject{Replicas: Pint32(4)},
+					Autoscaling:  &api.AutoscalingObject{MaxReplicas: 2},
+				}},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	deployment := env.Servers[0].Deployments[0]
+	assert.Equal(t, Pint32(2), deployment.Spec.Replicas, "Replicas should be lowered to the maximum")
+	assert.Len(t, env.Servers[0].HorizontalPodAutoscalers, 1)
+	assert.Equal(t, autoscalingv2beta2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment.Name}, env.Servers[0].HorizontalPodAutoscalers[0].Spec.ScaleTargetRef)
+}
@@ -0,0 +1,141 @@
+package defaults
+
+import (
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// setAvailability adds a PodDisruptionBudget for each component deployed with more than one replica and,
+// in the HA environments, spreads their pods across nodes and zones unless an affinity is set
+func setAvailability(env api.Environment, cr *api.KieApp) api.Environment {
+	ha := isHA(cr)
+	env.Console = setObjectAvailability(env.Console, &cr.Status.Applied.Objects.Console.KieAppObject, ha)
+	for index := range env.Servers {
+		serverSet, _ := GetServerSet(cr, index)
+		env.Servers[index] = setObjectAvailability(env.Servers[index], &serverSet.KieAppObject, ha)
+	}
+	if smartRouter := cr.Status.Applied.Objects.SmartRouter; smartRouter != nil {
+		env.SmartRouter = setObjectAvailability(env.SmartRouter, &smartRouter.KieAppObject, ha)
+	}
+	env.ProcessMigration = setObjectAvailability(env.ProcessMigration, nil, ha)
+	for index := range env.Databases {
+		env.Databases[index] = setObjectAvailability(env.Databases[index], nil, ha)
+	}
+	for index := range env.Others {
+		env.Others[index] = setObjectAvailability(env.Others[index], nil, ha)
+	}
+	return env
+}
+
+func setObjectAvailability(object api.CustomObject, appObject *api.KieAppObject, ha bool) api.CustomObject {
+	var budget *api.PodDisruptionBudgetObject
+	if appObject != nil {
+		budget = appObject.PodDisruptionBudget
+	}
+	for index := range object.DeploymentConfigs {
+		dc := &object.DeploymentConfigs[index]
+		if dc.Spec.Template == nil || !isReplicated(object, dc.Name, dc.Spec.Replicas) {
+			continue
+		}
+		selector := getPodSelector(dc.Name, dc.Spec.Template.Labels, &metav1.LabelSelector{MatchLabels: dc.Spec.Selector})
+		setPodAntiAffinity(&dc.Spec.Template.Spec, selector, ha)
+		object.PodDisruptionBudgets = append(object.PodDisruptionBudgets, getPodDisruptionBudget(dc.ObjectMeta, selector, budget))
+	}
+	for index := range object.Deployments {
+		deployment := &object.Deployments[index]
+		if deployment.Spec.Replicas == nil || !isReplicated(object, deployment.Name, *deployment.Spec.Replicas) {
+			continue
+		}
+		selector := getPodSelector(deployment.Name, deployment.Spec.Template.Labels, deployment.Spec.Selector)
+		setPodAntiAffinity(&deployment.Spec.Template.Spec, selector, ha)
+		object.PodDisruptionBudgets = append(object.PodDisruptionBudgets, getPodDisruptionBudget(deployment.ObjectMeta, selector, budget))
+	}
+	for index := range object.StatefulSets {
+		statefulSet := &object.StatefulSets[index]
+		if statefulSet.Spec.Replicas == nil || !isReplicated(object, statefulSet.Name, *statefulSet.Spec.Replicas) {
+			continue
+		}
+		selector := getPodSelector(statefulSet.Name, statefulSet.Spec.Template.Labels, statefulSet.Spec.Selector)
+		setPodAntiAffinity(&statefulSet.Spec.Template.Spec, selector, ha)
+		object.PodDisruptionBudgets = append(object.PodDisruptionBudgets, getPodDisruptionBudget(statefulSet.ObjectMeta, selector, budget))
+	}
+	return object
+}
+
+// isReplicated returns true if more than one replica of the named workload is deployed, or can be by its autoscaler
+func isReplicated(object api.CustomObject, name string, replicas int32) bool {
+	if replicas > 1 {
+		return true
+	}
+	for _, hpa := range object.HorizontalPodAutoscalers {
+		if hpa.Spec.ScaleTargetRef.Name == name && hpa.Spec.MaxReplicas > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// getPodSelector returns a selector of the pods of the named workload only, as some workload selectors also match
+// the pods of other components of the application
+func getPodSelector(name string, podLabels map[string]string, selector *metav1.LabelSelector) *metav1.LabelSelector {
+	if podLabels["deploymentConfig"] == name {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{"deploymentConfig": name}}
+	}
+	return selector.DeepCopy()
+}
+
+// setPodAntiAffinity prefers scheduling the pods on different nodes and zones, unless an affinity is already set
+func setPodAntiAffinity(podSpec *corev1.PodSpec, selector *metav1.LabelSelector, ha bool) {
+	if !ha || podSpec.Affinity != nil {
+		return
+	}
+	podSpec.Affinity = &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight:          100,
+					PodAffinityTerm: corev1.PodAffinityTerm{LabelSelector: selector.DeepCopy(), TopologyKey: corev1.LabelHostname},
+				},
+				{
+					Weight:          50,
+					PodAffinityTerm: corev1.PodAffinityTerm{LabelSelector: selector.DeepCopy(), TopologyKey: constants.TopologyZoneLabel},
+				},
+			},
+		},
+	}
+}
+
+func getPodDisruptionBudget(workloadMeta metav1.ObjectMeta, selector *metav1.LabelSelector, budget *api.PodDisruptionBudgetObject) policyv1beta1.PodDisruptionBudget {
+	labels := map[string]string{}
+	for key, value := range workloadMeta.Labels {
+		labels[key] = value
+	}
+	pdb := policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   workloadMeta.Name,
+			Labels: labels,
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector: selector.DeepCopy(),
+		},
+	}
+	if budget != nil && budget.MinAvailable != nil {
+		minAvailable := *budget.MinAvailable
+		pdb.Spec.MinAvailable = &minAvailable
+	} else if budget != nil && budget.MaxUnavailable != nil {
+		maxUnavailable := *budget.MaxUnavailable
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	} else {
+		maxUnavailable := intstr.FromInt(1)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+	return pdb
+}
+
+func isHA(cr *api.KieApp) bool {
+	return cr.Status.Applied.Environment == api.RhpamAuthoringHA || cr.Status.Applied.Environment == api.RhdmAuthoringHA
+}
@@ -0,0 +1,103 @@
+package defaults
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestAvailabilityHA(t *testing.T) {
+	minAvailable := intstr.FromString("50%")
+	nodeAffinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "node-role.kubernetes.io/kie", Operator: corev1.NodeSelectorOpExists}},
+				}},
+			},
+		},
+	}
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamAuthoringHA,
+			Objects: api.KieAppObjects{
+				Console: api.ConsoleObject{
+					KieAppObject: api.KieAppObject{PodDisruptionBudget: &api.PodDisruptionBudgetObject{MinAvailable: &minAvailable}},
+				},
+				Servers: []api.KieServerSet{{
+					KieAppObject: api.KieAppObject{
+						SchedulingObject: api.SchedulingObject{
+							Affinity:                  nodeAffinity,
+							TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule}},
+						},
+					},
+				}},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	console := env.Console.DeploymentConfigs[0]
+	assert.Equal(t, int32(2), console.Spec.Replicas)
+	assert.Len(t, env.Console.PodDisruptionBudgets, 1)
+	pdb := env.Console.PodDisruptionBudgets[0]
+	assert.Equal(t, console.Name, pdb.Name)
+	assert.Equal(t, map[string]string{"deploymentConfig": console.Name}, pdb.Spec.Selector.MatchLabels)
+	assert.Equal(t, &minAvailable, pdb.Spec.MinAvailable)
+	assert.Nil(t, pdb.Spec.MaxUnavailable)
+	terms := console.Spec.Template.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	assert.Len(t, terms, 2)
+	assert.Equal(t, corev1.LabelHostname, terms[0].PodAffinityTerm.TopologyKey)
+	assert.Equal(t, "topology.kubernetes.io/zone", terms[1].PodAffinityTerm.TopologyKey)
+	assert.Equal(t, pdb.Spec.Selector, terms[0].PodAffinityTerm.LabelSelector)
+
+	server := env.Servers[0].DeploymentConfigs[0]
+	assert.Equal(t, nodeAffinity, server.Spec.Template.Spec.Affinity, "The affinity of the CR should replace the default one")
+	assert.Len(t, server.Spec.Template.Spec.TopologySpreadConstraints, 1)
+	assert.Len(t, env.Servers[0].PodDisruptionBudgets, 1)
+	assert.Equal(t, intstr.FromInt(1), *env.Servers[0].PodDisruptionBudgets[0].Spec.MaxUnavailable)
+
+	var budgets []string
+	for _, others := range env.Others {
+		for _, statefulSet := range others.StatefulSets {
+			assert.NotNil(t, statefulSet.Spec.Template.Spec.Affinity, "StatefulSet %s should be spread", statefulSet.Name)
+		}
+		for _, pdb := range others.PodDisruptionBudgets {
+			budgets = append(budgets, pdb.Name)
+			assert.Equal(t, map[string]string{"deploymentConfig": pdb.Name}, pdb.Spec.Selector.MatchLabels, "Only the pods of %s should be selected", pdb.Name)
+		}
+	}
+	assert.ElementsMatch(t, []string{"test-datagrid", "test-amq"}, budgets)
+}
+
+func TestAvailabilitySingleReplica(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamAuthoring,
+			Objects: api.KieAppObjects{
+				Servers: []api.KieServerSet{{Name: "scaled", KieAppObject: api.KieAppObject{Replicas: Pint32(2)}}, {Name: "single"}},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	assert.Empty(t, env.Console.PodDisruptionBudgets, "Single replica components should not have a PodDisruptionBudget")
+	assert.Len(t, env.Servers[0].PodDisruptionBudgets, 1)
+	assert.Nil(t, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Affinity, "Pods should only be spread by default in HA environments")
+	assert.Empty(t, env.Servers[1].PodDisruptionBudgets)
+}
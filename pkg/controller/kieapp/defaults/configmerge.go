@@ -0,0 +1,268 @@
+package defaults
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/shared"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// maxConflictValueLength is the maximum length of the values reported for each conflicting field
+const maxConflictValueLength = 256
+
+// configField is a field of a configuration file with its values in each version, e.g. customized in a configuration
+// file and changed differently in the upgraded version
+type configField struct {
+	path                           string
+	original, customized, upgraded string
+}
+
+// mergeConfigFile applies the customizations of a configuration file, made on top of the original file of the applied
+// version, onto the file of the upgraded version. The files are compared as YAML so that only the customized fields
+// that are also changed by the upgrade conflict. The file is only rewritten when both versions change it, otherwise the
+// changed version is kept as is.
+func mergeConfigFile(original, customized, upgraded string) (string, []configField) {
+	if customized == original || customized == upgraded {
+		return upgraded, nil
+	} else if upgraded == original {
+		return customized, nil
+	}
+	var originalNode, customizedNode, upgradedNode yamlv3.Node
+	if yamlv3.Unmarshal([]byte(original), &originalNode) != nil ||
+		yamlv3.Unmarshal([]byte(customized), &customizedNode) != nil ||
+		yamlv3.Unmarshal([]byte(upgraded), &upgradedNode) != nil ||
+		len(originalNode.Content) == 0 || len(customizedNode.Content) == 0 || len(upgradedNode.Content) == 0 {
+		return upgraded, getFileConflict(original, customized, upgraded)
+	}
+	merged, conflicts := mergeConfigNodes("", originalNode.Content[0], customizedNode.Content[0], upgradedNode.Content[0])
+	if len(conflicts) > 0 {
+		return upgraded, conflicts
+	}
+	upgradedNode.Content[0] = merged
+	var b bytes.Buffer
+	encoder := yamlv3.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&upgradedNode); err != nil {
+		return upgraded, getFileConflict(original, customized, upgraded)
+	}
+	return b.String(), nil
+}
+
+// getFileConflict returns the conflict of a file that can't be merged field by field
+func getFileConflict(original, customized, upgraded string) []configField {
+	return []configField{{
+		original:   truncateConfigValue(original),
+		customized: truncateConfigValue(customized),
+		upgraded:   truncateConfigValue(upgraded),
+	}}
+}
+
+// diffConfigFile returns the fields of a configuration file that differ between the applied and the upgraded versions,
+// or the whole file if not valid YAML
+func diffConfigFile(applied, upgraded string) []configField {
+	if applied == upgraded {
+		return nil
+	}
+	var appliedNode, upgradedNode yamlv3.Node
+	if yamlv3.Unmarshal([]byte(applied), &appliedNode) != nil || yamlv3.Unmarshal([]byte(upgraded), &upgradedNode) != nil ||
+		len(appliedNode.Content) == 0 || len(upgradedNode.Content) == 0 {
+		return []configField{{original: truncateConfigValue(applied), upgraded: truncateConfigValue(upgraded)}}
+	}
+	return diffConfigNodes("", appliedNode.Content[0], upgradedNode.Content[0])
+}
+
+// diffConfigNodes compares the YAML nodes of two versions, nil when missing, field by field for maps and named lists
+func diffConfigNodes(path string, applied, upgraded *yamlv3.Node) []configField {
+	if equalConfigNodes(applied, upgraded) {
+		return nil
+	}
+	var getFields configFields
+	var getPath func(key string) string
+	if isConfigKind(yamlv3.MappingNode, nil, applied, upgraded) {
+		getFields = getConfigMapping
+		getPath = func(key string) string {
+			return shared.GetFieldPath(path, key)
+		}
+	} else if isConfigKind(yamlv3.SequenceNode, nil, applied, upgraded) && hasConfigNamedItems(applied) && hasConfigNamedItems(upgraded) {
+		getFields = getConfigNamedItems
+		getPath = func(name string) string {
+			return fmt.Sprintf("%s[name=%s]", path, name)
+		}
+	} else {
+		return []configField{{path: path, original: encodeConfigNode(applied), upgraded: encodeConfigNode(upgraded)}}
+	}
+	appliedKeys, appliedFields := getFields(applied)
+	upgradedKeys, upgradedFields := getFields(upgraded)
+	keys := appliedKeys
+	for _, key := range upgradedKeys {
+		if _, found := appliedFields[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	var diffs []configField
+	for _, key := range keys {
+		diffs = append(diffs, diffConfigNodes(getPath(key), lastConfigNode(appliedFields[key]), lastConfigNode(upgradedFields[key]))...)
+	}
+	return diffs
+}
+
+// mergeConfigNodes three-way merges a YAML node, nil when missing. Maps, and lists of items with a name, e.g. containers
+// or env variables, are merged field by field, other values conflict when both versions change them differently.
+func mergeConfigNodes(path string, original, customized, upgraded *yamlv3.Node) (*yamlv3.Node, []configField) {
+	if equalConfigNodes(customized, original) || equalConfigNodes(customized, upgraded) {
+		return upgraded, nil
+	} else if equalConfigNodes(upgraded, original) {
+		return customized, nil
+	}
+	if isConfigKind(yamlv3.MappingNode, original, customized, upgraded) {
+		return mergeConfigFields(path, original, customized, upgraded, getConfigMapping, func(key string) string {
+			return shared.GetFieldPath(path, key)
+		})
+	}
+	if isConfigKind(yamlv3.SequenceNode, original, customized, upgraded) &&
+		hasConfigNamedItems(original) && hasConfigNamedItems(customized) && hasConfigNamedItems(upgraded) {
+		return mergeConfigFields(path, original, customized, upgraded, getConfigNamedItems, func(name string) string {
+			return fmt.Sprintf("%s[name=%s]", path, name)
+		})
+	}
+	return upgraded, []configField{{
+		path:       path,
+		original:   encodeConfigNode(original),
+		customized: encodeConfigNode(customized),
+		upgraded:   encodeConfigNode(upgraded),
+	}}
+}
+
+// configFields returns the fields of a node by their key, with their key nodes for maps, in order
+type configFields func(node *yamlv3.Node) ([]string, map[string][]*yamlv3.Node)
+
+// mergeConfigFields merges the fields of a map or a named list, keeping the order of the upgraded version and
+// appending the customized fields
+func mergeConfigFields(path string, original, customized, upgraded *yamlv3.Node, getFields configFields, getPath func(key string) string) (*yamlv3.Node, []configField) {
+	_, originalFields := getFields(original)
+	customizedKeys, customizedFields := getFields(customized)
+	upgradedKeys, upgradedFields := getFields(upgraded)
+	keys := upgradedKeys
+	for _, key := range customizedKeys {
+		if _, found := upgradedFields[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	merged := *upgraded
+	merged.Content = nil
+	var conflicts []configField
+	for _, key := range keys {
+		fields := upgradedFields[key]
+		if fields == nil {
+			fields = customizedFields[key]
+		}
+		value, valueConflicts := mergeConfigNodes(getPath(key), lastConfigNode(originalFields[key]), lastConfigNode(customizedFields[key]), lastConfigNode(upgradedFields[key]))
+		conflicts = append(conflicts, valueConflicts...)
+		if value != nil {
+			merged.Content = append(merged.Content, append(fields[:len(fields)-1:len(fields)-1], value)...)
+		}
+	}
+	return &merged, conflicts
+}
+
+// getConfigMapping returns the keys of a map node in order, and their key and value nodes
+func getConfigMapping(node *yamlv3.Node) ([]string, map[string][]*yamlv3.Node) {
+	var keys []string
+	fields := map[string][]*yamlv3.Node{}
+	if node == nil {
+		return keys, fields
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+		fields[node.Content[i].Value] = node.Content[i : i+2]
+	}
+	return keys, fields
+}
+
+// getConfigNamedItems returns the names of the items of a list node in order, and the items, or nil if any of the items
+// has no unique name
+func getConfigNamedItems(node *yamlv3.Node) ([]string, map[string][]*yamlv3.Node) {
+	var names []string
+	items := map[string][]*yamlv3.Node{}
+	if node == nil {
+		return names, items
+	}
+	for _, item := range node.Content {
+		name, found := getConfigItemName(item)
+		if !found || items[name] != nil {
+			return nil, nil
+		}
+		names = append(names, name)
+		items[name] = []*yamlv3.Node{item}
+	}
+	return names, items
+}
+
+// getConfigItemName returns the name of a list item, or the name in its metadata for objects, e.g. DeploymentConfigs
+func getConfigItemName(item *yamlv3.Node) (string, bool) {
+	if item.Kind != yamlv3.MappingNode {
+		return "", false
+	}
+	_, fields := getConfigMapping(item)
+	if name := lastConfigNode(fields["name"]); name != nil && name.Kind == yamlv3.ScalarNode {
+		return name.Value, true
+	}
+	if metadata := lastConfigNode(fields["metadata"]); metadata != nil && metadata.Kind == yamlv3.MappingNode {
+		_, metadataFields := getConfigMapping(metadata)
+		if name := lastConfigNode(metadataFields["name"]); name != nil && name.Kind == yamlv3.ScalarNode {
+			return name.Value, true
+		}
+	}
+	return "", false
+}
+
+func hasConfigNamedItems(node *yamlv3.Node) bool {
+	_, items := getConfigNamedItems(node)
+	return items != nil
+}
+
+// isConfigKind returns whether the customized and upgraded nodes, and the original one if any, are of the given kind
+func isConfigKind(kind yamlv3.Kind, original, customized, upgraded *yamlv3.Node) bool {
+	return customized != nil && customized.Kind == kind && upgraded != nil && upgraded.Kind == kind &&
+		(original == nil || original.Kind == kind)
+}
+
+func lastConfigNode(nodes []*yamlv3.Node) *yamlv3.Node {
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[len(nodes)-1]
+}
+
+// equalConfigNodes compares the values of the nodes, regardless of their style and comments
+func equalConfigNodes(a, b *yamlv3.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	var aValue, bValue interface{}
+	if a.Decode(&aValue) != nil || b.Decode(&bValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+func encodeConfigNode(node *yamlv3.Node) string {
+	if node == nil {
+		return ""
+	}
+	out, err := yamlv3.Marshal(node)
+	if err != nil {
+		return ""
+	}
+	return truncateConfigValue(strings.TrimSpace(string(out)))
+}
+
+func truncateConfigValue(value string) string {
+	if len(value) > maxConflictValueLength {
+		return value[:maxConflictValueLength] + "..."
+	}
+	return value
+}
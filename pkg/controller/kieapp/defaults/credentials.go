@@ -0,0 +1,195 @@
+package defaults
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RHsyseng/operator-utils/pkg/utils/kubernetes"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// credential is a password of the KieApp along with the Secret key it can be read from instead
+type credential struct {
+	value *string
+	ref   *corev1.SecretKeySelector
+}
+
+// getCommonCredentials returns the credentials of the common config by their key in the credentials Secret
+func getCommonCredentials(config *api.CommonConfig) map[string]credential {
+	return map[string]credential{
+		constants.CredentialsKeyStorePassword:   {&config.KeyStorePassword, config.KeyStorePasswordSecret},
+		constants.CredentialsAdminPassword:      {&config.AdminPassword, config.AdminPasswordSecret},
+		constants.CredentialsDBPassword:         {&config.DBPassword, config.DBPasswordSecret},
+		constants.CredentialsAMQPassword:        {&config.AMQPassword, config.AMQPasswordSecret},
+		constants.CredentialsAMQClusterPassword: {&config.AMQClusterPassword, config.AMQClusterPasswordSecret},
+	}
+}
+
+// GetCredentialsSecretName returns the name of the Secret holding the credentials of the KieApp
+func GetCredentialsSecretName(cr *api.KieApp) string {
+	applicationName := cr.Spec.CommonConfig.ApplicationName
+	if len(applicationName) == 0 {
+		applicationName = cr.Name
+	}
+	return fmt.Sprintf(constants.CredentialsSecret, applicationName)
+}
+
+// loadCredentials restores in the applied status the generated credentials kept in the credentials Secret,
+// so that they are retained across reconciliations without being stored in the status of the KieApp.
+// Credentials read from a Secret key referenced in the spec are not restored, they are resolved after the defaults are set.
+func loadCredentials(cr *api.KieApp, service kubernetes.PlatformService) error {
+	secret := &corev1.Secret{}
+	err := service.Get(context.TODO(), types.NamespacedName{Name: GetCredentialsSecretName(cr), Namespace: cr.Namespace}, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	specCredentials := getCommonCredentials(&cr.Spec.CommonConfig)
+	for key, applied := range getCommonCredentials(&cr.Status.Applied.CommonConfig) {
+		if specCredentials[key].ref != nil {
+			*applied.value = ""
+		} else if value, found := secret.Data[key]; found {
+			*applied.value = string(value)
+		}
+	}
+	applicationName := cr.Status.Applied.CommonConfig.ApplicationName
+	for index := range cr.Status.Applied.Objects.Servers {
+		serverSet := &cr.Status.Applied.Objects.Servers[index]
+		if serverSet.Jms == nil || serverSet.Deployments == nil {
+			continue
+		}
+		for i := 0; i < *serverSet.Deployments; i++ {
+			kieName := getKieDeploymentName(applicationName, serverSet.Name, 0, i)
+			if value, found := secret.Data[fmt.Sprintf(constants.CredentialsJmsPassword, kieName)]; found {
+				serverSet.Jms.Password = string(value)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// resolveCredentials reads the credentials left empty in the applied spec from the Secret keys they reference
+func resolveCredentials(cr *api.KieApp, service kubernetes.PlatformService) error {
+	applied := &cr.Status.Applied
+	credentials := []credential{}
+	for _, common := range getCommonCredentials(&applied.CommonConfig) {
+		credentials = append(credentials, common)
+	}
+	for _, serverSet := range applied.Objects.Servers {
+		if serverSet.Jms != nil {
+			credentials = append(credentials, credential{&serverSet.Jms.Password, serverSet.Jms.PasswordSecret})
+		}
+		if serverSet.Database != nil && serverSet.Database.ExternalConfig != nil {
+			externalConfig := &serverSet.Database.ExternalConfig.CommonExternalDatabaseObject
+			credentials = append(credentials, credential{&externalConfig.Password, externalConfig.PasswordSecret})
+		}
+	}
+	if applied.Objects.ProcessMigration != nil && applied.Objects.ProcessMigration.Database.ExternalConfig != nil {
+		externalConfig := &applied.Objects.ProcessMigration.Database.ExternalConfig.CommonExternalDatabaseObject
+		credentials = append(credentials, credential{&externalConfig.Password, externalConfig.PasswordSecret})
+	}
+	if applied.Auth != nil && applied.Auth.SSO != nil {
+		credentials = append(credentials, credential{&applied.Auth.SSO.AdminPassword, applied.Auth.SSO.AdminPasswordSecret})
+	}
+	if applied.Auth != nil && applied.Auth.LDAP != nil {
+		credentials = append(credentials, credential{&applied.Auth.LDAP.BindCredential, applied.Auth.LDAP.BindCredentialSecret})
+	}
+	for _, credential := range credentials {
+		if len(*credential.value) > 0 || credential.ref == nil {
+			continue
+		}
+		value, err := getSecretKeyValue(service, cr.Namespace, credential.ref)
+		if err != nil {
+			return err
+		}
+		*credential.value = value
+	}
+	return nil
+}
+
+func getSecretKeyValue(service kubernetes.PlatformService, namespace string, ref *corev1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	err := service.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret)
+	if err != nil {
+		if errors.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to read the credentials from Secret %s: %v", ref.Name, err)
+	}
+	value, found := secret.Data[ref.Key]
+	if !found && (ref.Optional == nil || !*ref.Optional) {
+		return "", fmt.Errorf("key %s not found in Secret %s", ref.Key, ref.Name)
+	}
+	return string(value), nil
+}
+
+// getCredentialsSecret returns the Secret holding the credentials injected in the deployments of the KieApp
+func getCredentialsSecret(cr *api.KieApp, envTemplate api.EnvTemplate) corev1.Secret {
+	data := map[string][]byte{}
+	for key, common := range getCommonCredentials(&cr.Status.Applied.CommonConfig) {
+		data[key] = []byte(*common.value)
+	}
+	if len(envTemplate.Auth.SSO.URL) > 0 {
+		data[constants.CredentialsSSOAdminPassword] = []byte(envTemplate.Auth.SSO.AdminPassword)
+	}
+	if len(envTemplate.Auth.LDAP.URL) > 0 {
+		data[constants.CredentialsLDAPBindCredential] = []byte(envTemplate.Auth.LDAP.BindCredential)
+	}
+	for _, server := range envTemplate.Servers {
+		if server.Jms.EnableIntegration {
+			data[fmt.Sprintf(constants.CredentialsJmsPassword, server.KieName)] = []byte(server.Jms.Password)
+		}
+		if server.Database.ExternalConfig != nil {
+			data[fmt.Sprintf(constants.CredentialsExternalDBPassword, server.KieName)] = []byte(server.Database.ExternalConfig.Password)
+		}
+	}
+	if envTemplate.ProcessMigration.Database.ExternalConfig != nil {
+		data[constants.CredentialsProcessMigrationDBPassword] = []byte(envTemplate.ProcessMigration.Database.ExternalConfig.Password)
+	}
+	secret := corev1.Secret{
+		Type: corev1.SecretTypeOpaque,
+		ObjectMeta: metav1.ObjectMeta{
+			Name: GetCredentialsSecretName(cr),
+			Labels: map[string]string{
+				"app":         cr.Status.Applied.CommonConfig.ApplicationName,
+				"application": cr.Status.Applied.CommonConfig.ApplicationName,
+			},
+		},
+		Data: data,
+	}
+	secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	return secret
+}
+
+// RemoveCredentials clears the credentials from the applied status, so that they are not stored in the KieApp.
+// They are kept in the credentials Secret instead.
+func RemoveCredentials(cr *api.KieApp) {
+	applied := &cr.Status.Applied
+	for _, common := range getCommonCredentials(&applied.CommonConfig) {
+		*common.value = ""
+	}
+	for index := range applied.Objects.Servers {
+		serverSet := &applied.Objects.Servers[index]
+		if serverSet.Jms != nil {
+			serverSet.Jms.Password = ""
+		}
+		if serverSet.Database != nil && serverSet.Database.ExternalConfig != nil && serverSet.Database.ExternalConfig.PasswordSecret != nil {
+			serverSet.Database.ExternalConfig.Password = ""
+		}
+	}
+	if applied.Objects.ProcessMigration != nil && applied.Objects.ProcessMigration.Database.ExternalConfig != nil &&
+		applied.Objects.ProcessMigration.Database.ExternalConfig.PasswordSecret != nil {
+		applied.Objects.ProcessMigration.Database.ExternalConfig.Password = ""
+	}
+	if applied.Auth != nil && applied.Auth.SSO != nil && applied.Auth.SSO.AdminPasswordSecret != nil {
+		applied.Auth.SSO.AdminPassword = ""
+	}
+	if applied.Auth != nil && applied.Auth.LDAP != nil && applied.Auth.LDAP.BindCredentialSecret != nil {
+		applied.Auth.LDAP.BindCredential = ""
+	}
+}
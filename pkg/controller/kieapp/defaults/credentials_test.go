@@ -0,0 +1,186 @@
+package defaults
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCredentialsRetained(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamTrial,
+		},
+	}
+	service := test.MockService()
+	env, err := GetEnvironment(cr, service)
+	assert.Nil(t, err, "Error getting trial environment")
+	adminPassword := cr.Status.Applied.CommonConfig.AdminPassword
+	assert.NotEmpty(t, adminPassword)
+
+	secret := getCredentialsSecretFromEnv(t, env)
+	assert.Equal(t, "test-credentials", secret.Name)
+	assert.Equal(t, adminPassword, string(secret.Data[constants.CredentialsAdminPassword]))
+	assert.Equal(t, cr.Status.Applied.CommonConfig.KeyStorePassword, string(secret.Data[constants.CredentialsKeyStorePassword]))
+	assert.Contains(t, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Env, getCredentialsEnv("KIE_ADMIN_PWD", "test", constants.CredentialsAdminPassword))
+
+	RemoveCredentials(cr)
+	assert.Empty(t, cr.Status.Applied.CommonConfig.AdminPassword)
+	assert.Empty(t, cr.Status.Applied.CommonConfig.KeyStorePassword)
+
+	secret.Namespace = cr.Namespace
+	assert.Nil(t, service.Create(context.TODO(), &secret))
+	_, err = GetEnvironment(cr, service)
+	assert.Nil(t, err, "Error getting trial environment")
+	assert.Equal(t, adminPassword, cr.Status.Applied.CommonConfig.AdminPassword, "Generated credentials should be read from the credentials Secret")
+}
+
+func TestCredentialsFromSecretKey(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamTrial,
+			CommonConfig: api.CommonConfig{
+				AdminPasswordSecret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "admin"},
+					Key:                  "password",
+				},
+			},
+			Auth: &api.KieAppAuthObject{
+				LDAP: &api.LDAPAuthConfig{
+					URL: "ldaps://ldap.example.com",
+					BindCredentialSecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "ldap"},
+						Key:                  "credential",
+					},
+				},
+			},
+		},
+	}
+	service := test.MockService()
+	_, err := GetEnvironment(cr, service)
+	assert.EqualError(t, err, "unable to read the credentials from Secret admin: secrets \"admin\" not found")
+
+	for name, data := range map[string]map[string][]byte{
+		"admin": {"password": []byte("s3cr3t")},
+		"ldap":  {"credential": []byte("b1nd")},
+	} {
+		assert.Nil(t, service.Create(context.TODO(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace},
+			Data:       data,
+		}))
+	}
+	env, err := GetEnvironment(cr, service)
+	assert.Nil(t, err, "Error getting trial environment")
+	assert.Equal(t, "s3cr3t", cr.Status.Applied.CommonConfig.AdminPassword)
+	assert.Equal(t, "b1nd", cr.Status.Applied.Auth.LDAP.BindCredential)
+
+	secret := getCredentialsSecretFromEnv(t, env)
+	assert.Equal(t, "s3cr3t", string(secret.Data[constants.CredentialsAdminPassword]))
+	assert.Equal(t, "b1nd", string(secret.Data[constants.CredentialsLDAPBindCredential]))
+
+	RemoveCredentials(cr)
+	assert.Empty(t, cr.Status.Applied.CommonConfig.AdminPassword)
+	assert.Empty(t, cr.Status.Applied.Auth.LDAP.BindCredential)
+	assert.Empty(t, cr.Spec.CommonConfig.AdminPassword, "Credentials should never be stored in the spec")
+}
+
+func TestProcessMigrationCredentials(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamTrial,
+			CommonConfig: api.CommonConfig{
+				AdminPassword: "adminpwd",
+			},
+			Objects: api.KieAppObjects{
+				ProcessMigration: &api.ProcessMigrationObject{
+					Database: api.ProcessMigrationDatabaseObject{
+						InternalDatabaseObject: api.InternalDatabaseObject{Type: api.DatabaseExternal},
+						ExternalConfig: &api.CommonExtDBObjectRequiredURL{
+							JdbcURL: "jdbc:mysql://mydb.example.com:3306/pimdb",
+							CommonExternalDatabaseObject: api.CommonExternalDatabaseObject{
+								Driver:   "mysql",
+								Username: "pim",
+								Password: "pimpwd",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting trial environment")
+
+	secret := getCredentialsSecretFromEnv(t, env)
+	assert.Equal(t, "pimpwd", string(secret.Data[constants.CredentialsProcessMigrationDBPassword]))
+
+	container := env.ProcessMigration.DeploymentConfigs[0].Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Env, getCredentialsEnv("PIM_DATASOURCE_PASSWORD", "test", constants.CredentialsProcessMigrationDBPassword))
+	assert.Contains(t, container.Env, getCredentialsEnv("JBOSS_KIE_ADMIN_PWD", "test", constants.CredentialsAdminPassword))
+	overrides := env.ProcessMigration.ConfigMaps[0].Data["project-overrides.yml"]
+	assert.NotContains(t, overrides, "adminpwd")
+	assert.NotContains(t, overrides, "pimpwd")
+}
+
+func getCredentialsSecretFromEnv(t *testing.T, env api.Environment) corev1.Secret {
+	for _, other := range env.Others {
+		for _, secret := range other.Secrets {
+			if secret.Name == "test-credentials" {
+				return secret
+			}
+		}
+	}
+	assert.Fail(t, "Credentials Secret not found")
+	return corev1.Secret{}
+}
+
+func getCredentialsEnv(name, applicationName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf(constants.CredentialsSecret, applicationName)},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// getCredentialEnvVariable returns the value of an env variable, reading it from the credentials Secret of the environment when referenced
+func getCredentialEnvVariable(env api.Environment, container corev1.Container, name string) string {
+	for _, envVar := range container.Env {
+		if envVar.Name != name {
+			continue
+		}
+		if envVar.ValueFrom == nil || envVar.ValueFrom.SecretKeyRef == nil {
+			return envVar.Value
+		}
+		for _, other := range env.Others {
+			for _, secret := range other.Secrets {
+				if secret.Name == envVar.ValueFrom.SecretKeyRef.Name {
+					return string(secret.Data[envVar.ValueFrom.SecretKeyRef.Key])
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"text/template"
@@ -20,6 +21,7 @@ import (
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/shared"
 	"github.com/kiegroup/kie-cloud-operator/version"
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	"golang.org/x/mod/semver"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -27,33 +29,69 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var log = logs.GetLogger("kieapp.defaults")
 
+// IsPaused returns true if the reconciliation of the KieApp is paused, either by its spec or its annotation
+func IsPaused(cr *api.KieApp) bool {
+	if cr.Spec.Paused {
+		return true
+	}
+	value, found := cr.GetAnnotations()[constants.PausedAnnotation]
+	if !found {
+		return false
+	}
+	paused, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warnf("Ignoring invalid value %s of annotation %s on KieApp %s", value, constants.PausedAnnotation, cr.Name)
+		return false
+	}
+	return paused
+}
+
 // GetEnvironment returns an Environment from merging the common config and the config
 // related to the environment set in the KieApp definition
 func GetEnvironment(cr *api.KieApp, service kubernetes.PlatformService) (api.Environment, error) {
+	appliedVersion := cr.Status.Applied.Version
+	cr.Status.UpgradeAvailable = nil
+	cr.Status.UpgradePath = nil
+	if err := loadCredentials(cr, service); err != nil {
+		return api.Environment{}, err
+	}
+	if errs := validateVersion(cr.Spec, appliedVersion, field.NewPath("spec")); len(errs) > 0 {
+		return api.Environment{}, errs.ToAggregate()
+	}
+	paused := IsPaused(cr)
+	if paused && checkVersion(appliedVersion) {
+		// the applied version is rendered as is until resumed, so that each version change is recorded
+		cr.Spec.Version = appliedVersion
+	} else {
+		pinUpgradeVersion(cr, appliedVersion)
+	}
 	minor, micro, err := checkProductUpgrade(cr)
 	if err != nil {
 		return api.Environment{}, err
 	}
-	// handle upgrade logic from here
-	cMajor, _, _ := GetMajorMinorMicro(cr.Status.Applied.Version)
-	lMajor, _, _ := GetMajorMinorMicro(constants.CurrentVersion)
-	minorVersion := GetMinorImageVersion(cr.Status.Applied.Version)
-	latestMinorVersion := GetMinorImageVersion(constants.CurrentVersion)
-	if (micro && minorVersion == latestMinorVersion) ||
-		(minor && minorVersion != latestMinorVersion && cMajor == lMajor) {
-		if err := getConfigVersionDiffs(cr.Status.Applied.Version, constants.CurrentVersion, service); err != nil {
+	if errs := validatePlatform(cr.Status.Applied, field.NewPath("spec")); len(errs) > 0 {
+		return api.Environment{}, errs.ToAggregate()
+	}
+	if paused {
+		if cr.Spec.RollbackTo == "" {
+			cr.Status.UpgradePath = getUpgradePath(cr.Status.Applied.Version, micro, minor)
+		}
+	} else if cr.Spec.RollbackTo != "" {
+		// upgrades are suspended while rolled back
+		if err := rollbackVersion(cr, service); err != nil {
+			return api.Environment{}, err
+		}
+	} else if path := getUpgradePath(cr.Status.Applied.Version, micro, minor); len(path) > 0 {
+		if err := upgradeVersion(cr, appliedVersion, path, service); err != nil {
 			return api.Environment{}, err
 		}
-		// reset current annotations and update CR to use latest product version
-		cr.SetAnnotations(map[string]string{})
-		cr.Status.Applied.Version = constants.CurrentVersion
-		cr.Spec.Version = ""
 	}
-	envTemplate, err := getEnvTemplate(cr)
+	envTemplate, err := getEnvTemplate(cr, service)
 	if err != nil {
 		return api.Environment{}, err
 	}
@@ -115,6 +153,8 @@ func GetEnvironment(cr *api.KieApp, service kubernetes.PlatformService) (api.Env
 	if err != nil {
 		return api.Environment{}, err
 	}
+	mergedEnv.Others = append(mergedEnv.Others, api.CustomObject{Secrets: []corev1.Secret{getCredentialsSecret(cr, envTemplate)}})
+	setRoutes(cr, &mergedEnv)
 	setProductLabels(cr, &mergedEnv)
 	return mergedEnv, nil
 }
@@ -132,6 +172,9 @@ func setObjectLabels(cr *api.KieApp, object *api.CustomObject, component string)
 	for index, obj := range object.DeploymentConfigs {
 		object.DeploymentConfigs[index].Spec.Template.Labels = setLabels(cr, obj.Spec.Template.Labels, component)
 	}
+	for index, obj := range object.Deployments {
+		object.Deployments[index].Spec.Template.Labels = setLabels(cr, obj.Spec.Template.Labels, component)
+	}
 	for index, obj := range object.StatefulSets {
 		object.StatefulSets[index].Spec.Template.Labels = setLabels(cr, obj.Spec.Template.Labels, component)
 	}
@@ -216,8 +259,11 @@ func findCustomObjectByName(template api.CustomObject, objects []api.CustomObjec
 	return api.CustomObject{}, false
 }
 
-func getEnvTemplate(cr *api.KieApp) (envTemplate api.EnvTemplate, err error) {
+func getEnvTemplate(cr *api.KieApp, service kubernetes.PlatformService) (envTemplate api.EnvTemplate, err error) {
 	SetDefaults(cr)
+	if err := resolveCredentials(cr, service); err != nil {
+		return envTemplate, err
+	}
 	serversConfig, err := getServersConfig(cr)
 	if err != nil {
 		return envTemplate, err
@@ -421,6 +467,54 @@ func GetImage(imageURL string) (image, imageTag, imageContext string) {
 	return image, imageTag, imageContext
 }
 
+// GetImageRegistryURL returns the tag name and registry image backing the given ImageStreamTag reference
+func GetImageRegistryURL(tagRefName, imageURL string, cr *api.KieApp) (tagName, registryURL string) {
+	result := strings.Split(tagRefName, ":")
+	if len(result) == 1 {
+		result = append(result, "latest")
+	}
+	product := GetProduct(cr.Status.Applied.Environment)
+	tagName = fmt.Sprintf("%s:%s", result[0], result[1])
+	imageName := tagName
+	major, _, _ := GetMajorMinorMicro(cr.Status.Applied.Version)
+	regContext := fmt.Sprintf("%s-%s", product, major)
+	if _, _, imageContext := GetImage(imageURL); imageContext != "" {
+		regContext = imageContext
+	}
+
+	registryAddress := GetImageRegistry(cr).Registry
+	if strings.Contains(result[0], "datagrid") {
+		registryAddress = constants.ImageRegistry
+		regContext = "jboss-datagrid-7"
+	} else if strings.Contains(result[0], "amq-broker-7") {
+		registryAddress = constants.ImageRegistry
+		regContext = "amq-broker-7"
+		if strings.Contains(result[0], "scaledown") {
+			regContext = "amq-broker-7-tech-preview"
+		}
+	} else if result[0] == "postgresql" || result[0] == "mysql" {
+		registryAddress = constants.ImageRegistry
+		regContext = "rhscl"
+		pattern := regexp.MustCompile("[0-9]+")
+		imageName = fmt.Sprintf("%s-%s-rhel7:%s", result[0], strings.Join(pattern.FindAllString(result[1], -1), ""), "latest")
+	}
+	return tagName, fmt.Sprintf("%s/%s/%s", registryAddress, regContext, imageName)
+}
+
+// GetImageRegistry returns the registry requested in the KieApp, or the default registry settings
+func GetImageRegistry(cr *api.KieApp) *api.KieAppRegistry {
+	registry := &api.KieAppRegistry{
+		Insecure: logs.GetBoolEnv("INSECURE"),
+	}
+	if cr.Status.Applied.ImageRegistry != nil {
+		registry = cr.Status.Applied.ImageRegistry
+	}
+	if registry.Registry == "" {
+		registry.Registry = logs.GetEnv("REGISTRY", constants.ImageRegistry)
+	}
+	return registry
+}
+
 func setReplicas(objectReplicas *int32, replicaConstant api.Replicas, hasEnv bool) (replicas int32, denyScale bool) {
 	if objectReplicas != nil {
 		if hasEnv && replicaConstant.DenyScale && *objectReplicas != replicaConstant.Replicas {
@@ -477,10 +571,9 @@ func getServersConfig(cr *api.KieApp) ([]api.ServerTemplate, error) {
 		}
 		for i := 0; i < *serverSet.Deployments; i++ {
 			name := getKieDeploymentName(cr.Status.Applied.CommonConfig.ApplicationName, serverSet.Name, 0, i)
-			if usedNames[name] {
-				return []api.ServerTemplate{}, fmt.Errorf("duplicate kieserver name %s", name)
+			if err := checkKieDeploymentName(name, usedNames); err != nil {
+				return []api.ServerTemplate{}, err
 			}
-			usedNames[name] = true
 			template := api.ServerTemplate{
 				KieName:          name,
 				KieServerID:      name,
@@ -491,10 +584,10 @@ func getServersConfig(cr *api.KieApp) ([]api.ServerTemplate, error) {
 			if serverSet.ID != "" {
 				template.KieServerID = serverSet.ID
 			}
+			if err := checkBuildDeployments(*serverSet, *serverSet.Deployments); err != nil {
+				return []api.ServerTemplate{}, err
+			}
 			if serverSet.Build != nil {
-				if *serverSet.Deployments > 1 {
-					return []api.ServerTemplate{}, fmt.Errorf("Cannot request %v deployments for a build", *serverSet.Deployments)
-				}
 				template.From = api.ImageObjRef{
 					Kind: "ImageStreamTag",
 					ObjectReference: api.ObjectReference{
@@ -574,8 +667,8 @@ func GetServerSet(cr *api.KieApp, requestedIndex int) (serverSet api.KieServerSe
 	return
 }
 
+// setKieSetNames names the server sets without a name in place, the same way wherever they are in the list
 func setKieSetNames(spec *api.KieAppSpec) {
-	spec.Objects.Servers = serverSortBlanks(spec.Objects.Servers)
 	for index := range spec.Objects.Servers {
 		if spec.Objects.Servers[index].Name == "" {
 			spec.Objects.Servers[index].Name = getKieSetName(spec, index)
@@ -603,29 +696,112 @@ func ConsolidateObjects(env api.Environment, cr *api.KieApp) api.Environment {
 	}
 	for index := range env.Servers {
 		serverSet, _ := GetServerSet(cr, index)
-		env.Servers[index] = ConstructObject(env.Servers[index], serverSet.KieAppObject)
+		env.Servers[index] = setStartupProbes(ConstructObject(env.Servers[index], serverSet.KieAppObject))
 	}
-	return env
+	env = setScheduling(env, cr)
+	env = setMetadata(env, cr)
+	if IsKubernetes(cr) {
+		env = ConvertToKubernetes(env, cr)
+	}
+	env = setAutoscalers(env, cr)
+	return setAvailability(env, cr)
 }
 
 // ConstructObject returns an object after merging the environment object and the one defined in the CR
 func ConstructObject(object api.CustomObject, appObject api.KieAppObject) api.CustomObject {
 	for dcIndex, dc := range object.DeploymentConfigs {
-		for containerIndex, c := range dc.Spec.Template.Spec.Containers {
-			c.Env = shared.EnvOverride(c.Env, appObject.Env)
-			if appObject.Resources != nil {
-				err := mergo.Merge(&c.Resources, *appObject.Resources, mergo.WithOverride)
-				if err != nil {
-					log.Error("Error merging interfaces. ", err)
-				}
+		constructContainers(dc.Spec.Template.Spec.Containers, appObject)
+		if dc.Spec.Template != nil {
+			if dcIndex == 0 && len(dc.Spec.Template.Spec.Containers) > 0 {
+				constructProbes(&dc.Spec.Template.Spec.Containers[0], appObject.Probes)
 			}
-			dc.Spec.Template.Spec.Containers[containerIndex] = c
+			constructPodSpec(&dc.Spec.Template.Spec, appObject)
+			constructScheduling(&dc.Spec.Template.Spec, appObject.SchedulingObject)
 		}
 		object.DeploymentConfigs[dcIndex] = dc
 	}
+	for index := range object.Deployments {
+		constructContainers(object.Deployments[index].Spec.Template.Spec.Containers, appObject)
+		if index == 0 && len(object.Deployments[index].Spec.Template.Spec.Containers) > 0 {
+			constructProbes(&object.Deployments[index].Spec.Template.Spec.Containers[0], appObject.Probes)
+		}
+		constructPodSpec(&object.Deployments[index].Spec.Template.Spec, appObject)
+		constructScheduling(&object.Deployments[index].Spec.Template.Spec, appObject.SchedulingObject)
+	}
+	for index := range object.StatefulSets {
+		constructScheduling(&object.StatefulSets[index].Spec.Template.Spec, appObject.SchedulingObject)
+	}
 	return object
 }
 
+// constructPodSpec merges the containers, init containers, volumes and security contexts defined in the CR into the pod spec.
+// The env sources, volume mounts and security context of the CR are set on the main container, the first one of the pod.
+func constructPodSpec(podSpec *corev1.PodSpec, appObject api.KieAppObject) {
+	custom := appObject.DeepCopy()
+	overwrite := corev1.PodSpec{
+		InitContainers:  custom.InitContainers,
+		Volumes:         custom.Volumes,
+		SecurityContext: custom.PodSecurityContext,
+	}
+	if len(podSpec.Containers) > 0 {
+		overwrite.Containers = append(overwrite.Containers, corev1.Container{
+			Name:            podSpec.Containers[0].Name,
+			EnvFrom:         custom.EnvFrom,
+			VolumeMounts:    custom.VolumeMounts,
+			SecurityContext: custom.SecurityContext,
+		})
+	}
+	overwrite.Containers = append(overwrite.Containers, custom.ExtraContainers...)
+	mergedPodSpec, err := mergePodSpecs(*podSpec, overwrite)
+	if err != nil {
+		log.Error("Error merging pod specs. ", err)
+		return
+	}
+	*podSpec = mergedPodSpec
+}
+
+// constructScheduling sets the node selector, tolerations, affinity, priority class and topology spread constraints
+// defined in the CR, replacing those of the pod spec when set
+func constructScheduling(podSpec *corev1.PodSpec, scheduling api.SchedulingObject) {
+	if len(scheduling.NodeSelector) > 0 {
+		podSpec.NodeSelector = map[string]string{}
+		for key, value := range scheduling.NodeSelector {
+			podSpec.NodeSelector[key] = value
+		}
+	}
+	if len(scheduling.Tolerations) > 0 {
+		podSpec.Tolerations = nil
+		for _, toleration := range scheduling.Tolerations {
+			podSpec.Tolerations = append(podSpec.Tolerations, *toleration.DeepCopy())
+		}
+	}
+	if scheduling.Affinity != nil {
+		podSpec.Affinity = scheduling.Affinity.DeepCopy()
+	}
+	if scheduling.PriorityClassName != "" {
+		podSpec.PriorityClassName = scheduling.PriorityClassName
+	}
+	if len(scheduling.TopologySpreadConstraints) > 0 {
+		podSpec.TopologySpreadConstraints = nil
+		for _, constraint := range scheduling.TopologySpreadConstraints {
+			podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, *constraint.DeepCopy())
+		}
+	}
+}
+
+func constructContainers(containers []corev1.Container, appObject api.KieAppObject) {
+	for containerIndex, c := range containers {
+		c.Env = shared.EnvOverride(c.Env, appObject.Env)
+		if appObject.Resources != nil {
+			err := mergo.Merge(&c.Resources, *appObject.Resources, mergo.WithOverride)
+			if err != nil {
+				log.Error("Error merging interfaces. ", err)
+			}
+		}
+		containers[containerIndex] = c
+	}
+}
+
 func getKieDeploymentName(applicationName string, setName string, arrayIdx, deploymentsIdx int) string {
 	name := setName
 	if name == "" {
@@ -743,8 +919,8 @@ func getDatabaseConfig(environment api.EnvironmentType, database *api.DatabaseOb
 		return defaultDB, nil
 	}
 
-	if database.Type == api.DatabaseExternal && database.ExternalConfig == nil {
-		return nil, fmt.Errorf("external database configuration is mandatory for external database type")
+	if err := checkExternalDatabase(database); err != nil {
+		return nil, err
 	}
 
 	if database.Size == "" && defaultDB != nil {
@@ -764,21 +940,15 @@ func getJmsConfig(environment api.EnvironmentType, jms *api.KieAppJmsObject) (*a
 		jms.AMQTruststoreName != "" && jms.AMQTruststorePassword != "" {
 		jms.AMQEnableSSL = true
 	}
-	t := true
-	if jms.Executor == nil {
-		jms.Executor = &t
-	}
-	if jms.AuditTransacted == nil {
-		jms.AuditTransacted = &t
-	}
+	setJmsDefaults(jms)
 
 	// if enabled, prepare the default values
 	defaultJms := api.KieAppJmsObject{
-		QueueExecutor: "queue/KIE.SERVER.EXECUTOR",
-		QueueRequest:  "queue/KIE.SERVER.REQUEST",
-		QueueResponse: "queue/KIE.SERVER.RESPONSE",
-		QueueSignal:   "queue/KIE.SERVER.SIGNAL",
-		QueueAudit:    "queue/KIE.SERVER.AUDIT",
+		QueueExecutor: constants.DefaultQueueExecutor,
+		QueueRequest:  constants.DefaultQueueRequest,
+		QueueResponse: constants.DefaultQueueResponse,
+		QueueSignal:   constants.DefaultQueueSignal,
+		QueueAudit:    constants.DefaultQueueAudit,
 		Username:      "user" + string(shared.GeneratePassword(4)),
 		Password:      string(shared.GeneratePassword(8)),
 	}
@@ -807,6 +977,31 @@ func getJmsConfig(environment api.EnvironmentType, jms *api.KieAppJmsObject) (*a
 	return jms, nil
 }
 
+// setJmsDefaults sets the executor and audit transaction flags and the names of the enabled queues
+func setJmsDefaults(jms *api.KieAppJmsObject) {
+	if jms.Executor == nil {
+		jms.Executor = Pbool(true)
+	}
+	if jms.AuditTransacted == nil {
+		jms.AuditTransacted = Pbool(true)
+	}
+	if *jms.Executor && jms.QueueExecutor == "" {
+		jms.QueueExecutor = constants.DefaultQueueExecutor
+	}
+	if jms.QueueRequest == "" {
+		jms.QueueRequest = constants.DefaultQueueRequest
+	}
+	if jms.QueueResponse == "" {
+		jms.QueueResponse = constants.DefaultQueueResponse
+	}
+	if jms.EnableSignal && jms.QueueSignal == "" {
+		jms.QueueSignal = constants.DefaultQueueSignal
+	}
+	if jms.EnableAudit && jms.QueueAudit == "" {
+		jms.QueueAudit = constants.DefaultQueueAudit
+	}
+}
+
 func getDefaultQueue(append bool, defaultJmsQueue string, jmsQueue string) string {
 	if append {
 		if jmsQueue == "" {
@@ -818,21 +1013,14 @@ func getDefaultQueue(append bool, defaultJmsQueue string, jmsQueue string) strin
 }
 
 func setPasswords(spec *api.KieAppSpec, isTrialEnv bool) {
-	passwords := []*string{
-		&spec.CommonConfig.KeyStorePassword,
-		&spec.CommonConfig.AdminPassword,
-		&spec.CommonConfig.DBPassword,
-		&spec.CommonConfig.AMQPassword,
-		&spec.CommonConfig.AMQClusterPassword,
-	}
-	for i := range passwords {
-		if len(*passwords[i]) > 0 {
+	for _, password := range getCommonCredentials(&spec.CommonConfig) {
+		if len(*password.value) > 0 || password.ref != nil {
 			continue
 		}
 		if isTrialEnv {
-			*passwords[i] = constants.DefaultPassword
+			*password.value = constants.DefaultPassword
 		} else {
-			*passwords[i] = string(shared.GeneratePassword(8))
+			*password.value = string(shared.GeneratePassword(8))
 		}
 	}
 }
@@ -954,7 +1142,7 @@ func ConfigMapsFromFile(myDep *appsv1.Deployment, ns string, scheme *runtime.Sch
 
 // UseEmbeddedFiles checks environment variables WATCH_NAMESPACE & OPERATOR_NAME
 func UseEmbeddedFiles(service kubernetes.PlatformService) (opName string, depNameSpace string, useEmbedded bool) {
-	namespace := os.Getenv(constants.NameSpaceEnv)
+	namespace := GetOperatorNamespace()
 	name := os.Getenv(constants.OpNameEnv)
 	if service.IsMockService() || namespace == "" || name == "" {
 		return name, namespace, true
@@ -962,6 +1150,16 @@ func UseEmbeddedFiles(service kubernetes.PlatformService) (opName string, depNam
 	return name, namespace, false
 }
 
+// GetOperatorNamespace returns the namespace the operator is deployed in. When WATCH_NAMESPACE lists several namespaces,
+// it is read from the service account of the operator pod instead.
+func GetOperatorNamespace() string {
+	namespace := os.Getenv(constants.NameSpaceEnv)
+	if strings.Contains(namespace, ",") {
+		namespace, _ = k8sutil.GetOperatorNamespace()
+	}
+	return namespace
+}
+
 // Pint returns a pointer to an integer
 func Pint(i int) *int {
 	return &i
@@ -972,6 +1170,11 @@ func Pint32(i int32) *int32 {
 	return &i
 }
 
+// Pint64 returns a pointer to an integer
+func Pint64(i int64) *int64 {
+	return &i
+}
+
 // Pbool returns a pointer to a boolean
 func Pbool(b bool) *bool {
 	return &b
@@ -1011,29 +1214,15 @@ func SetDefaults(cr *api.KieApp) {
 	if err := mergo.Merge(&specApply.CommonConfig, cr.Status.Applied.CommonConfig); err != nil {
 		log.Error(err)
 	}
-	if len(specApply.CommonConfig.ApplicationName) == 0 {
-		specApply.CommonConfig.ApplicationName = cr.Name
-	}
-	if len(specApply.CommonConfig.AdminUser) == 0 {
-		specApply.CommonConfig.AdminUser = constants.DefaultAdminUser
-	}
-	if len(specApply.Objects.Servers) == 0 {
-		specApply.Objects.Servers = []api.KieServerSet{{Deployments: Pint(constants.DefaultKieDeployments)}}
-	}
-	setKieSetNames(specApply)
-	checkJvmOnConsole(&specApply.Objects.Console)
-	setResourcesDefault(&specApply.Objects.Console.KieAppObject, constants.ConsoleCPULimit, constants.ConsoleCPURequests)
+	// the server sets without a name are applied after the named ones, the spec keeps their order
+	specApply.Objects.Servers = serverSortBlanks(specApply.Objects.Servers)
+	setSpecDefaults(specApply, cr.Name)
 	for index := range specApply.Objects.Servers {
 		addWebhookTypes(specApply.Objects.Servers[index].Build)
 		for _, statusServer := range cr.Status.Applied.Objects.Servers {
 			retainAppliedPwds(&specApply.Objects.Servers[index], statusServer)
 		}
 		addWebhookPwds(specApply.Objects.Servers[index].Build)
-		checkJvmOnServer(&specApply.Objects.Servers[index])
-		setResourcesDefault(&specApply.Objects.Servers[index].KieAppObject, constants.ServersCPULimit, constants.ServersCPURequests)
-	}
-	if specApply.Objects.SmartRouter != nil {
-		setResourcesDefault(&specApply.Objects.SmartRouter.KieAppObject, constants.SmartRouterCPULimit, constants.SmartRouterCPURequests)
 	}
 
 	isTrialEnv := strings.HasSuffix(string(specApply.Environment), constants.TrialEnvSuffix)
@@ -1041,6 +1230,54 @@ func SetDefaults(cr *api.KieApp) {
 	cr.Status.Applied = *specApply
 }
 
+// SetSpecDefaults sets in the spec the default values that are not secret, i.e. server set names,
+// deployments, replicas, resources, JVM settings and JMS queue names, so that the spec describes what is deployed.
+// Generated passwords and usernames are only kept in the applied status.
+func SetSpecDefaults(cr *api.KieApp) {
+	setSpecDefaults(&cr.Spec, cr.Name)
+}
+
+// setSpecDefaults sets the non-secret defaults of the spec, keeping the order of the server sets
+func setSpecDefaults(spec *api.KieAppSpec, name string) {
+	envConstants, hasEnv := constants.EnvironmentConstants[spec.Environment]
+	if len(spec.CommonConfig.ApplicationName) == 0 {
+		spec.CommonConfig.ApplicationName = name
+	}
+	if len(spec.CommonConfig.AdminUser) == 0 {
+		spec.CommonConfig.AdminUser = constants.DefaultAdminUser
+	}
+	if len(spec.Objects.Servers) == 0 {
+		spec.Objects.Servers = []api.KieServerSet{{}}
+	}
+	setKieSetNames(spec)
+
+	checkJvmOnConsole(&spec.Objects.Console)
+	setResourcesDefault(&spec.Objects.Console.KieAppObject, constants.ConsoleCPULimit, constants.ConsoleCPURequests)
+	if hasEnv && spec.Objects.Console.Replicas == nil {
+		spec.Objects.Console.Replicas = Pint32(envConstants.Replica.Console.Replicas)
+	}
+	for index := range spec.Objects.Servers {
+		serverSet := &spec.Objects.Servers[index]
+		if serverSet.Deployments == nil {
+			serverSet.Deployments = Pint(constants.DefaultKieDeployments)
+		}
+		if hasEnv && serverSet.Replicas == nil {
+			serverSet.Replicas = Pint32(envConstants.Replica.Server.Replicas)
+		}
+		checkJvmOnServer(serverSet)
+		setResourcesDefault(&serverSet.KieAppObject, constants.ServersCPULimit, constants.ServersCPURequests)
+		if serverSet.Jms != nil && serverSet.Jms.EnableIntegration {
+			setJmsDefaults(serverSet.Jms)
+		}
+	}
+	if spec.Objects.SmartRouter != nil {
+		setResourcesDefault(&spec.Objects.SmartRouter.KieAppObject, constants.SmartRouterCPULimit, constants.SmartRouterCPURequests)
+		if hasEnv && spec.Objects.SmartRouter.Replicas == nil {
+			spec.Objects.SmartRouter.Replicas = Pint32(envConstants.Replica.SmartRouter.Replicas)
+		}
+	}
+}
+
 func setJvmDefault(jvm *api.JvmObject) {
 	if jvm != nil {
 		if jvm.JavaMaxMemRatio == nil {
@@ -1124,7 +1361,7 @@ func retainJMSPwds(dstJms *api.KieAppJmsObject, srcJms *api.KieAppJmsObject) {
 	if dstJms.Username == "" {
 		dstJms.Username = srcJms.Username
 	}
-	if dstJms.Password == "" {
+	if dstJms.Password == "" && dstJms.PasswordSecret == nil {
 		dstJms.Password = srcJms.Password
 	}
 }
@@ -1185,11 +1422,11 @@ func getProcessMigrationTemplate(cr *api.KieApp, serversConfig []api.ServerTempl
 				Password: cr.Status.Applied.CommonConfig.AdminPassword,
 			})
 		}
+		if err := checkProcessMigrationDatabase(cr.Status.Applied.Objects.ProcessMigration.Database); err != nil {
+			return nil, err
+		}
 		if cr.Status.Applied.Objects.ProcessMigration.Database.Type == "" {
 			processMigrationTemplate.Database.Type = constants.DefaultProcessMigrationDatabaseType
-		} else if cr.Status.Applied.Objects.ProcessMigration.Database.Type == api.DatabaseExternal &&
-			cr.Status.Applied.Objects.ProcessMigration.Database.ExternalConfig == nil {
-			return nil, fmt.Errorf("external database configuration is mandatory for external database type of process migration")
 		} else {
 			processMigrationTemplate.Database = *cr.Status.Applied.Objects.ProcessMigration.Database.DeepCopy()
 		}
@@ -264,13 +264,13 @@ func TestRhpamAuthoringHAEnvironment(t *testing.T) {
 	assert.Nil(t, err, "Error getting prod environment")
 	checkAuthoringHAEnv(t, cr, env, constants.RhpamPrefix)
 	assert.Equal(t, constants.ImageRegistry+"/"+constants.RhpamPrefix+"-7/"+constants.RhpamPrefix+"-businesscentral-rhel8"+":"+cr.Status.Applied.Version, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Image)
-	amqClusterPassword := getEnvVariable(env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "APPFORMER_JMS_BROKER_PASSWORD")
+	amqClusterPassword := getCredentialEnvVariable(env, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "APPFORMER_JMS_BROKER_PASSWORD")
 	assert.Equal(t, "cluster", amqClusterPassword, "Expected provided password to take effect, but found %v", amqClusterPassword)
-	amqPassword := getEnvVariable(env.Others[0].StatefulSets[1].Spec.Template.Spec.Containers[0], "AMQ_PASSWORD")
+	amqPassword := getCredentialEnvVariable(env, env.Others[0].StatefulSets[1].Spec.Template.Spec.Containers[0], "AMQ_PASSWORD")
 	assert.Equal(t, "amq", amqPassword, "Expected provided password to take effect, but found %v", amqPassword)
-	adminPassword := getEnvVariable(env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "KIE_ADMIN_PWD")
+	adminPassword := getCredentialEnvVariable(env, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "KIE_ADMIN_PWD")
 	assert.Equal(t, "admin", adminPassword, "Expected provided password to take effect, but found %v", adminPassword)
-	amqClusterPassword = getEnvVariable(env.Others[0].StatefulSets[1].Spec.Template.Spec.Containers[0], "AMQ_CLUSTER_PASSWORD")
+	amqClusterPassword = getCredentialEnvVariable(env, env.Others[0].StatefulSets[1].Spec.Template.Spec.Containers[0], "AMQ_CLUSTER_PASSWORD")
 	assert.Equal(t, "cluster", amqClusterPassword, "Expected provided password to take effect, but found %v", amqClusterPassword)
 	pingService := getService(env.Console.Services, "test-rhpamcentr-ping")
 	assert.Len(t, pingService.Spec.Ports, 1, "The ping service should have only one port")
@@ -630,7 +630,7 @@ func testAMQEnvs(t *testing.T, kieserverEnvs []corev1.EnvVar, amqEnvs []corev1.E
 			assert.Equal(t, "adminUser", env.Value)
 
 		case "AMQ_PASSWORD":
-			assert.Equal(t, "adminPassword", env.Value)
+			assert.Equal(t, "test-jms-kieserver-jms-password", env.ValueFrom.SecretKeyRef.Key)
 
 		case "AMQ_PROTOCOL":
 			assert.Equal(t, "tcp", env.Value)
@@ -646,7 +646,7 @@ func testAMQEnvs(t *testing.T, kieserverEnvs []corev1.EnvVar, amqEnvs []corev1.E
 			assert.Equal(t, "adminUser", env.Value)
 
 		case "AMQ_PASSWORD":
-			assert.Equal(t, "adminPassword", env.Value)
+			assert.Equal(t, "test-jms-kieserver-jms-password", env.ValueFrom.SecretKeyRef.Key)
 
 		case "AMQ_ROLE":
 			assert.Equal(t, "admin", env.Value)
@@ -1094,7 +1094,7 @@ func TestAuthoringEnvironment(t *testing.T) {
 	env, err := GetEnvironment(cr, test.MockService())
 	assert.True(t, env.SmartRouter.Omit, "SmarterRouter should be omitted")
 	assert.Nil(t, err, "Error getting authoring environment")
-	dbPassword := getEnvVariable(env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_PASSWORD")
+	dbPassword := getCredentialEnvVariable(env, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_PASSWORD")
 	assert.Equal(t, "Database", dbPassword, "Expected provided password to take effect, but found %v", dbPassword)
 	assert.Equal(t, fmt.Sprintf("%s-kieserver", cr.Name), env.Servers[len(env.Servers)-1].DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Name, "the container name should have incremented")
 	assert.Equal(t, string(appsv1.DeploymentStrategyTypeRolling), string(env.Servers[len(env.Servers)-1].DeploymentConfigs[0].Spec.Strategy.Type), "The DC should use a Rolling strategy when using the H2 DB")
@@ -1246,6 +1246,44 @@ func TestConstructServerObject(t *testing.T) {
 	}
 }
 
+func TestConstructPodCustomizations(t *testing.T) {
+	cr := buildKieApp("test", 1)
+	cr.Spec.Objects.Servers[0].ExtraContainers = []corev1.Container{{
+		Name:         "log-shipper",
+		Image:        "log-shipper:1.0",
+		VolumeMounts: []corev1.VolumeMount{{Name: "logs", MountPath: "/logs"}},
+	}}
+	cr.Spec.Objects.Servers[0].InitContainers = []corev1.Container{{Name: "vault-agent-init", Image: "vault:1.5"}}
+	cr.Spec.Objects.Servers[0].Volumes = []corev1.Volume{{Name: "logs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	cr.Spec.Objects.Servers[0].VolumeMounts = []corev1.VolumeMount{{Name: "logs", MountPath: "/opt/eap/standalone/log"}}
+	cr.Spec.Objects.Servers[0].EnvFrom = []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "server-config"}}}}
+	cr.Spec.Objects.Servers[0].PodSecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: Pbool(true)}
+	cr.Spec.Objects.Servers[0].SecurityContext = &corev1.SecurityContext{ReadOnlyRootFilesystem: Pbool(true)}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err)
+	env = ConsolidateObjects(env, cr)
+
+	podSpec := env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec
+	assert.Len(t, podSpec.Containers, 2)
+	server := podSpec.Containers[0]
+	assert.Equal(t, "test-kieserver", server.Name, "The main container should remain first")
+	for i := range sampleEnv {
+		assert.Contains(t, server.Env, sampleEnv[i])
+	}
+	assert.Equal(t, cr.Spec.Objects.Servers[0].EnvFrom, server.EnvFrom)
+	assert.Contains(t, server.VolumeMounts, corev1.VolumeMount{Name: "logs", MountPath: "/opt/eap/standalone/log"})
+	assert.Greater(t, len(server.VolumeMounts), 1, "The volume mounts of the template should be kept")
+	assert.Equal(t, Pbool(true), server.SecurityContext.ReadOnlyRootFilesystem)
+	assert.Equal(t, cr.Spec.Objects.Servers[0].ExtraContainers[0], podSpec.Containers[1])
+	assert.NotContains(t, podSpec.Containers[1].Env, sampleEnv[0], "The env of the CR should only be set on the containers of the component")
+	assert.Equal(t, "vault-agent-init", podSpec.InitContainers[len(podSpec.InitContainers)-1].Name)
+	assert.Contains(t, podSpec.Volumes, cr.Spec.Objects.Servers[0].Volumes[0])
+	assert.Greater(t, len(podSpec.Volumes), 1, "The volumes of the template should be kept")
+	assert.Equal(t, Pbool(true), podSpec.SecurityContext.RunAsNonRoot)
+
+	assert.Len(t, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers, 1, "Only the customized component should be changed")
+}
+
 func TestSetReplicas(t *testing.T) {
 	name := "test"
 	replicas := Pint32(8)
@@ -1555,10 +1593,8 @@ func TestMergeTrialAndCommonConfig(t *testing.T) {
 	assert.Equal(t, "test-kieserver-http", env.Servers[0].Routes[1].Name)
 
 	// Env vars overrides
-	assert.Contains(t, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
-		Name:  "KIE_ADMIN_PWD",
-		Value: "RedHat",
-	})
+	assert.Contains(t, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Env, getCredentialsEnv("KIE_ADMIN_PWD", "test", constants.CredentialsAdminPassword))
+	assert.Equal(t, "RedHat", getCredentialEnvVariable(env, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "KIE_ADMIN_PWD"))
 	assert.NotContains(t, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
 		Name:  "KIE_SERVER_PROTOCOL",
 		Value: "",
@@ -1745,6 +1781,7 @@ func TestSetProductLabels(t *testing.T) {
 	testObjectLabels(t, cr, env)
 
 	cr.Spec.Version = constants.PriorVersion1
+	cr.Status = api.KieAppStatus{}
 	env, err = GetEnvironment(cr, test.MockService())
 	assert.Nil(t, err, "Error getting trial environment")
 	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version)
@@ -1884,11 +1921,11 @@ func TestPartialTemplateConfig(t *testing.T) {
 
 	assert.Nil(t, err, "Error getting authoring environment")
 	adminUser := getEnvVariable(env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "KIE_ADMIN_USER")
-	adminPassword := getEnvVariable(env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "KIE_ADMIN_PWD")
+	adminPassword := getCredentialEnvVariable(env, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "KIE_ADMIN_PWD")
 	assert.Equal(t, cr.Spec.CommonConfig.AdminUser, adminUser, "Expected provided user to take effect, but found %v", adminUser)
 	assert.Equal(t, cr.Spec.CommonConfig.AdminPassword, adminPassword, "Expected provided password to take effect, but found %v", adminPassword)
 	assert.Equal(t, cr.Spec.CommonConfig.AdminPassword, cr.Status.Applied.CommonConfig.AdminPassword)
-	mavenPassword := getEnvVariable(env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHDMCENTR_MAVEN_REPO_PASSWORD")
+	mavenPassword := getCredentialEnvVariable(env, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHDMCENTR_MAVEN_REPO_PASSWORD")
 	assert.Equal(t, "MyPassword", mavenPassword, "Expected default password of RedHat, but found %v", mavenPassword)
 
 	assert.Equal(t, "test-rhdmcentr", env.Console.DeploymentConfigs[0].Name)
@@ -1919,9 +1956,9 @@ func TestOverwritePartialTrialPasswords(t *testing.T) {
 	env, err := GetEnvironment(cr, test.MockService())
 
 	assert.Nil(t, err, "Error getting trial environment")
-	adminPassword := getEnvVariable(env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "KIE_ADMIN_PWD")
+	adminPassword := getCredentialEnvVariable(env, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "KIE_ADMIN_PWD")
 	assert.Equal(t, "MyPassword", adminPassword, "Expected provided password to take effect, but found %v", adminPassword)
-	mavenPassword := getEnvVariable(env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHDMCENTR_MAVEN_REPO_PASSWORD")
+	mavenPassword := getCredentialEnvVariable(env, env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHDMCENTR_MAVEN_REPO_PASSWORD")
 	assert.Equal(t, "MyPassword", mavenPassword, "Expected default password of RedHat, but found %v", mavenPassword)
 
 	assert.Equal(t, "test-rhdmcentr", env.Console.DeploymentConfigs[0].Name)
@@ -2294,7 +2331,7 @@ func TestDatabaseExternal(t *testing.T) {
 		assert.Equal(t, "10000", getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "TIMER_SERVICE_DATA_STORE_REFRESH_INTERVAL"))
 		assert.Equal(t, "oracle", getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_DRIVER"))
 		assert.Equal(t, "oracleUser", getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_USERNAME"))
-		assert.Equal(t, "oraclePwd", getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_PASSWORD"))
+		assert.Equal(t, "oraclePwd", getCredentialEnvVariable(env, env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_PASSWORD"))
 		assert.Equal(t, "jdbc:oracle:thin:@myoracle.example.com:1521:rhpam7", getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_URL"))
 		assert.Equal(t, "jdbc:oracle:thin:@myoracle.example.com:1521:rhpam7", getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_XA_CONNECTION_PROPERTY_URL"))
 		assert.Equal(t, "false", getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_BACKGROUND_VALIDATION"))
@@ -2573,9 +2610,9 @@ func TestDatabaseMySQLDefaultSize(t *testing.T) {
 		adminUser := getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_USERNAME")
 		assert.NotEmpty(t, adminUser, "The admin user must not be empty")
 		assert.Equal(t, adminUser, getEnvVariable(env.Databases[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "MYSQL_USER"))
-		adminPwd := getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_PASSWORD")
+		adminPwd := getCredentialEnvVariable(env, env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_PASSWORD")
 		assert.NotEmpty(t, adminPwd, "The admin password should have been generated")
-		assert.Equal(t, adminPwd, getEnvVariable(env.Databases[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "MYSQL_PASSWORD"))
+		assert.Equal(t, adminPwd, getCredentialEnvVariable(env, env.Databases[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "MYSQL_PASSWORD"))
 		dbName := getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_DATABASE")
 		assert.NotEmpty(t, dbName, "The Database Name must not be empty")
 		assert.Equal(t, dbName, getEnvVariable(env.Databases[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "MYSQL_DATABASE"))
@@ -2753,9 +2790,9 @@ func TestDatabasePostgresqlDefaultSize(t *testing.T) {
 		adminUser := getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_USERNAME")
 		assert.NotEmpty(t, adminUser, "The admin user must not be empty")
 		assert.Equal(t, adminUser, getEnvVariable(env.Databases[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "POSTGRESQL_USER"))
-		adminPwd := getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_PASSWORD")
+		adminPwd := getCredentialEnvVariable(env, env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_PASSWORD")
 		assert.NotEmpty(t, adminPwd, "The admin password should have been generated")
-		assert.Equal(t, adminPwd, getEnvVariable(env.Databases[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "POSTGRESQL_PASSWORD"))
+		assert.Equal(t, adminPwd, getCredentialEnvVariable(env, env.Databases[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "POSTGRESQL_PASSWORD"))
 		dbName := getEnvVariable(env.Servers[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "RHPAM_DATABASE")
 		assert.NotEmpty(t, dbName, "The Database Name must not be empty")
 		assert.Equal(t, dbName, getEnvVariable(env.Databases[i].DeploymentConfigs[0].Spec.Template.Spec.Containers[0], "POSTGRESQL_DATABASE"))
@@ -3881,6 +3918,13 @@ func TestMergeProcessMigrationDB(t *testing.T) {
 			},
 			api.Environment{
 				ProcessMigration: api.CustomObject{
+					DeploymentConfigs: []appsv1.DeploymentConfig{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "kietest-process-migration",
+							},
+						},
+					},
 					ConfigMaps: []corev1.ConfigMap{
 						{
 							ObjectMeta: metav1.ObjectMeta{
@@ -4830,3 +4874,72 @@ func testContext(t *testing.T, image, version, context, label string) {
 		assert.Equal(t, constants.PamContext+label+constants.RhelVersion+":"+version, image)
 	}
 }
+
+func TestSetSpecDefaults(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamProduction,
+			Objects: api.KieAppObjects{
+				Servers: []api.KieServerSet{
+					{},
+					{Name: "jms", Jms: &api.KieAppJmsObject{EnableIntegration: true, EnableAudit: true}},
+				},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr.DeepCopy(), test.MockService())
+	assert.Nil(t, err)
+
+	SetSpecDefaults(cr)
+	assert.Equal(t, "test", cr.Spec.CommonConfig.ApplicationName)
+	assert.Equal(t, constants.DefaultAdminUser, cr.Spec.CommonConfig.AdminUser)
+	assert.Empty(t, cr.Spec.CommonConfig.AdminPassword, "Passwords should not be set in the spec")
+	replicas := constants.EnvironmentConstants[api.RhpamProduction].Replica
+	assert.Equal(t, replicas.Console.Replicas, *cr.Spec.Objects.Console.Replicas)
+	assert.Equal(t, int32(80), *cr.Spec.Objects.Console.Jvm.JavaMaxMemRatio)
+	assert.Equal(t, resource.MustParse(constants.ConsoleCPULimit), cr.Spec.Objects.Console.Resources.Limits[corev1.ResourceCPU])
+	assert.Len(t, cr.Spec.Objects.Servers, 2)
+	assert.Equal(t, "test-kieserver", cr.Spec.Objects.Servers[0].Name, "The order of the server sets should be kept")
+	assert.Equal(t, "jms", cr.Spec.Objects.Servers[1].Name)
+	for _, server := range cr.Spec.Objects.Servers {
+		assert.Equal(t, constants.DefaultKieDeployments, *server.Deployments)
+		assert.Equal(t, replicas.Server.Replicas, *server.Replicas)
+		assert.Equal(t, int32(25), *server.Jvm.JavaInitialMemRatio)
+	}
+	jms := cr.Spec.Objects.Servers[1].Jms
+	assert.Equal(t, constants.DefaultQueueExecutor, jms.QueueExecutor)
+	assert.Equal(t, constants.DefaultQueueAudit, jms.QueueAudit)
+	assert.Empty(t, jms.QueueSignal, "Signal queue is not enabled")
+	assert.Empty(t, jms.Username)
+	assert.Empty(t, jms.Password)
+
+	defaulted := cr.DeepCopy()
+	SetSpecDefaults(defaulted)
+	assert.Equal(t, cr, defaulted, "Defaults should be idempotent")
+
+	defaultedEnv, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err)
+	assert.Equal(t, len(env.Servers), len(defaultedEnv.Servers))
+	replicasByName := map[string]int32{}
+	for _, server := range env.Servers {
+		replicasByName[server.DeploymentConfigs[0].Name] = server.DeploymentConfigs[0].Spec.Replicas
+	}
+	for _, server := range defaultedEnv.Servers {
+		assert.Contains(t, replicasByName, server.DeploymentConfigs[0].Name, "The defaulted spec should deploy the same servers")
+		assert.Equal(t, replicasByName[server.DeploymentConfigs[0].Name], server.DeploymentConfigs[0].Spec.Replicas)
+	}
+	assert.Equal(t, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Resources, defaultedEnv.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Resources)
+}
+
+func TestIsPaused(t *testing.T) {
+	cr := &api.KieApp{}
+	assert.False(t, IsPaused(cr))
+	cr.Spec.Paused = true
+	assert.True(t, IsPaused(cr))
+	cr.Spec.Paused = false
+	cr.Annotations = map[string]string{constants.PausedAnnotation: "true"}
+	assert.True(t, IsPaused(cr))
+	cr.Annotations[constants.PausedAnnotation] = "invalid"
+	assert.False(t, IsPaused(cr), "Invalid annotation values should be ignored")
+}
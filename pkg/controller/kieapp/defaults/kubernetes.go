@@ -0,0 +1,175 @@
+package defaults
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// IsKubernetes returns true when the KieApp objects are generated for a vanilla Kubernetes cluster
+func IsKubernetes(cr *api.KieApp) bool {
+	return cr.Status.Applied.Platform == api.PlatformKubernetes
+}
+
+// ConvertToKubernetes replaces the OpenShift specific objects of the environment with Deployments and Ingresses,
+// resolving the image stream triggers to plain image references. BuildConfigs and ImageStreams are skipped.
+func ConvertToKubernetes(env api.Environment, cr *api.KieApp) api.Environment {
+	spec := cr.Status.Applied
+	appName := spec.CommonConfig.ApplicationName
+	env.Console = convertObject(env.Console, cr, getIngressTLSSecret(spec.Objects.Console.Route, spec.Objects.Console.KeystoreSecret,
+		fmt.Sprintf(constants.KeystoreSecret, strings.Join([]string{appName, "businesscentral"}, "-"))))
+	for index := range env.Servers {
+		serverSet, kieDeploymentName := GetServerSet(cr, index)
+		env.Servers[index] = convertObject(env.Servers[index], cr, getIngressTLSSecret(serverSet.Route, serverSet.KeystoreSecret,
+			fmt.Sprintf(constants.KeystoreSecret, kieDeploymentName)))
+	}
+	if spec.Objects.SmartRouter != nil {
+		env.SmartRouter = convertObject(env.SmartRouter, cr, getIngressTLSSecret(spec.Objects.SmartRouter.Route, spec.Objects.SmartRouter.KeystoreSecret,
+			fmt.Sprintf(constants.KeystoreSecret, strings.Join([]string{appName, "smartrouter"}, "-"))))
+	} else {
+		env.SmartRouter = convertObject(env.SmartRouter, cr, "")
+	}
+	if spec.Objects.ProcessMigration != nil {
+		env.ProcessMigration = convertObject(env.ProcessMigration, cr, getTLSSecret(spec.Objects.ProcessMigration.Route))
+	} else {
+		env.ProcessMigration = convertObject(env.ProcessMigration, cr, "")
+	}
+	for index := range env.Databases {
+		env.Databases[index] = convertObject(env.Databases[index], cr, "")
+	}
+	for index := range env.Others {
+		env.Others[index] = convertObject(env.Others[index], cr, "")
+	}
+	return env
+}
+
+// getIngressTLSSecret returns the Secret holding the certificate of the ingresses of a component: the TLS Secret of its
+// route, or else the generated keystore Secret, which also holds the PEM encoded certificate on Kubernetes
+func getIngressTLSSecret(route *api.RouteObject, keystoreSecret, generatedSecret string) string {
+	if tlsSecret := getTLSSecret(route); tlsSecret != "" {
+		return tlsSecret
+	}
+	if keystoreSecret != "" {
+		// the provided keystore only holds a Java keystore, the ingress controller serves its default certificate
+		return ""
+	}
+	return generatedSecret
+}
+
+func convertObject(object api.CustomObject, cr *api.KieApp, tlsSecret string) api.CustomObject {
+	for _, dc := range object.DeploymentConfigs {
+		object.Deployments = append(object.Deployments, convertDeploymentConfig(dc, cr))
+	}
+	for _, route := range object.Routes {
+		object.Ingresses = append(object.Ingresses, convertRoute(route, tlsSecret))
+	}
+	for _, bc := range object.BuildConfigs {
+		log.Warnf("Skipping BuildConfig %s, builds are not supported on the %s platform", bc.Name, api.PlatformKubernetes)
+	}
+	object.DeploymentConfigs = nil
+	object.Routes = nil
+	object.BuildConfigs = nil
+	object.ImageStreams = nil
+	return object
+}
+
+func convertDeploymentConfig(dc oappsv1.DeploymentConfig, cr *api.KieApp) appsv1.Deployment {
+	deployment := appsv1.Deployment{
+		ObjectMeta: *dc.ObjectMeta.DeepCopy(),
+		Spec: appsv1.DeploymentSpec{
+			Replicas:             Pint32(dc.Spec.Replicas),
+			Selector:             &metav1.LabelSelector{MatchLabels: dc.Spec.Selector},
+			MinReadySeconds:      dc.Spec.MinReadySeconds,
+			RevisionHistoryLimit: dc.Spec.RevisionHistoryLimit,
+			Paused:               dc.Spec.Paused,
+		},
+	}
+	if dc.Spec.Template != nil {
+		deployment.Spec.Template = *dc.Spec.Template.DeepCopy()
+	}
+	switch dc.Spec.Strategy.Type {
+	case oappsv1.DeploymentStrategyTypeRecreate:
+		deployment.Spec.Strategy.Type = appsv1.RecreateDeploymentStrategyType
+	case oappsv1.DeploymentStrategyTypeRolling:
+		deployment.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+		if params := dc.Spec.Strategy.RollingParams; params != nil {
+			deployment.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+				MaxSurge:       params.MaxSurge,
+				MaxUnavailable: params.MaxUnavailable,
+			}
+		}
+	}
+	for _, trigger := range dc.Spec.Triggers {
+		if trigger.Type != oappsv1.DeploymentTriggerOnImageChange || trigger.ImageChangeParams == nil {
+			continue
+		}
+		for _, containerName := range trigger.ImageChangeParams.ContainerNames {
+			for index, container := range deployment.Spec.Template.Spec.Containers {
+				if container.Name == containerName {
+					deployment.Spec.Template.Spec.Containers[index].Image = getTriggerImage(trigger.ImageChangeParams.From, container.Image, cr)
+				}
+			}
+		}
+	}
+	return deployment
+}
+
+// getTriggerImage returns the image that the image change trigger would have deployed on OpenShift
+func getTriggerImage(from corev1.ObjectReference, imageURL string, cr *api.KieApp) string {
+	if from.Kind == "DockerImage" {
+		return from.Name
+	}
+	_, registryURL := GetImageRegistryURL(from.Name, imageURL, cr)
+	return registryURL
+}
+
+// convertRoute returns the ingress of the route, the TLS Secret holding the certificate of the secure ones. The routes
+// are expected to have a host, as set on the ingress domain.
+func convertRoute(route routev1.Route, tlsSecret string) networkingv1beta1.Ingress {
+	ingress := networkingv1beta1.Ingress{
+		ObjectMeta: *route.ObjectMeta.DeepCopy(),
+	}
+	backend := networkingv1beta1.IngressBackend{ServiceName: route.Spec.To.Name}
+	if route.Spec.Port != nil {
+		backend.ServicePort = route.Spec.Port.TargetPort
+	} else {
+		backend.ServicePort = intstr.FromInt(80)
+	}
+	// routes without a path serve every path of their host
+	path := route.Spec.Path
+	if path == "" {
+		path = "/"
+	}
+	pathType := networkingv1beta1.PathTypePrefix
+	ingress.Spec.Rules = []networkingv1beta1.IngressRule{{
+		Host: route.Spec.Host,
+		IngressRuleValue: networkingv1beta1.IngressRuleValue{
+			HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+				Paths: []networkingv1beta1.HTTPIngressPath{{Path: path, PathType: &pathType, Backend: backend}},
+			},
+		},
+	}}
+	if route.Spec.TLS != nil {
+		if ingress.Annotations == nil {
+			ingress.Annotations = map[string]string{}
+		}
+		switch route.Spec.TLS.Termination {
+		case routev1.TLSTerminationPassthrough:
+			ingress.Annotations[constants.IngressSSLPassthroughAnnotation] = "true"
+			ingress.Annotations[constants.IngressBackendProtocolAnnotation] = "HTTPS"
+		case routev1.TLSTerminationReencrypt:
+			ingress.Annotations[constants.IngressBackendProtocolAnnotation] = "HTTPS"
+		}
+		ingress.Spec.TLS = []networkingv1beta1.IngressTLS{{Hosts: []string{route.Spec.Host}, SecretName: tlsSecret}}
+	}
+	return ingress
+}
@@ -0,0 +1,126 @@
+package defaults
+
+import (
+	"fmt"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestConvertToKubernetes(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "testns",
+		},
+		Spec: api.KieAppSpec{
+			Environment:   api.RhpamProductionImmutable,
+			Platform:      api.PlatformKubernetes,
+			IngressDomain: "apps.example.com",
+			UseImageTags:  true,
+			Objects: api.KieAppObjects{
+				Servers: []api.KieServerSet{
+					{
+						Build: &api.KieAppBuildObject{
+							KieServerContainerDeployment: "rhpam-kieserver-library=org.openshift.quickstarts:rhpam-kieserver-library:1.5.0-SNAPSHOT",
+							GitSource: api.GitSource{
+								URI:       "http://git.example.com",
+								Reference: "somebranch",
+							},
+						},
+					},
+					{
+						Name: "docker",
+						From: &api.ImageObjRef{
+							Kind:            "DockerImage",
+							ObjectReference: api.ObjectReference{Name: "quay.io/example/kieserver:1.0"},
+						},
+					},
+				},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	assert.True(t, IsKubernetes(cr))
+	env = ConsolidateObjects(env, cr)
+
+	for _, object := range append(env.Servers, env.Console, env.SmartRouter) {
+		assert.Empty(t, object.DeploymentConfigs)
+		assert.Empty(t, object.Routes)
+		assert.Empty(t, object.BuildConfigs)
+		assert.Empty(t, object.ImageStreams)
+	}
+
+	assert.Len(t, env.Servers, 2)
+	assert.Equal(t, "quay.io/example/kieserver:1.0", env.Servers[0].Deployments[0].Spec.Template.Spec.Containers[0].Image)
+	assert.Len(t, env.Servers[1].Deployments, 1)
+	server := env.Servers[1].Deployments[0]
+	assert.Equal(t, "test-kieserver", server.Name)
+	assert.Equal(t, int32(2), *server.Spec.Replicas)
+	assert.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, server.Spec.Strategy.Type)
+	maxSurge := intstr.FromString("100%")
+	assert.Equal(t, &maxSurge, server.Spec.Strategy.RollingUpdate.MaxSurge)
+	assert.Equal(t, map[string]string{"deploymentConfig": "test-kieserver"}, server.Spec.Selector.MatchLabels)
+	assert.Equal(t, "test-kieserver", server.Spec.Template.Labels["deploymentConfig"])
+	assert.Equal(t, fmt.Sprintf("%s/rhpam-7/test-kieserver:latest", constants.ImageRegistry), server.Spec.Template.Spec.Containers[0].Image, "Built image is expected in the registry")
+	assert.Len(t, env.Servers[1].Ingresses, 1)
+	ingress := env.Servers[1].Ingresses[0]
+	assert.Equal(t, "test-kieserver", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.ServiceName)
+	assert.Equal(t, "true", ingress.Annotations[constants.IngressSSLPassthroughAnnotation])
+	assert.Equal(t, "test-kieserver-testns.apps.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "/", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, []networkingv1beta1.IngressTLS{{Hosts: []string{"test-kieserver-testns.apps.example.com"}, SecretName: "test-kieserver-app-secret"}}, ingress.Spec.TLS)
+
+	hosts := map[string]bool{}
+	for _, object := range append(env.Servers, env.Console) {
+		for _, ingress := range object.Ingresses {
+			assert.False(t, hosts[ingress.Spec.Rules[0].Host], "Ingress host %s is not unique", ingress.Spec.Rules[0].Host)
+			hosts[ingress.Spec.Rules[0].Host] = true
+		}
+	}
+}
+
+func TestKubernetesRequiresIngressDomain(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamTrial,
+			Platform:    api.PlatformKubernetes,
+		},
+	}
+	_, err := GetEnvironment(cr, test.MockService())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.ingressDomain")
+}
+
+func TestIngressTLSSecret(t *testing.T) {
+	assert.Equal(t, "tls", getIngressTLSSecret(&api.RouteObject{TLSSecret: "tls"}, "keystore", "generated"))
+	assert.Equal(t, "", getIngressTLSSecret(nil, "keystore", "generated"), "The provided keystore can't be served by the ingress")
+	assert.Equal(t, "generated", getIngressTLSSecret(&api.RouteObject{}, "", "generated"))
+}
+
+func TestConvertRouteWithHost(t *testing.T) {
+	env, err := getEnvironment(api.RhpamTrial, "test")
+	assert.Nil(t, err)
+	route := env.Console.Routes[0]
+	route.Spec.Host = "console.example.com"
+	route.Spec.TLS.Termination = "reencrypt"
+
+	ingress := convertRoute(route, "tls")
+	assert.Equal(t, route.Name, ingress.Name)
+	assert.Equal(t, "console.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "/", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, []networkingv1beta1.IngressTLS{{Hosts: []string{"console.example.com"}, SecretName: "tls"}}, ingress.Spec.TLS)
+	assert.Equal(t, "HTTPS", ingress.Annotations[constants.IngressBackendProtocolAnnotation])
+	assert.NotContains(t, ingress.Annotations, constants.IngressSSLPassthroughAnnotation)
+}
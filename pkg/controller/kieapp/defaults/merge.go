@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
 
@@ -62,11 +63,13 @@ func mergeCustomObject(baseline api.CustomObject, overwrite api.CustomObject) ap
 	object.Roles = mergeRoles(baseline.Roles, overwrite.Roles)
 	object.RoleBindings = mergeRoleBindings(baseline.RoleBindings, overwrite.RoleBindings)
 	object.DeploymentConfigs = mergeDeploymentConfigs(baseline.DeploymentConfigs, overwrite.DeploymentConfigs)
+	object.Deployments = mergeDeployments(baseline.Deployments, overwrite.Deployments)
 	object.StatefulSets = mergeStatefulSets(baseline.StatefulSets, overwrite.StatefulSets)
 	object.ImageStreams = mergeImageStreams(baseline.ImageStreams, overwrite.ImageStreams)
 	object.BuildConfigs = mergeBuildConfigs(baseline.BuildConfigs, overwrite.BuildConfigs)
 	object.Services = mergeServices(baseline.Services, overwrite.Services)
 	object.Routes = mergeRoutes(baseline.Routes, overwrite.Routes)
+	object.Ingresses = mergeIngresses(baseline.Ingresses, overwrite.Ingresses)
 	object.ConfigMaps = mergeConfigMaps(baseline.ConfigMaps, overwrite.ConfigMaps)
 	return object
 }
@@ -238,6 +241,43 @@ func mergeDeploymentConfigs(baseline []oappsv1.DeploymentConfig, overwrite []oap
 
 }
 
+func mergeDeployments(baseline []appsv1.Deployment, overwrite []appsv1.Deployment) []appsv1.Deployment {
+	if len(overwrite) == 0 {
+		return baseline
+	}
+	if len(baseline) == 0 {
+		return overwrite
+	}
+	baselineRefs := getDeploymentReferenceSlice(baseline)
+	overwriteRefs := getDeploymentReferenceSlice(overwrite)
+	for overwriteIndex := range overwrite {
+		overwriteItem := &overwrite[overwriteIndex]
+		baselineIndex, _ := findOpenShiftObject(overwriteItem, baselineRefs)
+		if baselineIndex >= 0 {
+			baselineItem := baseline[baselineIndex]
+			err := mergo.Merge(&overwriteItem.ObjectMeta, baselineItem.ObjectMeta)
+			if err != nil {
+				log.Error("Error merging interfaces. ", err)
+				return nil
+			}
+			mergedSpec, err := mergeDeploymentSpec(baselineItem.Spec, overwriteItem.Spec)
+			if err != nil {
+				log.Error("Error merging Deployment Specs. ", err)
+				return nil
+			}
+			overwriteItem.Spec = mergedSpec
+		}
+	}
+	slice := make([]appsv1.Deployment, combinedSize(baselineRefs, overwriteRefs))
+	err := mergeObjects(baselineRefs, overwriteRefs, slice)
+	if err != nil {
+		log.Error("Error merging objects. ", err)
+		return nil
+	}
+	return slice
+
+}
+
 func mergeStatefulSets(baseline []appsv1.StatefulSet, overwrite []appsv1.StatefulSet) []appsv1.StatefulSet {
 	if len(overwrite) == 0 {
 		return baseline
@@ -357,6 +397,20 @@ func mergeDCSpec(baseline oappsv1.DeploymentConfigSpec, overwrite oappsv1.Deploy
 	return baseline, nil
 }
 
+func mergeDeploymentSpec(baseline appsv1.DeploymentSpec, overwrite appsv1.DeploymentSpec) (appsv1.DeploymentSpec, error) {
+	mergedTemplate, err := mergeTemplate(&baseline.Template, &overwrite.Template)
+	if err != nil {
+		return appsv1.DeploymentSpec{}, err
+	}
+	overwrite.Template = *mergedTemplate
+
+	err = mergo.Merge(&baseline, overwrite, mergo.WithOverride)
+	if err != nil {
+		return appsv1.DeploymentSpec{}, nil
+	}
+	return baseline, nil
+}
+
 func mergeStatefulSpec(baseline appsv1.StatefulSetSpec, overwrite appsv1.StatefulSetSpec) (appsv1.StatefulSetSpec, error) {
 	mergedTemplate, err := mergeTemplate(&baseline.Template, &overwrite.Template)
 	if err != nil {
@@ -498,6 +552,12 @@ func mergePodSpecs(baseline corev1.PodSpec, overwrite corev1.PodSpec) (corev1.Po
 	}
 	overwrite.Containers = mergedContainers
 
+	mergedInitContainers, err := mergeContainers(baseline.InitContainers, overwrite.InitContainers)
+	if err != nil {
+		return corev1.PodSpec{}, err
+	}
+	overwrite.InitContainers = mergedInitContainers
+
 	mergedVolumes, err := mergeVolumes(baseline.Volumes, overwrite.Volumes)
 	if err != nil {
 		return corev1.PodSpec{}, err
@@ -511,36 +571,46 @@ func mergePodSpecs(baseline corev1.PodSpec, overwrite corev1.PodSpec) (corev1.Po
 	return baseline, nil
 }
 
+// mergeContainers merges the containers with the same name and appends the other overwrite ones, e.g. sidecars
 func mergeContainers(baseline []corev1.Container, overwrite []corev1.Container) ([]corev1.Container, error) {
 	if len(overwrite) == 0 {
 		return baseline, nil
 	} else if len(baseline) == 0 {
 		return overwrite, nil
-	} else if len(baseline) > 1 || len(overwrite) > 1 {
-		err := errors.New("Merge algorithm does not yet support multiple containers within a deployment")
-		return nil, err
-	}
-	if baseline[0].Env == nil {
-		baseline[0].Env = make([]corev1.EnvVar, 0)
 	}
-	overwrite[0].Env = shared.EnvOverride(baseline[0].Env, overwrite[0].Env)
-	mergedPorts, err := mergePorts(baseline[0].Ports, overwrite[0].Ports)
-	if err != nil {
-		return nil, err
+	for overwriteIndex, overwriteItem := range overwrite {
+		idx, _ := findContainer(overwriteItem, baseline)
+		if idx == -1 {
+			log.Debugf("Not found, appending %v to slice\n", overwriteItem.Name)
+			baseline = append(baseline, overwrite[overwriteIndex])
+			continue
+		}
+		err := mergeContainer(&baseline[idx], overwriteItem)
+		if err != nil {
+			return nil, err
+		}
 	}
-	overwrite[0].Ports = mergedPorts
+	return baseline, nil
+}
 
-	mergedVolumeMounts, err := mergeVolumeMounts(baseline[0].VolumeMounts, overwrite[0].VolumeMounts)
+func mergeContainer(baseline *corev1.Container, overwrite corev1.Container) error {
+	if baseline.Env == nil {
+		baseline.Env = make([]corev1.EnvVar, 0)
+	}
+	overwrite.Env = shared.EnvOverride(baseline.Env, overwrite.Env)
+	mergedPorts, err := mergePorts(baseline.Ports, overwrite.Ports)
 	if err != nil {
-		return []corev1.Container{}, err
+		return err
 	}
-	overwrite[0].VolumeMounts = mergedVolumeMounts
+	overwrite.Ports = mergedPorts
 
-	err = mergo.Merge(&baseline[0], overwrite[0], mergo.WithOverride)
+	mergedVolumeMounts, err := mergeVolumeMounts(baseline.VolumeMounts, overwrite.VolumeMounts)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return baseline, nil
+	overwrite.VolumeMounts = mergedVolumeMounts
+
+	return mergo.Merge(baseline, overwrite, mergo.WithOverride)
 }
 
 func mergePorts(baseline []corev1.ContainerPort, overwrite []corev1.ContainerPort) ([]corev1.ContainerPort, error) {
@@ -620,6 +690,15 @@ func mergeVolumeMounts(baseline []corev1.VolumeMount, overwrite []corev1.VolumeM
 	return mergedVolumeMounts, nil
 }
 
+func findContainer(object corev1.Container, slice []corev1.Container) (int, corev1.Container) {
+	for index, candidate := range slice {
+		if candidate.Name == object.Name {
+			return index, candidate
+		}
+	}
+	return -1, corev1.Container{}
+}
+
 func findVolume(object corev1.Volume, slice []corev1.Volume) (int, corev1.Volume) {
 	for index, candidate := range slice {
 		if candidate.Name == object.Name {
@@ -646,6 +725,14 @@ func getDeploymentConfigReferenceSlice(objects []oappsv1.DeploymentConfig) []api
 	return slice
 }
 
+func getDeploymentReferenceSlice(objects []appsv1.Deployment) []api.OpenShiftObject {
+	slice := make([]api.OpenShiftObject, len(objects))
+	for index := range objects {
+		slice[index] = &objects[index]
+	}
+	return slice
+}
+
 func getStatefulSetReferenceSlice(objects []appsv1.StatefulSet) []api.OpenShiftObject {
 	slice := make([]api.OpenShiftObject, len(objects))
 	for index := range objects {
@@ -761,6 +848,32 @@ func getRouteReferenceSlice(objects []routev1.Route) []api.OpenShiftObject {
 	return slice
 }
 
+func mergeIngresses(baseline []networkingv1beta1.Ingress, overwrite []networkingv1beta1.Ingress) []networkingv1beta1.Ingress {
+	if len(overwrite) == 0 {
+		return baseline
+	} else if len(baseline) == 0 {
+		return overwrite
+	} else {
+		baselineRefs := getIngressReferenceSlice(baseline)
+		overwriteRefs := getIngressReferenceSlice(overwrite)
+		slice := make([]networkingv1beta1.Ingress, combinedSize(baselineRefs, overwriteRefs))
+		err := mergeObjects(baselineRefs, overwriteRefs, slice)
+		if err != nil {
+			log.Error("Error merging objects. ", err)
+			return nil
+		}
+		return slice
+	}
+}
+
+func getIngressReferenceSlice(objects []networkingv1beta1.Ingress) []api.OpenShiftObject {
+	slice := make([]api.OpenShiftObject, len(objects))
+	for index := range objects {
+		slice[index] = &objects[index]
+	}
+	return slice
+}
+
 func mergeConfigMaps(baseline []corev1.ConfigMap, overwrite []corev1.ConfigMap) []corev1.ConfigMap {
 	if len(overwrite) == 0 {
 		return baseline
@@ -8,7 +8,9 @@ import (
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
 	appsv1 "github.com/openshift/api/apps/v1"
 	"github.com/stretchr/testify/assert"
+	kappsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -302,6 +304,59 @@ func TestMergeDeploymentconfigs(t *testing.T) {
 	assert.Equal(t, overwrite[1], results[0])
 }
 
+func TestMergeDeployments(t *testing.T) {
+	baseline := []kappsv1.Deployment{
+		{
+			ObjectMeta: *buildObjectMeta("deployment1"),
+			Spec: kappsv1.DeploymentSpec{
+				Replicas: Pint32(1),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "container1", Image: "baseline"}},
+					},
+				},
+			},
+		},
+	}
+	overwrite := []kappsv1.Deployment{
+		{
+			ObjectMeta: *buildObjectMeta("deployment1"),
+			Spec: kappsv1.DeploymentSpec{
+				Replicas: Pint32(3),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "container1", Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}}}},
+					},
+				},
+			},
+		},
+		{ObjectMeta: *buildObjectMeta("deployment2")},
+	}
+	results := mergeDeployments(baseline, overwrite)
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, "deployment1", results[0].Name)
+	assert.Equal(t, int32(3), *results[0].Spec.Replicas)
+	assert.Equal(t, "baseline", results[0].Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "FOO", Value: "bar"}}, results[0].Spec.Template.Spec.Containers[0].Env)
+	assert.Equal(t, "deployment2", results[1].Name)
+}
+
+func TestMergeIngresses(t *testing.T) {
+	baseline := []networkingv1beta1.Ingress{
+		{ObjectMeta: *buildObjectMeta("ingress1")},
+		{ObjectMeta: *buildObjectMeta("ingress2")},
+	}
+	overwrite := []networkingv1beta1.Ingress{
+		{ObjectMeta: *buildObjectMeta("ingress1"), Spec: networkingv1beta1.IngressSpec{Rules: []networkingv1beta1.IngressRule{{Host: "example.com"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ingress2", Annotations: map[string]string{"delete": "true"}}},
+	}
+	results := mergeIngresses(baseline, overwrite)
+
+	assert.Len(t, results, 1)
+	assert.Equal(t, "example.com", results[0].Spec.Rules[0].Host)
+}
+
 func TestMergeDeploymentconfigs_Metadata(t *testing.T) {
 	baseline := []appsv1.DeploymentConfig{
 		*buildDC("dc1"),
@@ -588,7 +643,7 @@ func getParsedTemplateWithDB(filename string, name string, object interface{}) e
 }
 
 func getParsedTemplateFromCR(cr *api.KieApp, filename string, object interface{}) error {
-	envTemplate, err := getEnvTemplate(cr)
+	envTemplate, err := getEnvTemplate(cr, test.MockService())
 	if err != nil {
 		log.Error("Error getting environment template", err)
 	}
@@ -755,3 +810,24 @@ func buildConfigMap(name string, data map[string]string, binaryData map[string][
 		BinaryData: binaryData,
 	}
 }
+
+func TestMergeContainersByName(t *testing.T) {
+	baseline := []corev1.Container{{
+		Name:         "server",
+		Image:        "server:1.0",
+		Env:          []corev1.EnvVar{{Name: "A", Value: "1"}},
+		VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}},
+	}}
+	overwrite := []corev1.Container{
+		{Name: "sidecar", Image: "sidecar:1.0"},
+		{Name: "server", Env: []corev1.EnvVar{{Name: "B", Value: "2"}}, VolumeMounts: []corev1.VolumeMount{{Name: "certs", MountPath: "/certs"}}},
+	}
+	merged, err := mergeContainers(baseline, overwrite)
+	assert.Nil(t, err)
+	assert.Len(t, merged, 2)
+	assert.Equal(t, "server", merged[0].Name, "The containers of the baseline should come first")
+	assert.Equal(t, "server:1.0", merged[0].Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}}, merged[0].Env)
+	assert.Len(t, merged[0].VolumeMounts, 2)
+	assert.Equal(t, "sidecar", merged[1].Name, "Containers not found in the baseline should be appended")
+}
@@ -0,0 +1,105 @@
+package defaults
+
+import (
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+)
+
+// setMetadata adds the common labels and annotations defined in the CR to the objects of all the components, and the
+// ones of each component to its pods, services and routes
+func setMetadata(env api.Environment, cr *api.KieApp) api.Environment {
+	spec := cr.Status.Applied
+	setObjectMetadata(&env.Console, spec, spec.Objects.Console.MetadataObject)
+	for index := range env.Servers {
+		serverSet, _ := GetServerSet(cr, index)
+		setObjectMetadata(&env.Servers[index], spec, serverSet.MetadataObject)
+	}
+	if spec.Objects.SmartRouter != nil {
+		setObjectMetadata(&env.SmartRouter, spec, spec.Objects.SmartRouter.MetadataObject)
+	}
+	if spec.Objects.ProcessMigration != nil {
+		setObjectMetadata(&env.ProcessMigration, spec, spec.Objects.ProcessMigration.MetadataObject)
+	}
+	for index := range env.Databases {
+		setObjectMetadata(&env.Databases[index], spec, api.MetadataObject{})
+	}
+	for index := range env.Others {
+		setObjectMetadata(&env.Others[index], spec, api.MetadataObject{})
+	}
+	return env
+}
+
+// setObjectMetadata sets the labels and annotations on the DeploymentConfigs, StatefulSets, Services, Routes,
+// PersistentVolumeClaims and Secrets of the object, and on the pods of the workloads
+func setObjectMetadata(object *api.CustomObject, spec api.KieAppSpec, metadata api.MetadataObject) {
+	for index := range object.DeploymentConfigs {
+		dc := &object.DeploymentConfigs[index]
+		dc.Labels = addLabels(dc.Labels, spec.CommonLabels)
+		dc.Annotations = addAnnotations(dc.Annotations, spec.CommonAnnotations)
+		if dc.Spec.Template != nil {
+			dc.Spec.Template.Labels = addLabels(dc.Spec.Template.Labels, metadata.PodLabels, spec.CommonLabels)
+			dc.Spec.Template.Annotations = addAnnotations(dc.Spec.Template.Annotations, metadata.PodAnnotations)
+		}
+	}
+	for index := range object.Deployments {
+		deployment := &object.Deployments[index]
+		deployment.Labels = addLabels(deployment.Labels, spec.CommonLabels)
+		deployment.Annotations = addAnnotations(deployment.Annotations, spec.CommonAnnotations)
+		deployment.Spec.Template.Labels = addLabels(deployment.Spec.Template.Labels, metadata.PodLabels, spec.CommonLabels)
+		deployment.Spec.Template.Annotations = addAnnotations(deployment.Spec.Template.Annotations, metadata.PodAnnotations)
+	}
+	for index := range object.StatefulSets {
+		statefulSet := &object.StatefulSets[index]
+		statefulSet.Labels = addLabels(statefulSet.Labels, spec.CommonLabels)
+		statefulSet.Annotations = addAnnotations(statefulSet.Annotations, spec.CommonAnnotations)
+		statefulSet.Spec.Template.Labels = addLabels(statefulSet.Spec.Template.Labels, metadata.PodLabels, spec.CommonLabels)
+		statefulSet.Spec.Template.Annotations = addAnnotations(statefulSet.Spec.Template.Annotations, metadata.PodAnnotations)
+	}
+	for index := range object.Services {
+		service := &object.Services[index]
+		service.Labels = addLabels(service.Labels, spec.CommonLabels)
+		service.Annotations = addAnnotations(service.Annotations, spec.CommonAnnotations, metadata.ServiceAnnotations)
+	}
+	for index := range object.Routes {
+		route := &object.Routes[index]
+		route.Labels = addLabels(route.Labels, spec.CommonLabels)
+		route.Annotations = addAnnotations(route.Annotations, spec.CommonAnnotations, metadata.RouteAnnotations)
+	}
+	for index := range object.PersistentVolumeClaims {
+		pvc := &object.PersistentVolumeClaims[index]
+		pvc.Labels = addLabels(pvc.Labels, spec.CommonLabels)
+		pvc.Annotations = addAnnotations(pvc.Annotations, spec.CommonAnnotations)
+	}
+	for index := range object.Secrets {
+		secret := &object.Secrets[index]
+		secret.Labels = addLabels(secret.Labels, spec.CommonLabels)
+		secret.Annotations = addAnnotations(secret.Annotations, spec.CommonAnnotations)
+	}
+}
+
+// addLabels adds the labels, the first ones taking precedence, without replacing the existing ones as they may be used by selectors
+func addLabels(labels map[string]string, added ...map[string]string) map[string]string {
+	for _, values := range added {
+		for key, value := range values {
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			if _, found := labels[key]; !found {
+				labels[key] = value
+			}
+		}
+	}
+	return labels
+}
+
+// addAnnotations adds the annotations in order, replacing the existing ones with the same name
+func addAnnotations(annotations map[string]string, added ...map[string]string) map[string]string {
+	for _, values := range added {
+		for key, value := range values {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
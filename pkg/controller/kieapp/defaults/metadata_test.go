@@ -0,0 +1,113 @@
+package defaults
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMetadata(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment:       api.RhpamProduction,
+			CommonLabels:      map[string]string{"cost-center": "1234", "application": "other"},
+			CommonAnnotations: map[string]string{"owner": "kie-team"},
+			Objects: api.KieAppObjects{
+				Console: api.ConsoleObject{
+					KieAppObject: api.KieAppObject{MetadataObject: api.MetadataObject{
+						PodAnnotations:   map[string]string{"sidecar.istio.io/inject": "true"},
+						RouteAnnotations: map[string]string{"haproxy.router.openshift.io/timeout": "5m"},
+					}},
+				},
+				Servers: []api.KieServerSet{{
+					KieAppObject: api.KieAppObject{MetadataObject: api.MetadataObject{
+						PodLabels:          map[string]string{"tier": "backend", "cost-center": "5678"},
+						ServiceAnnotations: map[string]string{"service.beta.openshift.io/serving-cert-secret-name": "kieserver-tls"},
+					}},
+				}},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	console := env.Console.DeploymentConfigs[0]
+	assert.Equal(t, "1234", console.Labels["cost-center"])
+	assert.Equal(t, "test", console.Labels["application"], "The labels set by the operator should not be replaced")
+	assert.Equal(t, "kie-team", console.Annotations["owner"])
+	assert.Equal(t, "1234", console.Spec.Template.Labels["cost-center"])
+	assert.Equal(t, "business-central", console.Spec.Template.Labels[constants.LabelRHcomponentName])
+	assert.Equal(t, "true", console.Spec.Template.Annotations["sidecar.istio.io/inject"])
+	for _, route := range env.Console.Routes {
+		assert.Equal(t, "5m", route.Annotations["haproxy.router.openshift.io/timeout"], "The annotations of the CR should replace the ones of the templates")
+		assert.Equal(t, "kie-team", route.Annotations["owner"])
+	}
+	for _, pvc := range env.Console.PersistentVolumeClaims {
+		assert.Equal(t, "1234", pvc.Labels["cost-center"])
+	}
+
+	server := env.Servers[0].DeploymentConfigs[0]
+	assert.Equal(t, "5678", server.Spec.Template.Labels["cost-center"], "The pod labels should take precedence over the common ones")
+	assert.Equal(t, "backend", server.Spec.Template.Labels["tier"])
+	assert.Empty(t, server.Spec.Template.Annotations["sidecar.istio.io/inject"])
+	for _, service := range env.Servers[0].Services {
+		assert.Equal(t, "kieserver-tls", service.Annotations["service.beta.openshift.io/serving-cert-secret-name"])
+		assert.Equal(t, "1234", service.Labels["cost-center"])
+	}
+	for _, service := range env.Console.Services {
+		assert.Empty(t, service.Annotations["service.beta.openshift.io/serving-cert-secret-name"])
+	}
+	for _, db := range env.Databases {
+		for _, dc := range db.DeploymentConfigs {
+			assert.Equal(t, "1234", dc.Labels["cost-center"])
+		}
+	}
+	for _, other := range env.Others {
+		for _, secret := range other.Secrets {
+			assert.Equal(t, "1234", secret.Labels["cost-center"])
+			assert.Equal(t, "kie-team", secret.Annotations["owner"])
+		}
+	}
+}
+
+func TestMetadataKubernetes(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment:   api.RhpamTrial,
+			Platform:      api.PlatformKubernetes,
+			IngressDomain: "apps.example.com",
+			CommonLabels:  map[string]string{"cost-center": "1234"},
+			Objects: api.KieAppObjects{
+				Console: api.ConsoleObject{
+					KieAppObject: api.KieAppObject{MetadataObject: api.MetadataObject{
+						PodAnnotations:   map[string]string{"sidecar.istio.io/inject": "false"},
+						RouteAnnotations: map[string]string{"kubernetes.io/ingress.class": "nginx"},
+					}},
+				},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	deployment := env.Console.Deployments[0]
+	assert.Equal(t, "1234", deployment.Labels["cost-center"])
+	assert.Equal(t, "1234", deployment.Spec.Template.Labels["cost-center"])
+	assert.Equal(t, "false", deployment.Spec.Template.Annotations["sidecar.istio.io/inject"])
+	assert.NotEmpty(t, env.Console.Ingresses)
+	for _, ingress := range env.Console.Ingresses {
+		assert.Equal(t, "nginx", ingress.Annotations["kubernetes.io/ingress.class"])
+	}
+}
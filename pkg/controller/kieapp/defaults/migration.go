@@ -0,0 +1,153 @@
+package defaults
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// migrationDrivers are the database types migrated for the JDBC drivers of the KIE Server datasources
+var migrationDrivers = map[string]api.DatabaseType{
+	"mysql":      api.DatabaseMySQL,
+	"mariadb":    api.DatabaseMySQL,
+	"postgresql": api.DatabasePostgreSQL,
+}
+
+// migrationPorts are the default ports of the migrated database types
+var migrationPorts = map[api.DatabaseType]string{
+	api.DatabaseMySQL:      "3306",
+	api.DatabasePostgreSQL: "5432",
+}
+
+// migrationCommands apply an upgrade script, with the client of each migrated database type
+var migrationCommands = map[api.DatabaseType]string{
+	api.DatabaseMySQL:      `MYSQL_PWD="$DB_PASSWORD" mysql -h "$DB_HOST" -P "$DB_PORT" -u "$DB_USERNAME" -D "$DB_NAME" < "$script"`,
+	api.DatabasePostgreSQL: `PGPASSWORD="$DB_PASSWORD" psql -v ON_ERROR_STOP=1 -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USERNAME" -d "$DB_NAME" -f "$script"`,
+}
+
+const migrationScriptsVolume = "upgrade-scripts"
+
+// GetDatabaseMigrationName returns the name of the Job migrating the database of a KIE Server to a version
+func GetDatabaseMigrationName(server, version string) string {
+	return fmt.Sprintf(constants.DatabaseMigrationJob, server, version)
+}
+
+// NewDatabaseMigrationJob returns the Job migrating the database of a KIE Server from the version upgraded from. The
+// upgrade scripts of the upgraded KIE Server image are applied in name order with the client of the database, using
+// the datasource of the KIE Server. Returns nil when the KIE Server has no database to migrate, or an error when its
+// database can't be migrated by the operator.
+func NewDatabaseMigrationJob(cr *api.KieApp, fromVersion, server string, podSpec corev1.PodSpec) (*batchv1.Job, error) {
+	container := getServerContainer(server, podSpec)
+	if container == nil {
+		return nil, nil
+	}
+	env := map[string]corev1.EnvVar{}
+	for _, envVar := range container.Env {
+		env[envVar.Name] = envVar
+	}
+	datasource := strings.Split(env["DATASOURCES"].Value, ",")[0]
+	driver := env[datasource+"_DRIVER"].Value
+	if datasource == "" || driver == "" || driver == "h2" {
+		return nil, nil
+	}
+	dbType, found := migrationDrivers[driver]
+	if !found {
+		return nil, fmt.Errorf("The %s database driver of %s is not supported for migrations, apply the upgrade scripts from version %s manually", driver, server, fromVersion)
+	}
+	host, port, name := env[datasource+"_SERVICE_HOST"].Value, env[datasource+"_SERVICE_PORT"].Value, env[datasource+"_DATABASE"].Value
+	if host == "" {
+		host, port, name = parseJdbcURL(env[datasource+"_URL"].Value)
+	}
+	if port == "" {
+		port = migrationPorts[dbType]
+	}
+	if host == "" || name == "" {
+		return nil, fmt.Errorf("The database host and name of %s are not set, apply the upgrade scripts from version %s manually", server, fromVersion)
+	}
+	password := env[datasource+"_PASSWORD"]
+	password.Name = "DB_PASSWORD"
+
+	image := getTemplateConstants(cr).MySQLImageURL
+	if dbType == api.DatabasePostgreSQL {
+		image = getTemplateConstants(cr).PostgreSQLImageURL
+	}
+	scriptsMount := []corev1.VolumeMount{{Name: migrationScriptsVolume, MountPath: "/" + migrationScriptsVolume}}
+	scripts := fmt.Sprintf("/%s/%s/%s/*.sql", migrationScriptsVolume, dbType, fromVersion)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetDatabaseMigrationName(server, cr.Status.Applied.Version),
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				"app":         cr.Status.Applied.CommonConfig.ApplicationName,
+				"application": cr.Status.Applied.CommonConfig.ApplicationName,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          Pint32(constants.DatabaseMigrationBackoffLimit),
+			ActiveDeadlineSeconds: Pint64(constants.DatabaseMigrationDeadlineSeconds),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: podSpec.ServiceAccountName,
+					ImagePullSecrets:   podSpec.ImagePullSecrets,
+					InitContainers: []corev1.Container{{
+						Name:  migrationScriptsVolume,
+						Image: container.Image,
+						Command: []string{"/bin/sh", "-c", fmt.Sprintf("if [ -d %[1]s ]; then cp -r %[1]s/. /%[2]s/; else echo No upgrade scripts found; fi",
+							constants.DatabaseMigrationScripts, migrationScriptsVolume)},
+						VolumeMounts: scriptsMount,
+					}},
+					Containers: []corev1.Container{{
+						Name:  "migration",
+						Image: image,
+						Command: []string{"/bin/sh", "-c", fmt.Sprintf("set -e; for script in $(ls %s 2>/dev/null | sort); do echo Applying $script; %s; done",
+							scripts, migrationCommands[dbType])},
+						Env: []corev1.EnvVar{
+							{Name: "DB_HOST", Value: host},
+							{Name: "DB_PORT", Value: port},
+							{Name: "DB_NAME", Value: name},
+							{Name: "DB_USERNAME", Value: env[datasource+"_USERNAME"].Value},
+							password,
+						},
+						VolumeMounts: scriptsMount,
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         migrationScriptsVolume,
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+func getServerContainer(server string, podSpec corev1.PodSpec) *corev1.Container {
+	for index := range podSpec.Containers {
+		if podSpec.Containers[index].Name == server {
+			return &podSpec.Containers[index]
+		}
+	}
+	if len(podSpec.Containers) > 0 {
+		return &podSpec.Containers[0]
+	}
+	return nil
+}
+
+// parseJdbcURL returns the host, port and database name of a JDBC URL, e.g. jdbc:mysql://mydb.example.com:3306/rhpam
+func parseJdbcURL(jdbcURL string) (host, port, name string) {
+	parsed, err := url.Parse(strings.TrimPrefix(jdbcURL, "jdbc:"))
+	if err != nil {
+		return "", "", ""
+	}
+	name = strings.TrimPrefix(parsed.Path, "/")
+	if index := strings.IndexAny(name, ";?"); index >= 0 {
+		name = name[:index]
+	}
+	return parsed.Hostname(), parsed.Port(), name
+}
@@ -0,0 +1,95 @@
+package defaults
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewDatabaseMigrationJob(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testns"},
+		Status: api.KieAppStatus{Applied: api.KieAppSpec{
+			Version:      constants.CurrentVersion,
+			CommonConfig: api.CommonConfig{ApplicationName: "test"},
+		}},
+	}
+	password := corev1.EnvVar{Name: "RHPAM_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "test-credentials"}, Key: "db-password"},
+	}}
+	podSpec := func(env ...corev1.EnvVar) corev1.PodSpec {
+		return corev1.PodSpec{
+			ServiceAccountName: "test-rhpamsvc",
+			Containers: []corev1.Container{{
+				Name:  "test-kieserver",
+				Image: "registry.example.com/rhpam-kieserver-rhel8:7.9.0",
+				Env:   append([]corev1.EnvVar{{Name: "DATASOURCES", Value: "RHPAM"}, {Name: "RHPAM_USERNAME", Value: "rhpam"}, password}, env...),
+			}},
+		}
+	}
+
+	// internal MySQL database
+	job, err := NewDatabaseMigrationJob(cr, constants.PriorVersion1, "test-kieserver", podSpec(
+		corev1.EnvVar{Name: "RHPAM_DRIVER", Value: "mariadb"},
+		corev1.EnvVar{Name: "RHPAM_SERVICE_HOST", Value: "test-kieserver-mysql"},
+		corev1.EnvVar{Name: "RHPAM_SERVICE_PORT", Value: "3306"},
+		corev1.EnvVar{Name: "RHPAM_DATABASE", Value: "rhpam7"},
+	))
+	assert.Nil(t, err)
+	assert.NotNil(t, job)
+	assert.Equal(t, "test-kieserver-migration-"+constants.CurrentVersion, job.Name)
+	assert.Equal(t, "testns", job.Namespace)
+	assert.Equal(t, int32(constants.DatabaseMigrationBackoffLimit), *job.Spec.BackoffLimit)
+	assert.Equal(t, int64(constants.DatabaseMigrationDeadlineSeconds), *job.Spec.ActiveDeadlineSeconds, "A stuck migration should fail")
+	pod := job.Spec.Template.Spec
+	assert.Equal(t, corev1.RestartPolicyNever, pod.RestartPolicy)
+	assert.Equal(t, "test-rhpamsvc", pod.ServiceAccountName)
+	assert.Equal(t, "registry.example.com/rhpam-kieserver-rhel8:7.9.0", pod.InitContainers[0].Image, "The upgrade scripts should come from the upgraded image")
+	assert.Equal(t, "if [ -d /opt/kie/upgrade-scripts ]; then cp -r /opt/kie/upgrade-scripts/. /upgrade-scripts/; else echo No upgrade scripts found; fi",
+		pod.InitContainers[0].Command[2], "Only missing upgrade scripts should be tolerated")
+	assert.Equal(t, getTemplateConstants(cr).MySQLImageURL, pod.Containers[0].Image)
+	assert.Contains(t, pod.Containers[0].Command[2], "/upgrade-scripts/mysql/"+constants.PriorVersion1+"/*.sql")
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "DB_HOST", Value: "test-kieserver-mysql"},
+		{Name: "DB_PORT", Value: "3306"},
+		{Name: "DB_NAME", Value: "rhpam7"},
+		{Name: "DB_USERNAME", Value: "rhpam"},
+		{Name: "DB_PASSWORD", ValueFrom: password.ValueFrom},
+	}, pod.Containers[0].Env)
+
+	// external PostgreSQL database
+	job, err = NewDatabaseMigrationJob(cr, constants.PriorVersion1, "test-kieserver", podSpec(
+		corev1.EnvVar{Name: "RHPAM_DRIVER", Value: "postgresql"},
+		corev1.EnvVar{Name: "RHPAM_URL", Value: "jdbc:postgresql://db.example.com/rhpam?sslmode=require"},
+	))
+	assert.Nil(t, err)
+	assert.NotNil(t, job)
+	assert.Equal(t, getTemplateConstants(cr).PostgreSQLImageURL, job.Spec.Template.Spec.Containers[0].Image)
+	assert.Contains(t, job.Spec.Template.Spec.Containers[0].Command[2], "/upgrade-scripts/postgresql/"+constants.PriorVersion1+"/*.sql")
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "DB_HOST", Value: "db.example.com"},
+		{Name: "DB_PORT", Value: "5432"},
+		{Name: "DB_NAME", Value: "rhpam"},
+	}, job.Spec.Template.Spec.Containers[0].Env[:3])
+
+	// nothing to migrate
+	job, err = NewDatabaseMigrationJob(cr, constants.PriorVersion1, "test-kieserver", podSpec(corev1.EnvVar{Name: "RHPAM_DRIVER", Value: "h2"}))
+	assert.Nil(t, err)
+	assert.Nil(t, job)
+	job, err = NewDatabaseMigrationJob(cr, constants.PriorVersion1, "test-kieserver", corev1.PodSpec{Containers: []corev1.Container{{Name: "test-kieserver"}}})
+	assert.Nil(t, err)
+	assert.Nil(t, job)
+
+	// manual migrations
+	_, err = NewDatabaseMigrationJob(cr, constants.PriorVersion1, "test-kieserver", podSpec(
+		corev1.EnvVar{Name: "RHPAM_DRIVER", Value: "oracle"},
+		corev1.EnvVar{Name: "RHPAM_URL", Value: "jdbc:oracle:thin:@db.example.com:1521:rhpam"},
+	))
+	assert.Error(t, err, "Only MySQL and PostgreSQL databases should be migrated")
+	_, err = NewDatabaseMigrationJob(cr, constants.PriorVersion1, "test-kieserver", podSpec(corev1.EnvVar{Name: "RHPAM_DRIVER", Value: "mysql"}))
+	assert.Error(t, err, "The database host should be required")
+}
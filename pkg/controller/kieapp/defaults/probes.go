@@ -0,0 +1,107 @@
+package defaults
+
+import (
+	"strings"
+
+	"github.com/imdario/mergo"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// constructProbes overrides the probes of the main container with the ones defined in the CR. Only the first workload
+// of a component is concerned, e.g. not the AMQ broker of a KIE Server.
+func constructProbes(container *corev1.Container, probes *api.ProbesObject) {
+	if probes == nil {
+		return
+	}
+	container.LivenessProbe = mergeProbe(container.LivenessProbe, probes.Liveness)
+	container.ReadinessProbe = mergeProbe(container.ReadinessProbe, probes.Readiness)
+	if probes.Startup != nil {
+		container.StartupProbe = mergeProbe(getStartupProbe(container), probes.Startup)
+		setStartupProbeDefaults(container.StartupProbe)
+	}
+}
+
+// mergeProbe returns the baseline probe with the fields set in the overwrite one. The handler of the overwrite probe,
+// if any, replaces the baseline one as a probe has a single handler.
+func mergeProbe(baseline, overwrite *corev1.Probe) *corev1.Probe {
+	if overwrite == nil {
+		return baseline
+	} else if baseline == nil {
+		return overwrite.DeepCopy()
+	}
+	merged := overwrite.DeepCopy()
+	defaults := baseline.DeepCopy()
+	if merged.Handler == (corev1.Handler{}) {
+		merged.Handler = defaults.Handler
+	}
+	defaults.Handler = corev1.Handler{}
+	if err := mergo.Merge(merged, defaults); err != nil {
+		log.Error("Error merging probes. ", err)
+		return baseline
+	}
+	return merged
+}
+
+// getStartupProbe returns the startup probe of the container or, if not set, one checking the endpoint of its liveness probe
+func getStartupProbe(container *corev1.Container) *corev1.Probe {
+	if container.StartupProbe != nil {
+		return container.StartupProbe
+	} else if container.LivenessProbe == nil {
+		return nil
+	}
+	return &corev1.Probe{Handler: *container.LivenessProbe.Handler.DeepCopy()}
+}
+
+// setStartupProbes sets a startup probe on the KIE Servers deploying many KIE containers, unless one is set,
+// allowing each of them to take up to a minute to deploy before the liveness probe restarts the pod
+func setStartupProbes(object api.CustomObject) api.CustomObject {
+	for index := range object.DeploymentConfigs {
+		dc := &object.DeploymentConfigs[index]
+		if dc.Spec.Template == nil || len(dc.Spec.Template.Spec.Containers) == 0 {
+			continue
+		}
+		container := &dc.Spec.Template.Spec.Containers[0]
+		kieContainers := getKieContainerCount(container)
+		if container.StartupProbe != nil || kieContainers < constants.StartupProbeContainers {
+			continue
+		}
+		if container.StartupProbe = getStartupProbe(container); container.StartupProbe != nil {
+			container.StartupProbe.PeriodSeconds = constants.StartupProbePeriodSeconds
+			container.StartupProbe.FailureThreshold = int32(kieContainers * constants.StartupProbeFailuresPerContainer)
+			setStartupProbeDefaults(container.StartupProbe)
+		}
+	}
+	return object
+}
+
+// setStartupProbeDefaults sets the fields of a startup probe defaulted by the API server, as unlike those of the liveness
+// and readiness probes they are not ignored when comparing the deployed resources
+func setStartupProbeDefaults(probe *corev1.Probe) {
+	if probe == nil {
+		return
+	}
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = 1
+	}
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = 10
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = 1
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = 3
+	}
+}
+
+// getKieContainerCount returns the number of KIE containers deployed by a KIE Server, separated by '|' in its environment
+func getKieContainerCount(container *corev1.Container) int {
+	for _, env := range container.Env {
+		if env.Name == constants.KieServerContainerDeploymentEnv && strings.TrimSpace(env.Value) != "" {
+			return len(strings.Split(strings.Trim(env.Value, "|"), "|"))
+		}
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package defaults
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestProbes(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamProduction,
+			Objects: api.KieAppObjects{
+				Console: api.ConsoleObject{
+					KieAppObject: api.KieAppObject{Probes: &api.ProbesObject{
+						Readiness: &corev1.Probe{Handler: corev1.Handler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)}}},
+					}},
+				},
+				Servers: []api.KieServerSet{{
+					KieAppObject: api.KieAppObject{Probes: &api.ProbesObject{
+						Liveness: &corev1.Probe{InitialDelaySeconds: 600},
+						Startup:  &corev1.Probe{FailureThreshold: 60},
+					}},
+					Jms: &api.KieAppJmsObject{EnableIntegration: true},
+				}},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	console := env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0]
+	assert.Nil(t, console.ReadinessProbe.HTTPGet, "The handler of the CR should replace the default one")
+	assert.Equal(t, intstr.FromInt(8080), console.ReadinessProbe.TCPSocket.Port)
+	assert.Equal(t, int32(30), console.ReadinessProbe.InitialDelaySeconds, "The fields not set in the CR should be kept")
+	assert.Nil(t, console.StartupProbe)
+
+	server := env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0]
+	assert.Equal(t, int32(600), server.LivenessProbe.InitialDelaySeconds)
+	assert.Equal(t, "/services/rest/server/healthcheck", server.LivenessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(60), server.StartupProbe.FailureThreshold)
+	assert.Equal(t, server.LivenessProbe.Handler, server.StartupProbe.Handler, "The startup probe should check the liveness endpoint")
+
+	amq, found := findDeploymentConfig(env.Servers[0], "test-kieserver-amq")
+	assert.True(t, found)
+	assert.Nil(t, amq.Spec.Template.Spec.Containers[0].StartupProbe, "Only the KIE Server probes should be changed")
+}
+
+func TestDefaultStartupProbe(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamProduction,
+			Objects: api.KieAppObjects{
+				Servers: []api.KieServerSet{
+					{Name: "many", KieAppObject: api.KieAppObject{Env: []corev1.EnvVar{{
+						Name:  constants.KieServerContainerDeploymentEnv,
+						Value: "a=org.example:a:1.0|b=org.example:b:1.0|c=org.example:c:1.0|d=org.example:d:1.0",
+					}}}},
+					{Name: "few", KieAppObject: api.KieAppObject{Env: []corev1.EnvVar{{
+						Name:  constants.KieServerContainerDeploymentEnv,
+						Value: "a=org.example:a:1.0",
+					}}}},
+				},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	many := env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec.Containers[0]
+	assert.NotNil(t, many.StartupProbe)
+	assert.Equal(t, many.LivenessProbe.Handler, many.StartupProbe.Handler)
+	assert.Equal(t, int32(4*constants.StartupProbeFailuresPerContainer), many.StartupProbe.FailureThreshold)
+	assert.Equal(t, int32(constants.StartupProbePeriodSeconds), many.StartupProbe.PeriodSeconds)
+	assert.Nil(t, env.Servers[1].DeploymentConfigs[0].Spec.Template.Spec.Containers[0].StartupProbe)
+}
+
+func TestStartupProbeDefaults(t *testing.T) {
+	container := &corev1.Container{
+		LivenessProbe: &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthy"}}, InitialDelaySeconds: 180},
+	}
+	constructProbes(container, &api.ProbesObject{Startup: &corev1.Probe{FailureThreshold: 30}})
+	assert.Equal(t, &corev1.Probe{
+		Handler:          corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthy"}},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 30,
+	}, container.StartupProbe, "The fields defaulted by the API server should be set to avoid updating the deployed resources")
+}
@@ -0,0 +1,78 @@
+package defaults
+
+import (
+	"fmt"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	routev1 "github.com/openshift/api/route/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// setRoutes sets the host, path and TLS termination configured in the CR on the secure routes of the components
+func setRoutes(cr *api.KieApp, env *api.Environment) {
+	spec := cr.Status.Applied
+	setObjectRoutes(&env.Console, spec.Objects.Console.Route)
+	for index := range env.Servers {
+		serverSet, _ := GetServerSet(cr, index)
+		setObjectRoutes(&env.Servers[index], serverSet.Route)
+	}
+	if spec.Objects.SmartRouter != nil {
+		setObjectRoutes(&env.SmartRouter, spec.Objects.SmartRouter.Route)
+	}
+	if spec.Objects.ProcessMigration != nil {
+		setObjectRoutes(&env.ProcessMigration, spec.Objects.ProcessMigration.Route)
+	}
+	if IsKubernetes(cr) {
+		// there is no router to generate the hosts, the ingresses would otherwise all match any host
+		setIngressHosts(&env.Console, cr)
+		for index := range env.Servers {
+			setIngressHosts(&env.Servers[index], cr)
+		}
+		setIngressHosts(&env.SmartRouter, cr)
+		setIngressHosts(&env.ProcessMigration, cr)
+		for index := range env.Others {
+			setIngressHosts(&env.Others[index], cr)
+		}
+	}
+}
+
+// setIngressHosts sets the host of the routes without one the way the OpenShift router would, on the ingress domain
+func setIngressHosts(object *api.CustomObject, cr *api.KieApp) {
+	for index := range object.Routes {
+		route := &object.Routes[index]
+		if route.Spec.Host == "" {
+			route.Spec.Host = fmt.Sprintf("%s-%s.%s", route.Name, cr.Namespace, cr.Status.Applied.IngressDomain)
+		}
+	}
+}
+
+func setObjectRoutes(object *api.CustomObject, config *api.RouteObject) {
+	if config == nil {
+		return
+	}
+	for index := range object.Routes {
+		route := &object.Routes[index]
+		if route.Spec.TLS == nil {
+			continue
+		}
+		route.Spec.Host = config.Host
+		route.Spec.Path = config.Path
+		if config.Termination != "" && config.Termination != route.Spec.TLS.Termination {
+			route.Spec.TLS.Termination = config.Termination
+			// edge terminated routes reach the pods over http, the others over https
+			targetPort := "https"
+			if config.Termination == routev1.TLSTerminationEdge {
+				targetPort = "http"
+			}
+			route.Spec.Port = &routev1.RoutePort{TargetPort: intstr.FromString(targetPort)}
+		}
+	}
+}
+
+// getTLSSecret returns the name of the Secret holding the certificate of the routes, if any
+func getTLSSecret(config *api.RouteObject) string {
+	if config == nil {
+		return ""
+	}
+	return config.TLSSecret
+}
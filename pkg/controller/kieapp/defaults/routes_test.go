@@ -0,0 +1,64 @@
+package defaults
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRoutes(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamProduction,
+			Objects: api.KieAppObjects{
+				Console: api.ConsoleObject{
+					KieAppObject: api.KieAppObject{Route: &api.RouteObject{Host: "console.example.com", Path: "/console", Termination: routev1.TLSTerminationEdge}},
+				},
+				Servers: []api.KieServerSet{
+					{KieAppObject: api.KieAppObject{Route: &api.RouteObject{Host: "server.example.com"}}},
+					{},
+				},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+
+	assert.NotEmpty(t, env.Console.Routes)
+	for _, route := range env.Console.Routes {
+		assert.Equal(t, "console.example.com", route.Spec.Host)
+		assert.Equal(t, "/console", route.Spec.Path)
+		assert.Equal(t, routev1.TLSTerminationEdge, route.Spec.TLS.Termination)
+		assert.Equal(t, "http", route.Spec.Port.TargetPort.String(), "Edge terminated routes should reach the pods over http")
+	}
+	assert.NotEmpty(t, env.Servers[0].Routes)
+	for _, route := range env.Servers[0].Routes {
+		assert.Equal(t, "server.example.com", route.Spec.Host)
+		assert.Equal(t, routev1.TLSTerminationPassthrough, route.Spec.TLS.Termination, "The termination of the templates should be kept")
+		assert.Equal(t, "https", route.Spec.Port.TargetPort.String())
+	}
+	for _, route := range env.Servers[1].Routes {
+		assert.Empty(t, route.Spec.Host, "The host should be generated by the cluster")
+	}
+}
+
+func TestConvertRouteWithTLSSecret(t *testing.T) {
+	route := routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rhpamcentr"},
+		Spec: routev1.RouteSpec{
+			Host: "console.example.com",
+			To:   routev1.RouteTargetReference{Name: "test-rhpamcentr"},
+			TLS:  &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge},
+		},
+	}
+	ingress := convertRoute(route, "corporate-tls")
+	assert.Equal(t, []string{"console.example.com"}, ingress.Spec.TLS[0].Hosts)
+	assert.Equal(t, "corporate-tls", ingress.Spec.TLS[0].SecretName)
+}
@@ -0,0 +1,72 @@
+package defaults
+
+import (
+	"strings"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// setScheduling sets the scheduling of the components not constructed from a KieAppObject. The databases and AMQ brokers
+// of the KIE Servers and the database of the process migration follow the scheduling of the component using them,
+// unless their own is set.
+func setScheduling(env api.Environment, cr *api.KieApp) api.Environment {
+	objects := cr.Status.Applied.Objects
+	databases := map[string][]api.SchedulingObject{}
+	for index := range env.Servers {
+		serverSet, kieName := GetServerSet(cr, index)
+		databases[kieName] = []api.SchedulingObject{serverSet.SchedulingObject}
+		if serverSet.Database != nil {
+			databases[kieName] = append(databases[kieName], serverSet.Database.SchedulingObject)
+		}
+		if serverSet.Jms != nil {
+			setObjectScheduling(&env.Servers[index], kieName+"-amq", serverSet.SchedulingObject, serverSet.Jms.SchedulingObject)
+		}
+	}
+	if processMigration := objects.ProcessMigration; processMigration != nil {
+		setObjectScheduling(&env.ProcessMigration, "", processMigration.SchedulingObject)
+		databases[cr.Name+"-process-migration"] = []api.SchedulingObject{processMigration.SchedulingObject, processMigration.Database.SchedulingObject}
+	}
+	for index := range env.Databases {
+		for _, dc := range env.Databases[index].DeploymentConfigs {
+			if separator := strings.LastIndex(dc.Name, "-"); separator > 0 {
+				setObjectScheduling(&env.Databases[index], dc.Name, databases[dc.Name[:separator]]...)
+			}
+		}
+	}
+	applicationName := cr.Status.Applied.CommonConfig.ApplicationName
+	for index := range env.Others {
+		if objects.Datagrid != nil {
+			setObjectScheduling(&env.Others[index], applicationName+"-datagrid", *objects.Datagrid)
+		}
+		if objects.Broker != nil {
+			setObjectScheduling(&env.Others[index], applicationName+"-amq", *objects.Broker)
+		}
+	}
+	return env
+}
+
+// setObjectScheduling applies the schedulings in order to the pods of the named workloads of the object, or of all of them if no name is given
+func setObjectScheduling(object *api.CustomObject, name string, schedulings ...api.SchedulingObject) {
+	var podSpecs []*corev1.PodSpec
+	for index := range object.DeploymentConfigs {
+		if dc := &object.DeploymentConfigs[index]; dc.Spec.Template != nil && (name == "" || dc.Name == name) {
+			podSpecs = append(podSpecs, &dc.Spec.Template.Spec)
+		}
+	}
+	for index := range object.Deployments {
+		if deployment := &object.Deployments[index]; name == "" || deployment.Name == name {
+			podSpecs = append(podSpecs, &deployment.Spec.Template.Spec)
+		}
+	}
+	for index := range object.StatefulSets {
+		if statefulSet := &object.StatefulSets[index]; name == "" || statefulSet.Name == name {
+			podSpecs = append(podSpecs, &statefulSet.Spec.Template.Spec)
+		}
+	}
+	for _, podSpec := range podSpecs {
+		for _, scheduling := range schedulings {
+			constructScheduling(podSpec, scheduling)
+		}
+	}
+}
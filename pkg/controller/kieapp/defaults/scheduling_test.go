@@ -0,0 +1,117 @@
+package defaults
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	appsv1 "github.com/openshift/api/apps/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScheduling(t *testing.T) {
+	serverScheduling := api.SchedulingObject{
+		NodeSelector:      map[string]string{"node-role.kubernetes.io/kie": ""},
+		Tolerations:       []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "kie", Effect: corev1.TaintEffectNoSchedule}},
+		PriorityClassName: "kie-high",
+	}
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamProduction,
+			Objects: api.KieAppObjects{
+				Console: api.ConsoleObject{
+					KieAppObject: api.KieAppObject{SchedulingObject: api.SchedulingObject{PriorityClassName: "console"}},
+				},
+				Servers: []api.KieServerSet{{
+					KieAppObject: api.KieAppObject{SchedulingObject: serverScheduling},
+					Database: &api.DatabaseObject{
+						InternalDatabaseObject: api.InternalDatabaseObject{
+							Type:             api.DatabaseMySQL,
+							SchedulingObject: api.SchedulingObject{NodeSelector: map[string]string{"node-role.kubernetes.io/db": ""}},
+						},
+					},
+					Jms: &api.KieAppJmsObject{EnableIntegration: true},
+				}},
+				ProcessMigration: &api.ProcessMigrationObject{
+					Database: api.ProcessMigrationDatabaseObject{
+						InternalDatabaseObject: api.InternalDatabaseObject{Type: api.DatabaseMySQL},
+					},
+					SchedulingObject: api.SchedulingObject{PriorityClassName: "migration"},
+				},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	assert.Equal(t, "console", env.Console.DeploymentConfigs[0].Spec.Template.Spec.PriorityClassName)
+	server := env.Servers[0].DeploymentConfigs[0].Spec.Template.Spec
+	assert.Equal(t, serverScheduling.NodeSelector, server.NodeSelector)
+	assert.Equal(t, serverScheduling.Tolerations, server.Tolerations)
+	assert.Equal(t, "kie-high", server.PriorityClassName)
+
+	amq, found := findDeploymentConfig(env.Servers[0], "test-kieserver-amq")
+	assert.True(t, found)
+	assert.Equal(t, serverScheduling.NodeSelector, amq.Spec.Template.Spec.NodeSelector, "The broker should follow the scheduling of its KIE Server")
+
+	database, found := findDeploymentConfig(env.Databases[0], "test-kieserver-mysql")
+	assert.True(t, found)
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/db": ""}, database.Spec.Template.Spec.NodeSelector, "The scheduling of the database should replace the one of its KIE Server")
+	assert.Equal(t, serverScheduling.Tolerations, database.Spec.Template.Spec.Tolerations)
+	assert.Equal(t, "kie-high", database.Spec.Template.Spec.PriorityClassName)
+
+	assert.Equal(t, "migration", env.ProcessMigration.DeploymentConfigs[0].Spec.Template.Spec.PriorityClassName)
+	migrationDatabase, found := findDeploymentConfig(env.Databases[1], "test-process-migration-mysql")
+	assert.True(t, found)
+	assert.Equal(t, "migration", migrationDatabase.Spec.Template.Spec.PriorityClassName, "The database should follow the scheduling of the process migration")
+}
+
+func TestSchedulingHA(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamAuthoringHA,
+			Objects: api.KieAppObjects{
+				Datagrid: &api.SchedulingObject{PriorityClassName: "datagrid"},
+				Broker:   &api.SchedulingObject{NodeSelector: map[string]string{"node-role.kubernetes.io/amq": ""}},
+			},
+		},
+	}
+	env, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err, "Error getting environment")
+	env = ConsolidateObjects(env, cr)
+
+	var statefulSets []string
+	for _, others := range env.Others {
+		for _, statefulSet := range others.StatefulSets {
+			statefulSets = append(statefulSets, statefulSet.Name)
+			switch statefulSet.Name {
+			case "test-datagrid":
+				assert.Equal(t, "datagrid", statefulSet.Spec.Template.Spec.PriorityClassName)
+				assert.Empty(t, statefulSet.Spec.Template.Spec.NodeSelector)
+			case "test-amq":
+				assert.Equal(t, map[string]string{"node-role.kubernetes.io/amq": ""}, statefulSet.Spec.Template.Spec.NodeSelector)
+				assert.Empty(t, statefulSet.Spec.Template.Spec.PriorityClassName)
+			}
+			assert.NotNil(t, statefulSet.Spec.Template.Spec.Affinity, "The default anti-affinity should be kept")
+		}
+	}
+	assert.ElementsMatch(t, []string{"test-datagrid", "test-amq"}, statefulSets)
+}
+
+func findDeploymentConfig(object api.CustomObject, name string) (appsv1.DeploymentConfig, bool) {
+	for _, dc := range object.DeploymentConfigs {
+		if dc.Name == name {
+			return dc, true
+		}
+	}
+	return appsv1.DeploymentConfig{}, false
+}
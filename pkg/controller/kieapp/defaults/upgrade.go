@@ -3,14 +3,21 @@ package defaults
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/RHsyseng/operator-utils/pkg/utils/kubernetes"
+	"github.com/ghodss/yaml"
 	"github.com/gobuffalo/packr/v2"
-	"github.com/google/go-cmp/cmp"
 	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/shared"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -53,39 +60,434 @@ func GetMajorMinorMicro(productVersion string) (major, minor, micro string) {
 	return version[0], version[1], version[2]
 }
 
-// getConfigVersionDiffs ...
+// maxUpgradeFields is the maximum number of conflicting or changed fields of the configuration reported for an upgrade
+const maxUpgradeFields = 10
+
+// UpgradeConflictError is returned when the upgrade is blocked by customizations of the configuration of the current
+// version that conflict with the changes of the upgraded version
+type UpgradeConflictError struct {
+	FromVersion string
+	ToVersion   string
+	Conflicts   []api.ConfigConflict
+}
+
+func (e *UpgradeConflictError) Error() string {
+	var fields []string
+	for _, conflict := range e.Conflicts {
+		field := strings.Join([]string{conflict.ConfigMap, conflict.File}, "/")
+		if conflict.Path != "" {
+			field = strings.Join([]string{field, conflict.Path}, ":")
+		}
+		fields = append(fields, field)
+	}
+	return fmt.Sprintf("Can't upgrade from %s to %s, configuration conflicts in your %s ConfigMap(s): %s", e.FromVersion, e.ToVersion, e.FromVersion, strings.Join(fields, ", "))
+}
+
+// getConfigVersionDiffs merges the customizations of the ConfigMaps of the current version onto the ConfigMaps of the
+// upgraded version. Returns an UpgradeConflictError with the customized fields that the upgrade changes differently.
 func getConfigVersionDiffs(fromVersion, toVersion string, service kubernetes.PlatformService) error {
-	if checkVersion(fromVersion) && checkVersion(toVersion) {
-		fromList, toList := getConfigVersionLists(fromVersion, toVersion)
-		diffs := configDiffs(fromList, toList)
-		cmDiffs := diffs
-		// only check against existing configmaps if running via deployment in a cluster
-		if _, depNameSpace, useEmbedded := UseEmbeddedFiles(service); !useEmbedded {
-			cmFromList := map[string][]map[string]string{}
-			for name := range fromList {
-				nameSplit := strings.Split(name, "-")
-				cmName := strings.Join(append([]string{nameSplit[0], fromVersion}, nameSplit[1:]...), "-")
-				// *** need to retrieve cm of same name w/ current version and do compare against default upgrade diffs...
-				currentCM := &corev1.ConfigMap{}
-				if err := service.Get(context.TODO(), types.NamespacedName{Name: cmName, Namespace: depNameSpace}, currentCM); err != nil {
-					return err
+	_, mergedList, upgradedCMs, err := mergeDeployedConfigVersions(fromVersion, toVersion, service)
+	if err != nil {
+		return err
+	}
+	for name, upgradedCM := range upgradedCMs {
+		if upgradedCM == nil || reflect.DeepEqual(mergedList[name], upgradedCM.Data) {
+			continue
+		}
+		log.Infof("Applying the customizations of the %s ConfigMap to %s", getVersionedConfigMapName(name, fromVersion), upgradedCM.Name)
+		upgradedCM.Data = mergedList[name]
+		if err := service.Update(context.TODO(), upgradedCM); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getConfigVersionChanges returns the fields of the configuration that the upgrade changes, once the customizations of
+// the ConfigMaps of the current version are merged onto the upgraded version
+func getConfigVersionChanges(fromVersion, toVersion string, service kubernetes.PlatformService) ([]api.ConfigDiff, error) {
+	customizedList, mergedList, _, err := mergeDeployedConfigVersions(fromVersion, toVersion, service)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []api.ConfigDiff
+	var names []string
+	for name := range customizedList {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var files []string
+		for _, data := range []map[string]string{customizedList[name], mergedList[name]} {
+			for file := range data {
+				if _, found := shared.Find(files, file); !found {
+					files = append(files, file)
+				}
+			}
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			for _, diff := range diffConfigFile(customizedList[name][file], mergedList[name][file]) {
+				diffs = append(diffs, api.ConfigDiff{
+					ConfigMap: getVersionedConfigMapName(name, toVersion),
+					File:      file,
+					Path:      diff.path,
+					Applied:   diff.original,
+					Upgraded:  diff.upgraded,
+				})
+			}
+		}
+	}
+	if len(diffs) > maxUpgradeFields {
+		diffs = diffs[:maxUpgradeFields]
+	}
+	return diffs, nil
+}
+
+// mergeDeployedConfigVersions merges the files of the deployed ConfigMaps of the current version onto the ones of the
+// upgraded version, by ConfigMap name without version. Returns the customized and merged files, and the deployed
+// ConfigMaps of the upgraded version, or an UpgradeConflictError.
+func mergeDeployedConfigVersions(fromVersion, toVersion string, service kubernetes.PlatformService) (customizedList, mergedList map[string]map[string]string, upgradedCMs map[string]*corev1.ConfigMap, err error) {
+	if !checkVersion(fromVersion) || !checkVersion(toVersion) {
+		return nil, nil, nil, nil
+	}
+	fromList, toList := getConfigVersionLists(fromVersion, toVersion)
+	customizedList = map[string]map[string]string{}
+	upgradedList := map[string]map[string]string{}
+	upgradedCMs = map[string]*corev1.ConfigMap{}
+	_, depNameSpace, useEmbedded := UseEmbeddedFiles(service)
+	for name := range fromList {
+		if _, found := toList[name]; !found {
+			continue
+		}
+		customized, _, err := getDeployedConfigData(service, getVersionedConfigMapName(name, fromVersion), depNameSpace, useEmbedded, fromList[name])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		upgraded, upgradedCM, err := getDeployedConfigData(service, getVersionedConfigMapName(name, toVersion), depNameSpace, useEmbedded, toList[name])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		customizedList[name] = customized
+		upgradedList[name] = upgraded
+		upgradedCMs[name] = upgradedCM
+	}
+	mergedList, conflicts := mergeConfigVersions(fromVersion, fromList, customizedList, upgradedList)
+	// if conflicts, stop upgrade
+	if len(conflicts) > 0 {
+		return nil, nil, nil, &UpgradeConflictError{FromVersion: fromVersion, ToVersion: toVersion, Conflicts: conflicts}
+	}
+	return customizedList, mergedList, upgradedCMs, nil
+}
+
+// mergeConfigVersions three-way merges the files of the customized ConfigMaps of the current version onto the upgraded
+// ones, by ConfigMap name without version. Returns the merged files and the conflicts, sorted by ConfigMap and file.
+func mergeConfigVersions(fromVersion string, fromList map[string][]map[string]string, customizedList, upgradedList map[string]map[string]string) (map[string]map[string]string, []api.ConfigConflict) {
+	mergedList := map[string]map[string]string{}
+	var conflicts []api.ConfigConflict
+	var names []string
+	for name := range customizedList {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		original := getConfigData(fromList[name])
+		customized := customizedList[name]
+		upgraded := upgradedList[name]
+		var files []string
+		for _, data := range []map[string]string{original, customized, upgraded} {
+			for file := range data {
+				if _, found := shared.Find(files, file); !found {
+					files = append(files, file)
 				}
-				cmFromList[name] = append(cmFromList[name], currentCM.Data)
 			}
-			cmDiffs = configDiffs(cmFromList, toList)
-		} else if service.IsMockService() { // test
-			fromList[constants.ConfigMapPrefix] = []map[string]string{{"common.yaml": "changed"}}
-			cmDiffs = configDiffs(fromList, toList)
 		}
-		// if conflicts, stop upgrade
-		// COMPARE NEEDS IMPROVEMENT - more precise comparison? and should maybe show exact differences that conflict.
-		if !cmp.Equal(diffs, cmDiffs) {
-			return fmt.Errorf("Can't upgrade, potential configuration conflicts in your %s ConfigMap(s)", fromVersion)
+		sort.Strings(files)
+		merged := map[string]string{}
+		for _, file := range files {
+			// missing files are merged as empty ones, and removed if empty once merged
+			data, fileConflicts := mergeConfigFile(original[file], customized[file], upgraded[file])
+			for _, conflict := range fileConflicts {
+				conflicts = append(conflicts, api.ConfigConflict{
+					ConfigMap:  getVersionedConfigMapName(name, fromVersion),
+					File:       file,
+					Path:       conflict.path,
+					Original:   conflict.original,
+					Customized: conflict.customized,
+					Upgraded:   conflict.upgraded,
+				})
+			}
+			if data != "" {
+				merged[file] = data
+			}
+		}
+		mergedList[name] = merged
+	}
+	if len(conflicts) > maxUpgradeFields {
+		conflicts = conflicts[:maxUpgradeFields]
+	}
+	return mergedList, conflicts
+}
+
+// getDeployedConfigData returns the files of a ConfigMap deployed with the operator, only when running via deployment
+// in a cluster, or when created in tests. Returns the embedded files otherwise.
+func getDeployedConfigData(service kubernetes.PlatformService, name, namespace string, useEmbedded bool, embedded []map[string]string) (map[string]string, *corev1.ConfigMap, error) {
+	if useEmbedded && !service.IsMockService() {
+		return getConfigData(embedded), nil, nil
+	}
+	configMap := &corev1.ConfigMap{}
+	err := service.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, configMap)
+	if useEmbedded && errors.IsNotFound(err) {
+		return getConfigData(embedded), nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	return configMap.Data, configMap, nil
+}
+
+// getVersionedConfigMapName returns the name of the ConfigMap of the given version, e.g. kieconfigs-7.9.0-envs for kieconfigs-envs
+func getVersionedConfigMapName(name, version string) string {
+	nameSplit := strings.Split(name, "-")
+	return strings.Join(append([]string{nameSplit[0], version}, nameSplit[1:]...), "-")
+}
+
+// getConfigData returns the files of a ConfigMap read from the embedded config folder
+func getConfigData(dataSlice []map[string]string) map[string]string {
+	data := map[string]string{}
+	for _, dataList := range dataSlice {
+		for file, content := range dataList {
+			data[file] = content
+		}
+	}
+	return data
+}
+
+// pinUpgradeVersion keeps the applied version when upgrades are enabled and no version is set, so that the upgrade
+// path is walked through one version at a time. The next version replaces it once it is ready, approved and in the
+// upgrade window, so the applied version is only kept while the upgrade is pending.
+func pinUpgradeVersion(cr *api.KieApp, appliedVersion string) {
+	if !cr.Spec.Upgrades.Enabled || cr.Spec.RollbackTo != "" || cr.Spec.Version != "" || !checkVersion(appliedVersion) {
+		return
+	}
+	if len(getUpgradePath(appliedVersion, true, cr.Spec.Upgrades.Minor)) > 0 {
+		cr.Spec.Version = appliedVersion
+	}
+}
+
+// compareVersions returns a negative number, zero or a positive number when the first product version is older than,
+// the same as or newer than the second one
+func compareVersions(version1, version2 string) int {
+	major1, minor1, micro1 := GetMajorMinorMicro(version1)
+	major2, minor2, micro2 := GetMajorMinorMicro(version2)
+	for _, parts := range [][2]string{{major1, major2}, {minor1, minor2}, {micro1, micro2}} {
+		part1, _ := strconv.Atoi(parts[0])
+		part2, _ := strconv.Atoi(parts[1])
+		if part1 != part2 {
+			return part1 - part2
+		}
+	}
+	return 0
+}
+
+// getUpgradePath returns the supported versions to upgrade through, oldest first, up to the version of the operator.
+// Micro upgrades stay within the minor version, minor upgrades within the major version.
+func getUpgradePath(fromVersion string, micro, minor bool) []string {
+	if !micro {
+		return nil
+	}
+	fromMajor, fromMinor, _ := GetMajorMinorMicro(fromVersion)
+	var path []string
+	for _, version := range constants.SupportedVersions {
+		major, minorVersion, _ := GetMajorMinorMicro(version)
+		if compareVersions(version, fromVersion) > 0 && major == fromMajor && (minor || minorVersion == fromMinor) {
+			path = append(path, version)
+		}
+	}
+	sort.Slice(path, func(i, j int) bool {
+		return compareVersions(path[i], path[j]) < 0
+	})
+	return path
+}
+
+// upgradeVersion upgrades to the next version of the upgrade path, once the upgrade to the applied version completed.
+// The upgrade path stops at a failed upgrade, until its components recover or it is rolled back.
+func upgradeVersion(cr *api.KieApp, appliedVersion string, path []string, service kubernetes.PlatformService) error {
+	cr.Status.UpgradePath = path
+	if history := cr.Status.UpgradeHistory; len(history) > 0 {
+		last := history[len(history)-1]
+		if last.ToVersion == cr.Status.Applied.Version && (last.Phase == api.UpgradeInProgress || last.Phase == api.UpgradeFailed) {
+			log.Debugf("Waiting for the upgrade from version %s to %s before upgrading to %s", last.FromVersion, last.ToVersion, path[0])
+			return nil
+		}
+	}
+	upgrade, err := checkUpgradeGate(cr, appliedVersion, path[0], service, time.Now())
+	if err != nil || !upgrade {
+		return err
+	}
+	if err := getConfigVersionDiffs(cr.Status.Applied.Version, path[0], service); err != nil {
+		return err
+	}
+	// reset current annotations and update CR to use the next product version
+	cr.SetAnnotations(map[string]string{})
+	cr.Status.Applied.Version = path[0]
+	cr.Spec.Version = path[0]
+	if path[0] == constants.CurrentVersion {
+		cr.Spec.Version = ""
+	}
+	cr.Status.UpgradePath = nil
+	if len(path) > 1 {
+		cr.Status.UpgradePath = path[1:]
+	}
+	return nil
+}
+
+// checkUpgradeGate returns whether the upgrade to the next version of the upgrade path can be applied. Until the
+// upgrade to the version of the operator is approved and in the upgrade window, it is reported as available in the
+// status, with the changes of the configuration of the next version.
+func checkUpgradeGate(cr *api.KieApp, appliedVersion, nextVersion string, service kubernetes.PlatformService, now time.Time) (bool, error) {
+	toVersion := constants.CurrentVersion
+	if appliedVersion == nextVersion {
+		// the upgrade was already applied
+		return true, nil
+	}
+	var pending []string
+	upgrades := cr.Spec.Upgrades
+	if upgrades.Approval == api.UpgradeApprovalManual && cr.GetAnnotations()[constants.ApprovedVersionAnnotation] != toVersion {
+		pending = append(pending, fmt.Sprintf("set the %s annotation to %s to approve the upgrade", constants.ApprovedVersionAnnotation, toVersion))
+	}
+	if upgrades.Window != nil {
+		open, start, err := checkUpgradeWindow(*upgrades.Window, now)
+		if err != nil {
+			return false, err
+		} else if !open && start.IsZero() {
+			pending = append(pending, "no upgrade window within a year")
+		} else if !open {
+			pending = append(pending, fmt.Sprintf("the upgrade window opens at %s", start.Format(time.RFC3339)))
+		}
+	}
+	if len(pending) == 0 {
+		return true, nil
+	}
+	diffs, err := getConfigVersionChanges(cr.Status.Applied.Version, nextVersion, service)
+	if err != nil {
+		return false, err
+	}
+	cr.Status.UpgradeAvailable = &api.UpgradeAvailable{
+		Version:    toVersion,
+		Message:    fmt.Sprintf("Upgrade from version %s to %s pending, %s", cr.Status.Applied.Version, toVersion, strings.Join(pending, " and ")),
+		ConfigDiff: diffs,
+	}
+	return false, nil
+}
+
+// GetUpgradeRequeueAfter returns the time until the upgrade window opens, when an upgrade is only waiting for it
+func GetUpgradeRequeueAfter(cr *api.KieApp, now time.Time) time.Duration {
+	upgrades := cr.Spec.Upgrades
+	if cr.Status.UpgradeAvailable == nil || upgrades.Window == nil ||
+		upgrades.Approval == api.UpgradeApprovalManual && cr.GetAnnotations()[constants.ApprovedVersionAnnotation] != cr.Status.UpgradeAvailable.Version {
+		return 0
+	}
+	if open, start, err := checkUpgradeWindow(*upgrades.Window, now); err == nil && !open && !start.IsZero() {
+		return start.Sub(now)
+	}
+	return 0
+}
+
+// GetUpgradeSnapshotName returns the name of the Secret holding the snapshot of the KieApp taken before upgrading from the version
+func GetUpgradeSnapshotName(cr *api.KieApp, version string) string {
+	return fmt.Sprintf(constants.UpgradeSnapshotSecret, cr.Name, version)
+}
+
+// GetUpgradeSnapshot returns the Secret holding the snapshot of the KieApp taken before the last upgrade from the version,
+// as recorded in the upgrade history
+func GetUpgradeSnapshot(cr *api.KieApp, version string, service kubernetes.PlatformService) (*corev1.Secret, error) {
+	var snapshotName string
+	for _, record := range cr.Status.UpgradeHistory {
+		if record.FromVersion == version && record.Snapshot != "" {
+			snapshotName = record.Snapshot
+		}
+	}
+	if snapshotName == "" {
+		return nil, fmt.Errorf("No upgrade from version %s found in the upgrade history", version)
+	}
+	snapshot := &corev1.Secret{}
+	if err := service.Get(context.TODO(), types.NamespacedName{Name: snapshotName, Namespace: cr.Namespace}, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// NewUpgradeSnapshot returns the Secret holding the applied spec, without credentials, and the deployed resources of
+// the KieApp, taken before upgrading from the applied version
+func NewUpgradeSnapshot(cr *api.KieApp, applied api.KieAppSpec, resources []byte) (*corev1.Secret, error) {
+	snapshotCR := &api.KieApp{Status: api.KieAppStatus{Applied: *applied.DeepCopy()}}
+	RemoveCredentials(snapshotCR)
+	appliedBytes, err := yaml.Marshal(snapshotCR.Status.Applied)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetUpgradeSnapshotName(cr, applied.Version),
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				"app":         applied.CommonConfig.ApplicationName,
+				"application": applied.CommonConfig.ApplicationName,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			constants.UpgradeSnapshotApplied:   appliedBytes,
+			constants.UpgradeSnapshotResources: resources,
+		},
+	}, nil
+}
+
+// rollbackVersion restores the version upgraded from set in rollbackTo, along with the images of the components,
+// from the applied spec of the snapshot taken before the upgrade
+func rollbackVersion(cr *api.KieApp, service kubernetes.PlatformService) error {
+	if !checkVersion(cr.Spec.RollbackTo) {
+		return fmt.Errorf("Product version %s is not allowed for rollback. The following versions are allowed - %s", cr.Spec.RollbackTo, constants.SupportedVersions)
+	}
+	snapshot, err := GetUpgradeSnapshot(cr, cr.Spec.RollbackTo, service)
+	if err != nil {
+		return fmt.Errorf("Can't roll back to version %s: %v", cr.Spec.RollbackTo, err)
+	}
+	applied := api.KieAppSpec{}
+	if err := yaml.Unmarshal(snapshot.Data[constants.UpgradeSnapshotApplied], &applied); err != nil {
+		return fmt.Errorf("Can't roll back to version %s, invalid snapshot %s: %v", cr.Spec.RollbackTo, snapshot.Name, err)
+	}
+	// the applied spec is set from the spec again once the upgrade logic is handled
+	objects := &cr.Spec.Objects
+	restoreImage(&objects.Console.ImageContext, &objects.Console.Image, &objects.Console.ImageTag, applied.Objects.Console.KieAppObject)
+	for index := range objects.Servers {
+		for _, server := range applied.Objects.Servers {
+			if index < len(cr.Status.Applied.Objects.Servers) && server.Name == cr.Status.Applied.Objects.Servers[index].Name {
+				serverSet := &objects.Servers[index]
+				restoreImage(&serverSet.ImageContext, &serverSet.Image, &serverSet.ImageTag, server.KieAppObject)
+			}
 		}
 	}
+	if objects.SmartRouter != nil && applied.Objects.SmartRouter != nil {
+		restoreImage(&objects.SmartRouter.ImageContext, &objects.SmartRouter.Image, &objects.SmartRouter.ImageTag, applied.Objects.SmartRouter.KieAppObject)
+	}
+	if objects.ProcessMigration != nil && applied.Objects.ProcessMigration != nil {
+		processMigration := applied.Objects.ProcessMigration
+		objects.ProcessMigration.ImageContext = processMigration.ImageContext
+		objects.ProcessMigration.Image = processMigration.Image
+		objects.ProcessMigration.ImageTag = processMigration.ImageTag
+	}
+	cr.Status.Applied.Version = applied.Version
+	cr.Spec.Version = applied.Version
 	return nil
 }
 
+func restoreImage(imageContext, image, imageTag *string, applied api.KieAppObject) {
+	*imageContext = applied.ImageContext
+	*image = applied.Image
+	*imageTag = applied.ImageTag
+}
+
 // getConfigVersionLists ...
 func getConfigVersionLists(fromVersion, toVersion string) (configFromList, configToList map[string][]map[string]string) {
 	fromList := map[string][]map[string]string{}
@@ -96,7 +498,7 @@ func getConfigVersionLists(fromVersion, toVersion string) (configFromList, confi
 			cmList := getCMListfromBox(box)
 			for cmName, cmData := range cmList {
 				cmSplit := strings.Split(cmName, "-")
-				name := strings.Join(append(cmSplit[:1], cmSplit[2:]...), "-")
+				name := strings.Join(append([]string{cmSplit[0]}, cmSplit[2:]...), "-")
 				if cmSplit[1] == fromVersion {
 					fromList[name] = cmData
 				}
@@ -108,17 +510,3 @@ func getConfigVersionLists(fromVersion, toVersion string) (configFromList, confi
 	}
 	return fromList, toList
 }
-
-// configDiffs ...
-func configDiffs(cmFromList, cmToList map[string][]map[string]string) map[string]string {
-	configDiffs := map[string]string{}
-	for cmName, fromMapSlice := range cmFromList {
-		if toMapSlice, ok := cmToList[cmName]; ok {
-			diff := cmp.Diff(fromMapSlice, toMapSlice)
-			if diff != "" {
-				configDiffs[cmName] = diff
-			}
-		}
-	}
-	return configDiffs
-}
@@ -1,14 +1,19 @@
 package defaults
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestUpgradesTrue(t *testing.T) {
@@ -26,19 +31,303 @@ func TestUpgradesTrue(t *testing.T) {
 	assert.True(t, cr.Spec.Upgrades.Enabled, "Spec.Upgrades.Enabled should be true")
 }
 
-func TestGetConfigVersionDiffs(t *testing.T) {
+func TestRollbackVersion(t *testing.T) {
 	cr := &api.KieApp{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "test",
 		},
 		Spec: api.KieAppSpec{
 			Environment: api.RhpamTrial,
-			Version:     constants.PriorVersion2,
-			Upgrades:    api.KieAppUpgrades{Enabled: true},
+			Version:     constants.PriorVersion1,
+			Upgrades:    api.KieAppUpgrades{Enabled: true, Minor: true},
+			RollbackTo:  constants.PriorVersion1,
+			Objects: api.KieAppObjects{
+				Console: api.ConsoleObject{KieAppObject: api.KieAppObject{Image: "custom-console", ImageTag: "2.0"}},
+			},
+		},
+	}
+	service := test.MockService()
+	_, err := GetEnvironment(cr, service)
+	assert.Error(t, err, "Only upgraded versions can be rolled back to")
+
+	applied := api.KieAppSpec{
+		Environment: api.RhpamTrial,
+		Version:     constants.PriorVersion1,
+		Objects: api.KieAppObjects{
+			Console: api.ConsoleObject{KieAppObject: api.KieAppObject{Image: "custom-console", ImageTag: "1.0"}},
 		},
+		CommonConfig: api.CommonConfig{AdminPassword: "secret"},
+	}
+	snapshot, err := NewUpgradeSnapshot(cr, applied, []byte("[]"))
+	assert.Nil(t, err)
+	assert.NotContains(t, string(snapshot.Data[constants.UpgradeSnapshotApplied]), "secret", "The snapshot should not hold credentials")
+	assert.Nil(t, service.Create(context.TODO(), snapshot))
+	cr.Status.UpgradeHistory = []api.UpgradeRecord{{FromVersion: constants.PriorVersion1, ToVersion: constants.CurrentVersion, Snapshot: snapshot.Name}}
+
+	env, err := GetEnvironment(cr, service)
+	assert.Nil(t, err)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version, "The upgrade should be suspended")
+	assert.Equal(t, "1.0", cr.Status.Applied.Objects.Console.ImageTag, "The images of the snapshot should be restored")
+	assert.Equal(t, "custom-console:1.0", env.Console.DeploymentConfigs[0].Spec.Triggers[0].ImageChangeParams.From.Name)
+}
+
+func TestGetConfigVersionDiffs(t *testing.T) {
+	service := test.MockService()
+	fromList, _ := getConfigVersionLists(constants.PriorVersion2, constants.CurrentVersion)
+	common := getConfigData(fromList[constants.ConfigMapPrefix])["common.yaml"]
+	customized := strings.Replace(common, "terminationGracePeriodSeconds: 60", "terminationGracePeriodSeconds: 90", 1)
+	assert.NotEqual(t, common, customized)
+	upgraded := strings.Replace(common, "terminationGracePeriodSeconds: 60", "terminationGracePeriodSeconds: 120", 1)
+	customCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: strings.Join([]string{constants.ConfigMapPrefix, constants.PriorVersion2}, "-")},
+		Data:       map[string]string{"common.yaml": customized},
+	}
+	upgradedCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: strings.Join([]string{constants.ConfigMapPrefix, constants.CurrentVersion}, "-")},
+		Data:       map[string]string{"common.yaml": common},
 	}
-	err := getConfigVersionDiffs(cr.Spec.Version, constants.CurrentVersion, test.MockService())
+	assert.Nil(t, service.Create(context.TODO(), customCM))
+	assert.Nil(t, service.Create(context.TODO(), upgradedCM))
+
+	// the customizations are applied to the upgraded version
+	err := getConfigVersionDiffs(constants.PriorVersion2, constants.CurrentVersion, service)
+	assert.Nil(t, err)
+	assert.Nil(t, service.Get(context.TODO(), types.NamespacedName{Name: upgradedCM.Name}, upgradedCM))
+	assert.Equal(t, customized, upgradedCM.Data["common.yaml"])
+
+	// the customizations conflict with the changes of the upgraded version
+	upgradedCM.Data["common.yaml"] = upgraded
+	assert.Nil(t, service.Update(context.TODO(), upgradedCM))
+	err = getConfigVersionDiffs(constants.PriorVersion2, constants.CurrentVersion, service)
 	assert.Error(t, err)
+	assert.IsType(t, &UpgradeConflictError{}, err)
+	conflicts := err.(*UpgradeConflictError).Conflicts
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, api.ConfigConflict{
+		ConfigMap:  customCM.Name,
+		File:       "common.yaml",
+		Path:       "console.deploymentConfigs[name=[[.ApplicationName]]-[[.Console.Name]]].spec.template.spec.terminationGracePeriodSeconds",
+		Original:   "60",
+		Customized: "90",
+		Upgraded:   "120",
+	}, conflicts[0])
+	assert.Equal(t, fmt.Sprintf("Can't upgrade from %s to %s, configuration conflicts in your %s ConfigMap(s): %s/common.yaml:%s",
+		constants.PriorVersion2, constants.CurrentVersion, constants.PriorVersion2, customCM.Name, conflicts[0].Path), err.Error())
+}
+
+func TestGetConfigVersionChanges(t *testing.T) {
+	service := test.MockService()
+	fromList, _ := getConfigVersionLists(constants.PriorVersion2, constants.CurrentVersion)
+	common := getConfigData(fromList[constants.ConfigMapPrefix])["common.yaml"]
+	upgraded := strings.Replace(common, "terminationGracePeriodSeconds: 60", "terminationGracePeriodSeconds: 120", 1)
+	appliedCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: strings.Join([]string{constants.ConfigMapPrefix, constants.PriorVersion2}, "-")},
+		Data:       map[string]string{"common.yaml": common},
+	}
+	upgradedCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: strings.Join([]string{constants.ConfigMapPrefix, constants.CurrentVersion}, "-")},
+		Data:       map[string]string{"common.yaml": upgraded},
+	}
+	assert.Nil(t, service.Create(context.TODO(), appliedCM))
+	assert.Nil(t, service.Create(context.TODO(), upgradedCM))
+
+	diffs, err := getConfigVersionChanges(constants.PriorVersion2, constants.CurrentVersion, service)
+	assert.Nil(t, err)
+	assert.Equal(t, []api.ConfigDiff{{
+		ConfigMap: upgradedCM.Name,
+		File:      "common.yaml",
+		Path:      "console.deploymentConfigs[name=[[.ApplicationName]]-[[.Console.Name]]].spec.template.spec.terminationGracePeriodSeconds",
+		Applied:   "60",
+		Upgraded:  "120",
+	}}, diffs)
+	assert.Nil(t, service.Get(context.TODO(), types.NamespacedName{Name: upgradedCM.Name}, upgradedCM))
+	assert.Equal(t, upgraded, upgradedCM.Data["common.yaml"], "Pending upgrades should not change the ConfigMaps")
+}
+
+func TestUpgradeGate(t *testing.T) {
+	newCR := func(upgrades api.KieAppUpgrades) *api.KieApp {
+		upgrades.Enabled, upgrades.Minor = true, true
+		return &api.KieApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec:       api.KieAppSpec{Environment: api.RhpamTrial, Upgrades: upgrades},
+			Status:     api.KieAppStatus{Applied: api.KieAppSpec{Version: constants.PriorVersion1}},
+		}
+	}
+
+	// waiting for approval, the applied version is kept
+	cr := newCR(api.KieAppUpgrades{Approval: api.UpgradeApprovalManual})
+	_, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version)
+	assert.NotNil(t, cr.Status.UpgradeAvailable)
+	assert.Equal(t, constants.CurrentVersion, cr.Status.UpgradeAvailable.Version)
+	assert.Equal(t, fmt.Sprintf("Upgrade from version %s to %s pending, set the %s annotation to %s to approve the upgrade",
+		constants.PriorVersion1, constants.CurrentVersion, constants.ApprovedVersionAnnotation, constants.CurrentVersion), cr.Status.UpgradeAvailable.Message)
+	assert.Equal(t, time.Duration(0), GetUpgradeRequeueAfter(cr, time.Now()), "Upgrades waiting for approval should not be requeued")
+
+	cr = newCR(api.KieAppUpgrades{Approval: api.UpgradeApprovalManual})
+	cr.SetAnnotations(map[string]string{constants.ApprovedVersionAnnotation: constants.CurrentVersion})
+	_, err = GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err)
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version, "Approved upgrades should be applied")
+	assert.Nil(t, cr.Status.UpgradeAvailable)
+
+	// outside of the upgrade window
+	cr = newCR(api.KieAppUpgrades{Window: &api.UpgradeWindow{Schedule: "0 0 30 2 *", Duration: "1h"}})
+	_, err = GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version)
+	assert.NotNil(t, cr.Status.UpgradeAvailable)
+	assert.True(t, strings.HasSuffix(cr.Status.UpgradeAvailable.Message, "no upgrade window within a year"))
+
+	cr = newCR(api.KieAppUpgrades{Window: &api.UpgradeWindow{Schedule: "* * * * *", Duration: "1h"}})
+	_, err = GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err)
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version, "Upgrades should be applied within the window")
+	assert.Nil(t, cr.Status.UpgradeAvailable)
+
+	// the window is only checked before upgrading
+	cr = newCR(api.KieAppUpgrades{Window: &api.UpgradeWindow{Schedule: "0 0 30 2 *", Duration: "1h"}})
+	cr.Status.Applied.Version = constants.CurrentVersion
+	_, err = GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err)
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version)
+	assert.Empty(t, cr.Spec.Version, "The version should only be pinned while an upgrade is pending")
+	assert.Nil(t, cr.Status.UpgradeAvailable)
+}
+
+func TestGetUpgradePath(t *testing.T) {
+	assert.True(t, compareVersions("7.8.1", "7.10.0") < 0)
+	assert.True(t, compareVersions("7.9", "7.9.0") == 0)
+	assert.True(t, compareVersions("8.0.0", "7.10.1") > 0)
+
+	assert.Equal(t, []string{constants.PriorVersion1, constants.CurrentVersion}, getUpgradePath(constants.PriorVersion2, true, true))
+	assert.Equal(t, []string{constants.PriorVersion1}, getUpgradePath(constants.PriorVersion2, true, false), "Micro upgrades should stay within the minor version")
+	assert.Empty(t, getUpgradePath(constants.PriorVersion1, true, false))
+	assert.Empty(t, getUpgradePath(constants.PriorVersion2, false, true))
+	assert.Empty(t, getUpgradePath(constants.CurrentVersion, true, true))
+	assert.Empty(t, getUpgradePath("6.3.1", true, true), "Upgrades should stay within the major version")
+}
+
+func TestUpgradeHops(t *testing.T) {
+	newCR := func(history ...api.UpgradeRecord) *api.KieApp {
+		return &api.KieApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: api.KieAppSpec{
+				Environment: api.RhpamTrial,
+				Upgrades:    api.KieAppUpgrades{Enabled: true, Minor: true},
+			},
+			Status: api.KieAppStatus{Applied: api.KieAppSpec{Version: constants.PriorVersion2}, UpgradeHistory: history},
+		}
+	}
+
+	// one version at a time
+	cr := newCR()
+	_, err := GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version)
+	assert.Equal(t, []string{constants.CurrentVersion}, cr.Status.UpgradePath)
+
+	// the upgraded version is kept until its upgrade completes
+	for _, phase := range []api.UpgradePhase{api.UpgradeInProgress, api.UpgradeFailed} {
+		cr = newCR(api.UpgradeRecord{FromVersion: constants.PriorVersion2, ToVersion: constants.PriorVersion1, Phase: phase})
+		cr.Status.Applied.Version = constants.PriorVersion1
+		_, err = GetEnvironment(cr, test.MockService())
+		assert.Nil(t, err)
+		assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version, string(phase))
+		assert.Equal(t, constants.PriorVersion1, cr.Spec.Version, string(phase))
+		assert.Equal(t, []string{constants.CurrentVersion}, cr.Status.UpgradePath, string(phase))
+	}
+
+	cr = newCR(api.UpgradeRecord{FromVersion: constants.PriorVersion2, ToVersion: constants.PriorVersion1, Phase: api.UpgradeCompleted})
+	cr.Status.Applied.Version = constants.PriorVersion1
+	_, err = GetEnvironment(cr, test.MockService())
+	assert.Nil(t, err)
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version)
+	assert.Empty(t, cr.Status.UpgradePath)
+
+	// an older version in the spec doesn't downgrade the applied version
+	cr = newCR()
+	cr.Spec.Version = constants.PriorVersion2
+	cr.Status.Applied.Version = constants.CurrentVersion
+	_, err = GetEnvironment(cr, test.MockService())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "spec.version")
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version)
+}
+
+func TestGetUpgradeRequeueAfter(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 1, 0, 0, 0, time.UTC)
+	cr := &api.KieApp{
+		Spec:   api.KieAppSpec{Upgrades: api.KieAppUpgrades{Window: &api.UpgradeWindow{Schedule: "0 2 * * 6", Duration: "4h"}}},
+		Status: api.KieAppStatus{UpgradeAvailable: &api.UpgradeAvailable{Version: constants.CurrentVersion}},
+	}
+	assert.Equal(t, time.Hour, GetUpgradeRequeueAfter(cr, now))
+	assert.Equal(t, time.Duration(0), GetUpgradeRequeueAfter(cr, now.Add(2*time.Hour)), "The window is open")
+
+	cr.Spec.Upgrades.Approval = api.UpgradeApprovalManual
+	assert.Equal(t, time.Duration(0), GetUpgradeRequeueAfter(cr, now))
+	cr.SetAnnotations(map[string]string{constants.ApprovedVersionAnnotation: constants.CurrentVersion})
+	assert.Equal(t, time.Hour, GetUpgradeRequeueAfter(cr, now))
+}
+
+func TestMergeConfigFile(t *testing.T) {
+	original := `console:
+  # the console
+  replicas: [[.Console.Replicas]]
+  env:
+  - name: A
+    value: "1"
+  - name: B
+    value: "2"
+`
+	customized := `console:
+  # the console
+  replicas: [[.Console.Replicas]]
+  env:
+  - name: A
+    value: "10"
+  - name: B
+    value: "2"
+  - name: C
+    value: "3"
+`
+	upgraded := `console:
+  # the console
+  replicas: [[.Console.Replicas]]
+  image: upgraded
+  env:
+  - name: B
+    value: "2"
+  - name: A
+    value: "1"
+`
+	merged, conflicts := mergeConfigFile(original, customized, upgraded)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, `console:
+  # the console
+  replicas: [[.Console.Replicas]]
+  image: upgraded
+  env:
+    - name: B
+      value: "2"
+    - name: A
+      value: "10"
+    - name: C
+      value: "3"
+`, merged, "The customized env variables should be merged with the ones of the upgraded version")
+
+	merged, conflicts = mergeConfigFile(original, customized, strings.Replace(upgraded, `value: "1"`, `value: "11"`, 1))
+	assert.Equal(t, []configField{{path: "console.env[name=A].value", original: `"1"`, customized: `"10"`, upgraded: `"11"`}}, conflicts)
+
+	merged, conflicts = mergeConfigFile(original, "invalid: [", upgraded)
+	assert.Equal(t, upgraded, merged)
+	assert.Len(t, conflicts, 1, "Files that are not valid YAML should conflict as a whole")
+	assert.Empty(t, conflicts[0].path)
+
+	merged, conflicts = mergeConfigFile(original, customized, original)
+	assert.Equal(t, customized, merged, "The customized file should be kept when the upgraded version has no changes")
+	assert.Empty(t, conflicts)
 }
 
 func TestCheckProductUpgrade(t *testing.T) {
@@ -59,8 +348,7 @@ func TestCheckProductUpgrade(t *testing.T) {
 	assert.False(t, minor)
 	assert.False(t, micro)
 
-	diffs := configDiffs(getConfigVersionLists(cr.Status.Applied.Version, constants.CurrentVersion))
-	assert.Empty(t, diffs)
+	assert.Nil(t, getConfigVersionDiffs(cr.Status.Applied.Version, constants.CurrentVersion, test.MockService()))
 
 	// Upgrades default to false
 	cr = &api.KieApp{
@@ -93,9 +381,7 @@ func TestCheckProductUpgrade(t *testing.T) {
 	assert.False(t, minor)
 	assert.True(t, micro)
 
-	diffs = configDiffs(getConfigVersionLists(cr.Status.Applied.Version, constants.CurrentVersion))
-	// assert.NotEmpty(t, diffs)
-	assert.Empty(t, diffs)
+	assert.Nil(t, getConfigVersionDiffs(cr.Status.Applied.Version, constants.CurrentVersion, test.MockService()))
 
 	// Past version, all upgrades true
 	cr = &api.KieApp{
@@ -113,9 +399,7 @@ func TestCheckProductUpgrade(t *testing.T) {
 	assert.True(t, minor)
 	assert.True(t, micro)
 
-	diffs = configDiffs(getConfigVersionLists(cr.Status.Applied.Version, constants.CurrentVersion))
-	// assert.NotEmpty(t, diffs)
-	assert.Empty(t, diffs)
+	assert.Nil(t, getConfigVersionDiffs(cr.Status.Applied.Version, constants.CurrentVersion, test.MockService()))
 
 	// Current version, no upgrades
 	cr = &api.KieApp{
@@ -132,8 +416,7 @@ func TestCheckProductUpgrade(t *testing.T) {
 	assert.False(t, minor)
 	assert.False(t, micro)
 
-	diffs = configDiffs(getConfigVersionLists(cr.Status.Applied.Version, constants.CurrentVersion))
-	assert.Empty(t, diffs)
+	assert.Nil(t, getConfigVersionDiffs(cr.Status.Applied.Version, constants.CurrentVersion, test.MockService()))
 
 	// Upgrades disabled with minor true
 	cr = &api.KieApp{
@@ -151,7 +434,5 @@ func TestCheckProductUpgrade(t *testing.T) {
 	assert.False(t, minor)
 	assert.False(t, micro)
 
-	diffs = configDiffs(getConfigVersionLists(cr.Status.Applied.Version, constants.CurrentVersion))
-	// assert.NotEmpty(t, diffs)
-	assert.Empty(t, diffs)
+	assert.Nil(t, getConfigVersionDiffs(cr.Status.Applied.Version, constants.CurrentVersion, test.MockService()))
 }
@@ -0,0 +1,142 @@
+package defaults
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+)
+
+// maxUpgradeWindowDuration is the maximum duration of an upgrade window
+const maxUpgradeWindowDuration = 7 * 24 * time.Hour
+
+// cronFieldBounds are the minimum and maximum values of the minute, hour, day of month, month and day of week fields
+var cronFieldBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+// cronSchedule is a cron schedule, with the values matched by each of its fields
+type cronSchedule struct {
+	fields [5]map[int]bool
+	// when both the days of month and of week are restricted, either of them matches, like in cron
+	restrictedDays bool
+}
+
+// parseCronSchedule parses a cron schedule with minute, hour, day of month, month and day of week fields. Each field
+// is a list of values, ranges and steps, e.g. '0,30', '1-5' or '*/15'.
+func parseCronSchedule(schedule string) (*cronSchedule, error) {
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFieldBounds) {
+		return nil, fmt.Errorf("Invalid schedule '%s', expected minute, hour, day of month, month and day of week fields", schedule)
+	}
+	cron := &cronSchedule{
+		restrictedDays: !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*"),
+	}
+	for index, field := range fields {
+		values, err := parseCronField(field, cronFieldBounds[index][0], cronFieldBounds[index][1])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid schedule '%s': %v", schedule, err)
+		}
+		cron.fields[index] = values
+	}
+	// both 0 and 7 are Sunday
+	cron.fields[4][0] = cron.fields[4][0] || cron.fields[4][7]
+	return cron, nil
+}
+
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if index := strings.Index(part, "/"); index >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[index+1:]); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in '%s'", part)
+			}
+			rangePart = part[:index]
+		}
+		start, end := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid value '%s'", part)
+			}
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("invalid value '%s'", part)
+				}
+			} else if step == 1 {
+				end = start
+			}
+		}
+		if start < min || end > max || start > end {
+			return nil, fmt.Errorf("'%s' is not within %d-%d", part, min, max)
+		}
+		for value := start; value <= end; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+func (cron *cronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth, dayOfWeek := cron.fields[2][t.Day()], cron.fields[4][int(t.Weekday())]
+	if cron.restrictedDays {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// next returns the first time the schedule matches at or after the given time, within a year
+func (cron *cronSchedule) next(t time.Time) (time.Time, bool) {
+	if truncated := t.Truncate(time.Minute); !truncated.Equal(t) {
+		t = truncated.Add(time.Minute)
+	}
+	end := t.AddDate(1, 0, 1)
+	for t.Before(end) {
+		if !cron.fields[3][int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		} else if !cron.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		} else if !cron.fields[1][t.Hour()] {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		} else if !cron.fields[0][t.Minute()] {
+			t = t.Add(time.Minute)
+		} else {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseUpgradeWindow returns the schedule and the duration of an upgrade window
+func parseUpgradeWindow(window api.UpgradeWindow) (*cronSchedule, time.Duration, error) {
+	cron, err := parseCronSchedule(window.Schedule)
+	if err != nil {
+		return nil, 0, err
+	}
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Invalid upgrade window duration '%s': %v", window.Duration, err)
+	} else if duration <= 0 || duration > maxUpgradeWindowDuration {
+		return nil, 0, fmt.Errorf("Invalid upgrade window duration '%s', it must be positive and at most %s", window.Duration, maxUpgradeWindowDuration)
+	}
+	return cron, duration, nil
+}
+
+// checkUpgradeWindow returns whether the upgrade window is open at the given time, or else when it opens next, if
+// within a year
+func checkUpgradeWindow(window api.UpgradeWindow, now time.Time) (bool, time.Time, error) {
+	cron, duration, err := parseUpgradeWindow(window)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	now = now.UTC()
+	// the window is open when it started less than its duration ago
+	if start, found := cron.next(now.Add(-duration).Add(time.Nanosecond)); found && !start.After(now) {
+		return true, start, nil
+	}
+	start, _ := cron.next(now)
+	return false, start, nil
+}
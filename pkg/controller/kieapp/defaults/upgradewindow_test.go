@@ -0,0 +1,57 @@
+package defaults
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, schedule := range []string{"* * * * *", "0 2 * * 6", "*/15 0-6 1,15 * 1-5", "30 22 * 1-12/3 0,7"} {
+		_, err := parseCronSchedule(schedule)
+		assert.Nil(t, err, schedule)
+	}
+	for _, schedule := range []string{"* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := parseCronSchedule(schedule)
+		assert.Error(t, err, schedule)
+	}
+}
+
+func TestCheckUpgradeWindow(t *testing.T) {
+	// every Saturday from 2:00 to 6:00
+	window := api.UpgradeWindow{Schedule: "0 2 * * 6", Duration: "4h"}
+	saturday := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+
+	open, start, err := checkUpgradeWindow(window, saturday.Add(time.Hour))
+	assert.Nil(t, err)
+	assert.False(t, open)
+	assert.Equal(t, saturday.Add(2*time.Hour), start)
+
+	open, start, err = checkUpgradeWindow(window, saturday.Add(3*time.Hour+30*time.Minute))
+	assert.Nil(t, err)
+	assert.True(t, open)
+	assert.Equal(t, saturday.Add(2*time.Hour), start)
+
+	open, start, err = checkUpgradeWindow(window, saturday.Add(6*time.Hour))
+	assert.Nil(t, err)
+	assert.False(t, open, "The window should close after its duration")
+	assert.Equal(t, saturday.AddDate(0, 0, 7).Add(2*time.Hour), start)
+
+	// the 1st of the month or any Sunday, like in cron
+	open, start, err = checkUpgradeWindow(api.UpgradeWindow{Schedule: "0 0 1 * 0", Duration: "1h"}, saturday)
+	assert.Nil(t, err)
+	assert.False(t, open)
+	assert.Equal(t, saturday.AddDate(0, 0, 1), start)
+
+	open, start, err = checkUpgradeWindow(api.UpgradeWindow{Schedule: "0 0 30 2 *", Duration: "1h"}, saturday)
+	assert.Nil(t, err)
+	assert.False(t, open)
+	assert.True(t, start.IsZero(), "There should be no upcoming window")
+
+	for _, duration := range []string{"", "0s", "-1h", "8d", "200h"} {
+		_, _, err = checkUpgradeWindow(api.UpgradeWindow{Schedule: "* * * * *", Duration: duration}, saturday)
+		assert.Error(t, err, duration)
+	}
+}
@@ -0,0 +1,238 @@
+package defaults
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	routev1 "github.com/openshift/api/route/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateKieApp checks the KieApp spec for the misconfigurations that would otherwise only be detected
+// when the environment is generated, with the same checks. The KieApp is not modified.
+func ValidateKieApp(kieApp *api.KieApp) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	cr := kieApp.DeepCopy()
+	allErrs = append(allErrs, validateVersion(cr.Spec, cr.Status.Applied.Version, specPath)...)
+	SetDefaults(cr)
+
+	if _, found := constants.EnvironmentConstants[cr.Status.Applied.Environment]; !found {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("environment"), cr.Spec.Environment, getEnvironmentNames()))
+	}
+	if !checkVersion(cr.Status.Applied.Version) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("version"), cr.Spec.Version, constants.SupportedVersions))
+	}
+	if cr.Spec.RollbackTo != "" && !checkVersion(cr.Spec.RollbackTo) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("rollbackTo"), cr.Spec.RollbackTo, constants.SupportedVersions))
+	}
+	if window := cr.Spec.Upgrades.Window; window != nil {
+		if _, _, err := parseUpgradeWindow(*window); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("upgrades", "window"), *window, err.Error()))
+		}
+	}
+
+	allErrs = append(allErrs, validatePlatform(cr.Status.Applied, specPath)...)
+	allErrs = append(allErrs, validateDisruptionBudget(cr.Status.Applied.Objects.Console.PodDisruptionBudget, specPath.Child("objects", "console", "podDisruptionBudget"))...)
+	allErrs = append(allErrs, validateRoute(cr.Status.Applied.Objects.Console.Route, routev1.TLSTerminationPassthrough, specPath.Child("objects", "console", "route"))...)
+	serversPath := specPath.Child("objects", "servers")
+	usedNames := map[string]bool{}
+	for _, serverSet := range cr.Status.Applied.Objects.Servers {
+		index := getServerSetIndex(cr.Spec.Objects.Servers, serverSet.Name)
+		serverPath := serversPath.Index(index)
+		deployments := constants.DefaultKieDeployments
+		if serverSet.Deployments != nil {
+			deployments = *serverSet.Deployments
+		}
+		for i := 0; i < deployments; i++ {
+			name := getKieDeploymentName(cr.Status.Applied.CommonConfig.ApplicationName, serverSet.Name, 0, i)
+			if err := checkKieDeploymentName(name, usedNames); err != nil {
+				allErrs = append(allErrs, field.Duplicate(serverPath.Child("name"), name))
+			}
+		}
+		if err := checkBuildDeployments(serverSet, deployments); err != nil {
+			allErrs = append(allErrs, field.Invalid(serverPath.Child("deployments"), deployments, err.Error()))
+		}
+		if err := checkExternalDatabase(serverSet.Database); err != nil {
+			allErrs = append(allErrs, field.Required(serverPath.Child("database", "externalConfig"), err.Error()))
+		} else if serverSet.Database != nil && serverSet.Database.ExternalConfig != nil {
+			allErrs = append(allErrs, validateExternalPassword(serverSet.Database.ExternalConfig.CommonExternalDatabaseObject, serverPath.Child("database", "externalConfig"))...)
+		}
+		allErrs = append(allErrs, validateAutoscaling(serverSet.Autoscaling, serverPath.Child("autoscaling"))...)
+		allErrs = append(allErrs, validateDisruptionBudget(serverSet.PodDisruptionBudget, serverPath.Child("podDisruptionBudget"))...)
+		allErrs = append(allErrs, validateRoute(serverSet.Route, routev1.TLSTerminationPassthrough, serverPath.Child("route"))...)
+		if serverSet.Route != nil && serverSet.Route.Host != "" && deployments > 1 {
+			allErrs = append(allErrs, field.Invalid(serverPath.Child("route", "host"), serverSet.Route.Host, "a route host can't be shared by multiple deployments"))
+		}
+	}
+	if smartRouter := cr.Status.Applied.Objects.SmartRouter; smartRouter != nil {
+		allErrs = append(allErrs, validateAutoscaling(smartRouter.Autoscaling, specPath.Child("objects", "smartRouter", "autoscaling"))...)
+		allErrs = append(allErrs, validateDisruptionBudget(smartRouter.PodDisruptionBudget, specPath.Child("objects", "smartRouter", "podDisruptionBudget"))...)
+		allErrs = append(allErrs, validateRoute(smartRouter.Route, routev1.TLSTerminationPassthrough, specPath.Child("objects", "smartRouter", "route"))...)
+	}
+
+	if processMigration := cr.Status.Applied.Objects.ProcessMigration; processMigration != nil {
+		processMigrationPath := specPath.Child("objects", "processMigration")
+		if !isRHPAM(cr) {
+			allErrs = append(allErrs, field.Forbidden(processMigrationPath, "process migration is only available for RHPAM environments"))
+		}
+		if err := checkProcessMigrationDatabase(processMigration.Database); err != nil {
+			allErrs = append(allErrs, field.Required(processMigrationPath.Child("database", "externalConfig"), err.Error()))
+		} else if processMigration.Database.ExternalConfig != nil {
+			allErrs = append(allErrs, validateExternalPassword(processMigration.Database.ExternalConfig.CommonExternalDatabaseObject, processMigrationPath.Child("database", "externalConfig"))...)
+		}
+		allErrs = append(allErrs, validateRoute(processMigration.Route, routev1.TLSTerminationEdge, processMigrationPath.Child("route"))...)
+		if route := processMigration.Route; route != nil && route.Termination != "" && route.Termination != routev1.TLSTerminationEdge {
+			allErrs = append(allErrs, field.NotSupported(processMigrationPath.Child("route", "termination"), route.Termination, []string{string(routev1.TLSTerminationEdge)}))
+		}
+	}
+	return allErrs
+}
+
+// checkKieDeploymentName returns an error when the name of a KIE Server deployment is already used, or records it
+func checkKieDeploymentName(name string, usedNames map[string]bool) error {
+	if usedNames[name] {
+		return fmt.Errorf("duplicate kieserver name %s", name)
+	}
+	usedNames[name] = true
+	return nil
+}
+
+// checkBuildDeployments returns an error when several deployments are requested for a server set built from source
+func checkBuildDeployments(serverSet api.KieServerSet, deployments int) error {
+	if serverSet.Build != nil && deployments > 1 {
+		return fmt.Errorf("Cannot request %v deployments for a build", deployments)
+	}
+	return nil
+}
+
+// checkExternalDatabase returns an error when an external database of a server set has no configuration
+func checkExternalDatabase(database *api.DatabaseObject) error {
+	if database != nil && database.Type == api.DatabaseExternal && database.ExternalConfig == nil {
+		return fmt.Errorf("external database configuration is mandatory for external database type")
+	}
+	return nil
+}
+
+// checkProcessMigrationDatabase returns an error when the external database of process migration has no configuration
+func checkProcessMigrationDatabase(database api.ProcessMigrationDatabaseObject) error {
+	if database.Type == api.DatabaseExternal && database.ExternalConfig == nil {
+		return fmt.Errorf("external database configuration is mandatory for external database type of process migration")
+	}
+	return nil
+}
+
+// validateVersion checks that the spec doesn't downgrade the applied version, which is only done through rollbackTo
+func validateVersion(spec api.KieAppSpec, appliedVersion string, path *field.Path) field.ErrorList {
+	if spec.RollbackTo != "" || !checkVersion(spec.Version) || !checkVersion(appliedVersion) {
+		return nil
+	}
+	if compareVersions(spec.Version, appliedVersion) < 0 {
+		return field.ErrorList{field.Invalid(path.Child("version"), spec.Version, fmt.Sprintf("version %s is older than the applied version %s, set rollbackTo to roll back", spec.Version, appliedVersion))}
+	}
+	return nil
+}
+
+// validatePlatform checks that the ingresses generated on Kubernetes have a domain to derive their hosts from
+func validatePlatform(spec api.KieAppSpec, path *field.Path) field.ErrorList {
+	if spec.Platform != api.PlatformKubernetes {
+		return nil
+	}
+	if spec.IngressDomain == "" {
+		return field.ErrorList{field.Required(path.Child("ingressDomain"), "an ingress domain is mandatory on the kubernetes platform")}
+	}
+	var allErrs field.ErrorList
+	for _, msg := range validation.IsDNS1123Subdomain(spec.IngressDomain) {
+		allErrs = append(allErrs, field.Invalid(path.Child("ingressDomain"), spec.IngressDomain, msg))
+	}
+	return allErrs
+}
+
+// validateExternalPassword checks that the password of an external database is either set or read from a Secret
+func validateExternalPassword(externalConfig api.CommonExternalDatabaseObject, path *field.Path) field.ErrorList {
+	if len(externalConfig.Password) == 0 && externalConfig.PasswordSecret == nil {
+		return field.ErrorList{field.Required(path.Child("password"), "either password or passwordSecret must be set")}
+	}
+	return nil
+}
+
+// validateAutoscaling checks that the replicas limits of the autoscaling, if any, are consistent
+func validateAutoscaling(autoscaling *api.AutoscalingObject, path *field.Path) field.ErrorList {
+	if autoscaling == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	minReplicas := int32(1)
+	if autoscaling.MinReplicas != nil {
+		minReplicas = *autoscaling.MinReplicas
+		if minReplicas < 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("minReplicas"), minReplicas, "must be at least 1"))
+		}
+	}
+	if autoscaling.MaxReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxReplicas"), autoscaling.MaxReplicas, "must be at least 1"))
+	} else if autoscaling.MaxReplicas < minReplicas {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxReplicas"), autoscaling.MaxReplicas, "must not be lower than minReplicas"))
+	}
+	return allErrs
+}
+
+// validateRoute checks that the route, if any, has a valid host and path, and no path with passthrough termination
+func validateRoute(route *api.RouteObject, defaultTermination routev1.TLSTerminationType, path *field.Path) field.ErrorList {
+	if route == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if route.Host != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(route.Host) {
+			allErrs = append(allErrs, field.Invalid(path.Child("host"), route.Host, msg))
+		}
+	}
+	termination := route.Termination
+	if termination == "" {
+		termination = defaultTermination
+	}
+	if route.Path != "" && !strings.HasPrefix(route.Path, "/") {
+		allErrs = append(allErrs, field.Invalid(path.Child("path"), route.Path, "must start with /"))
+	} else if route.Path != "" && termination == routev1.TLSTerminationPassthrough {
+		allErrs = append(allErrs, field.Invalid(path.Child("path"), route.Path, "a path can't be set with passthrough termination"))
+	}
+	return allErrs
+}
+
+// validateDisruptionBudget checks that at most one of the limits of the PodDisruptionBudget, if any, is set
+func validateDisruptionBudget(budget *api.PodDisruptionBudgetObject, path *field.Path) field.ErrorList {
+	if budget != nil && budget.MinAvailable != nil && budget.MaxUnavailable != nil {
+		return field.ErrorList{field.Invalid(path.Child("maxUnavailable"), budget.MaxUnavailable.String(), "minAvailable and maxUnavailable can't both be set")}
+	}
+	return nil
+}
+
+// getServerSetIndex returns the index of the named server set in the spec, or the position the server set
+// was added at when its name is generated
+func getServerSetIndex(servers []api.KieServerSet, name string) int {
+	for index := range servers {
+		if servers[index].Name == name {
+			return index
+		}
+	}
+	for index := range servers {
+		if servers[index].Name == "" {
+			return index
+		}
+	}
+	return 0
+}
+
+func getEnvironmentNames() []string {
+	var names []string
+	for env := range constants.EnvironmentConstants {
+		names = append(names, string(env))
+	}
+	sort.Strings(names)
+	return names
+}
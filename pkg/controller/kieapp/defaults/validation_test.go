@@ -0,0 +1,183 @@
+package defaults
+
+import (
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateKieApp(t *testing.T) {
+	one := intstr.FromInt(1)
+	tests := []struct {
+		name   string
+		spec   api.KieAppSpec
+		errors []string
+	}{
+		{
+			name: "Valid",
+			spec: api.KieAppSpec{
+				Environment: api.RhpamProduction,
+				Objects: api.KieAppObjects{
+					Servers:          []api.KieServerSet{{Name: "one"}, {Name: "two", Deployments: Pint(2)}, {}},
+					ProcessMigration: &api.ProcessMigrationObject{},
+				},
+			},
+		},
+		{
+			name:   "UnsupportedVersion",
+			spec:   api.KieAppSpec{Environment: api.RhpamTrial, Version: "7.1.0"},
+			errors: []string{"spec.version"},
+		},
+		{
+			name:   "UnsupportedRollbackVersion",
+			spec:   api.KieAppSpec{Environment: api.RhpamTrial, RollbackTo: "7.1.0"},
+			errors: []string{"spec.rollbackTo"},
+		},
+		{
+			name:   "InvalidUpgradeWindow",
+			spec:   api.KieAppSpec{Environment: api.RhpamTrial, Upgrades: api.KieAppUpgrades{Window: &api.UpgradeWindow{Schedule: "0 2 * *", Duration: "4h"}}},
+			errors: []string{"spec.upgrades.window"},
+		},
+		{
+			name:   "KubernetesWithoutIngressDomain",
+			spec:   api.KieAppSpec{Environment: api.RhpamTrial, Platform: api.PlatformKubernetes},
+			errors: []string{"spec.ingressDomain"},
+		},
+		{
+			name:   "InvalidIngressDomain",
+			spec:   api.KieAppSpec{Environment: api.RhpamTrial, Platform: api.PlatformKubernetes, IngressDomain: "apps_example.com"},
+			errors: []string{"spec.ingressDomain"},
+		},
+		{
+			name:   "UnsupportedEnvironment",
+			spec:   api.KieAppSpec{Environment: "rhpam-unknown"},
+			errors: []string{"spec.environment"},
+		},
+		{
+			name: "DuplicateServerSetName",
+			spec: api.KieAppSpec{
+				Environment: api.RhpamTrial,
+				Objects: api.KieAppObjects{
+					Servers: []api.KieServerSet{{Name: "server", Deployments: Pint(2)}, {Name: "server-2"}},
+				},
+			},
+			errors: []string{"spec.objects.servers[1].name"},
+		},
+		{
+			name: "BuildWithDeployments",
+			spec: api.KieAppSpec{
+				Environment: api.RhpamTrial,
+				Objects: api.KieAppObjects{
+					Servers: []api.KieServerSet{{Name: "server", Deployments: Pint(2), Build: &api.KieAppBuildObject{}}},
+				},
+			},
+			errors: []string{"spec.objects.servers[0].deployments"},
+		},
+		{
+			name: "ExternalDatabaseWithoutConfig",
+			spec: api.KieAppSpec{
+				Environment: api.RhpamProduction,
+				Objects: api.KieAppObjects{
+					Servers: []api.KieServerSet{
+						{Name: "one"},
+						{Name: "two", Database: &api.DatabaseObject{InternalDatabaseObject: api.InternalDatabaseObject{Type: api.DatabaseExternal}}},
+					},
+				},
+			},
+			errors: []string{"spec.objects.servers[1].database.externalConfig"},
+		},
+		{
+			name: "AutoscalingLimits",
+			spec: api.KieAppSpec{
+				Environment: api.RhpamProduction,
+				Objects: api.KieAppObjects{
+					Servers: []api.KieServerSet{
+						{Name: "one", Autoscaling: &api.AutoscalingObject{MaxReplicas: 3}},
+						{Name: "two", Autoscaling: &api.AutoscalingObject{MinReplicas: Pint32(4), MaxReplicas: 3}},
+					},
+					SmartRouter: &api.SmartRouterObject{Autoscaling: &api.AutoscalingObject{MinReplicas: Pint32(0)}},
+				},
+			},
+			errors: []string{"spec.objects.servers[1].autoscaling.maxReplicas", "spec.objects.smartRouter.autoscaling.minReplicas", "spec.objects.smartRouter.autoscaling.maxReplicas"},
+		},
+		{
+			name: "DisruptionBudgetLimits",
+			spec: api.KieAppSpec{
+				Environment: api.RhpamAuthoringHA,
+				Objects: api.KieAppObjects{
+					Console: api.ConsoleObject{KieAppObject: api.KieAppObject{PodDisruptionBudget: &api.PodDisruptionBudgetObject{MinAvailable: &one}}},
+					Servers: []api.KieServerSet{{KieAppObject: api.KieAppObject{PodDisruptionBudget: &api.PodDisruptionBudgetObject{MinAvailable: &one, MaxUnavailable: &one}}}},
+				},
+			},
+			errors: []string{"spec.objects.servers[0].podDisruptionBudget.maxUnavailable"},
+		},
+		{
+			name: "Routes",
+			spec: api.KieAppSpec{
+				Environment: api.RhpamProduction,
+				Objects: api.KieAppObjects{
+					Console: api.ConsoleObject{KieAppObject: api.KieAppObject{Route: &api.RouteObject{Host: "Console.example.com", Path: "/console"}}},
+					Servers: []api.KieServerSet{
+						{Name: "one", KieAppObject: api.KieAppObject{Route: &api.RouteObject{Host: "one.example.com", Path: "/one", Termination: "edge"}}},
+						{Name: "two", Deployments: Pint(2), KieAppObject: api.KieAppObject{Route: &api.RouteObject{Host: "two.example.com"}}},
+					},
+					ProcessMigration: &api.ProcessMigrationObject{Route: &api.RouteObject{Path: "migration", Termination: "reencrypt"}},
+				},
+			},
+			errors: []string{"spec.objects.console.route.host", "spec.objects.console.route.path", "spec.objects.servers[1].route.host", "spec.objects.processMigration.route.path", "spec.objects.processMigration.route.termination"},
+		},
+		{
+			name: "ProcessMigrationOnRhdm",
+			spec: api.KieAppSpec{
+				Environment: api.RhdmTrial,
+				Objects: api.KieAppObjects{
+					ProcessMigration: &api.ProcessMigrationObject{
+						Database: api.ProcessMigrationDatabaseObject{InternalDatabaseObject: api.InternalDatabaseObject{Type: api.DatabaseExternal}},
+					},
+				},
+			},
+			errors: []string{"spec.objects.processMigration", "spec.objects.processMigration.database.externalConfig"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := &api.KieApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       test.spec,
+			}
+			original := cr.DeepCopy()
+			errs := ValidateKieApp(cr)
+			assert.Equal(t, test.errors, getFields(errs), "Unexpected errors: %v", errs)
+			assert.Equal(t, original, cr, "The KieApp should not be modified")
+		})
+	}
+}
+
+func TestValidateKieAppDowngrade(t *testing.T) {
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       api.KieAppSpec{Environment: api.RhpamTrial, Version: constants.PriorVersion1},
+		Status:     api.KieAppStatus{Applied: api.KieAppSpec{Version: constants.CurrentVersion}},
+	}
+	assert.Equal(t, []string{"spec.version"}, getFields(ValidateKieApp(cr)))
+
+	cr.Spec.RollbackTo = constants.PriorVersion1
+	assert.Empty(t, ValidateKieApp(cr), "Rollbacks should not be reported as downgrades")
+
+	cr.Spec.RollbackTo = ""
+	cr.Spec.Version = constants.CurrentVersion
+	assert.Empty(t, ValidateKieApp(cr))
+}
+
+func getFields(errs field.ErrorList) []string {
+	var fields []string
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	return fields
+}
@@ -16,6 +16,7 @@ import (
 	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
 	"github.com/kiegroup/kie-cloud-operator/pkg/components"
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/shared"
 	routev1 "github.com/openshift/api/route/v1"
 	operatorsv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
@@ -44,7 +45,7 @@ func shouldDeployConsole() bool {
 
 func deployConsole(reconciler *Reconciler, operator *appsv1.Deployment) {
 	log.Debugf("Checking operator-ui deployment")
-	namespace := os.Getenv(constants.NameSpaceEnv)
+	namespace := defaults.GetOperatorNamespace()
 	operatorName = os.Getenv(constants.OpNameEnv)
 	role := getRole(namespace)
 	roleBinding := getRoleBinding(namespace)
@@ -0,0 +1,128 @@
+package kieapp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/RHsyseng/operator-utils/pkg/resource"
+	"github.com/RHsyseng/operator-utils/pkg/resource/compare"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/shared"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// maxDriftFields is the maximum number of field paths reported for each drifted resource
+const maxDriftFields = 10
+
+// getDrift lists the resources that would be created, updated or deleted to match the requested ones,
+// along with the paths of the fields that differ in the updated ones
+func getDrift(deployed map[reflect.Type][]resource.KubernetesResource, deltas map[reflect.Type]compare.ResourceDelta) []api.ResourceDrift {
+	var drift []api.ResourceDrift
+	for resourceType, delta := range deltas {
+		for _, res := range delta.Added {
+			drift = append(drift, api.ResourceDrift{Kind: resourceType.Name(), Name: res.GetName(), Action: api.DriftCreate})
+		}
+		for _, res := range delta.Updated {
+			resourceDrift := api.ResourceDrift{Kind: resourceType.Name(), Name: res.GetName(), Action: api.DriftUpdate}
+			for _, deployedRes := range deployed[resourceType] {
+				if deployedRes.GetName() == res.GetName() {
+					resourceDrift.Fields = getDriftFields(deployedRes, res)
+				}
+			}
+			drift = append(drift, resourceDrift)
+		}
+		for _, res := range delta.Removed {
+			drift = append(drift, api.ResourceDrift{Kind: resourceType.Name(), Name: res.GetName(), Action: api.DriftDelete})
+		}
+	}
+	return drift
+}
+
+// getDriftFields returns the paths of the fields set in the requested resource that differ in the deployed one.
+// Fields only set in the deployed resource, e.g. defaulted by the API server, are ignored.
+func getDriftFields(deployed, requested resource.KubernetesResource) []string {
+	deployedFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployed)
+	if err != nil {
+		log.Warnf("Unable to convert %s %s: %v", reflect.Indirect(reflect.ValueOf(deployed)).Type().Name(), deployed.GetName(), err)
+		return nil
+	}
+	requestedFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(requested)
+	if err != nil {
+		log.Warnf("Unable to convert %s %s: %v", reflect.Indirect(reflect.ValueOf(requested)).Type().Name(), requested.GetName(), err)
+		return nil
+	}
+	var fields []string
+	deployedMetadata, _ := deployedFields["metadata"].(map[string]interface{})
+	requestedMetadata, _ := requestedFields["metadata"].(map[string]interface{})
+	for _, key := range []string{"labels", "annotations"} {
+		if value, found := requestedMetadata[key]; found {
+			diffFields("metadata."+key, deployedMetadata[key], value, &fields)
+		}
+	}
+	for key, value := range requestedFields {
+		if key == "apiVersion" || key == "kind" || key == "metadata" || key == "status" {
+			continue
+		}
+		diffFields(key, deployedFields[key], value, &fields)
+	}
+	sort.Strings(fields)
+	if len(fields) > maxDriftFields {
+		fields = fields[:maxDriftFields]
+	}
+	return fields
+}
+
+// diffFields appends the paths of the requested fields that differ from the deployed ones
+func diffFields(path string, deployed, requested interface{}, fields *[]string) {
+	switch requestedValue := requested.(type) {
+	case map[string]interface{}:
+		if deployedValue, ok := deployed.(map[string]interface{}); ok || deployed == nil {
+			for key, value := range requestedValue {
+				diffFields(shared.GetFieldPath(path, key), deployedValue[key], value, fields)
+			}
+			return
+		}
+	case []interface{}:
+		deployedValue, ok := deployed.([]interface{})
+		if requestedNames, deployedNames := getNamedItems(requestedValue), getNamedItems(deployedValue); ok && requestedNames != nil && deployedNames != nil {
+			for name, value := range requestedNames {
+				diffFields(fmt.Sprintf("%s[name=%s]", path, name), deployedNames[name], value, fields)
+			}
+			for name := range deployedNames {
+				if _, found := requestedNames[name]; !found {
+					*fields = append(*fields, fmt.Sprintf("%s[name=%s]", path, name))
+				}
+			}
+			return
+		} else if ok && len(deployedValue) == len(requestedValue) {
+			for index := range requestedValue {
+				diffFields(fmt.Sprintf("%s[%d]", path, index), deployedValue[index], requestedValue[index], fields)
+			}
+			return
+		} else if deployed == nil && len(requestedValue) == 0 {
+			return
+		}
+	}
+	if !reflect.DeepEqual(deployed, requested) {
+		*fields = append(*fields, path)
+	}
+}
+
+// getNamedItems returns the items of a list by their name, e.g. for containers or env variables, so that they are compared
+// regardless of their order. Returns nil if any of the items has no name.
+func getNamedItems(items []interface{}) map[string]interface{} {
+	named := map[string]interface{}{}
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		name, ok := fields["name"].(string)
+		if !ok {
+			return nil
+		}
+		named[name] = item
+	}
+	return named
+}
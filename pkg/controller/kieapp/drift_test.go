@@ -0,0 +1,90 @@
+package kieapp
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestGetDriftFields(t *testing.T) {
+	requested := &oappsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test",
+			Labels: map[string]string{"app.kubernetes.io/name": "test"},
+		},
+		Spec: oappsv1.DeploymentConfigSpec{
+			Replicas: 1,
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:1.0"}},
+				},
+			},
+		},
+	}
+	deployed := requested.DeepCopy()
+	deployed.ResourceVersion = "2"
+	deployed.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	deployed.Status.Replicas = 1
+	assert.Empty(t, getDriftFields(deployed, requested), "Defaulted fields should be ignored")
+
+	deployed.Labels["app.kubernetes.io/name"] = "other"
+	deployed.Spec.Replicas = 2
+	deployed.Spec.Template.Spec.Containers[0].Image = "test:2.0"
+	assert.Equal(t, []string{
+		"metadata.labels[app.kubernetes.io/name]",
+		"spec.replicas",
+		"spec.template.spec.containers[name=test].image",
+	}, getDriftFields(deployed, requested))
+
+	deployed.Spec.Template.Spec.Containers = append([]corev1.Container{{Name: "sidecar"}}, deployed.Spec.Template.Spec.Containers...)
+	assert.Contains(t, getDriftFields(deployed, requested), "spec.template.spec.containers[name=test].image", "Named items should be compared regardless of their order")
+	assert.Contains(t, getDriftFields(deployed, requested), "spec.template.spec.containers[name=sidecar]")
+}
+
+func TestReconcileDrift(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "drift")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{Environment: api.RhpamTrial}
+	service := test.MockService()
+	assert.Nil(t, service.Create(context.TODO(), cr))
+	reconciler := Reconciler{Service: service}
+	for i := 0; i < 2; i++ {
+		_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		assert.Nil(t, err)
+	}
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Contains(t, cr.Status.Drift, api.ResourceDrift{Kind: "DeploymentConfig", Name: "drift-rhpamcentr", Action: api.DriftCreate}, "The created resources should be reported")
+	_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Empty(t, cr.Status.Drift, "Nothing should differ once reconciled")
+
+	dc := &oappsv1.DeploymentConfig{}
+	assert.Nil(t, service.Get(context.TODO(), getNamespacedName("testns", "drift-rhpamcentr"), dc))
+	dc.Spec.Template.Spec.Containers[0].Image = "manually-patched"
+	assert.Nil(t, service.Update(context.TODO(), dc))
+
+	requested, err := reconciler.RenderResources(cr)
+	assert.Nil(t, err)
+	drift, err := reconciler.DriftResources(cr, requested)
+	assert.Nil(t, err)
+	assert.Equal(t, []api.ResourceDrift{{
+		Kind:   reflect.TypeOf(oappsv1.DeploymentConfig{}).Name(),
+		Name:   "drift-rhpamcentr",
+		Action: api.DriftUpdate,
+		Fields: []string{"spec.template.spec.containers[name=drift-rhpamcentr].image"},
+	}}, drift)
+
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, drift, cr.Status.Drift, "The drift corrected by the reconciliation should be reported")
+}
@@ -1,13 +1,9 @@
 package kieapp
 
 import (
-	"bytes"
 	"context"
-	"crypto/x509"
-	"encoding/json"
 	"fmt"
 	"reflect"
-	"regexp"
 	"strings"
 	"time"
 
@@ -29,16 +25,23 @@ import (
 	oimagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	operatorsv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
-	"github.com/pavel-v-chernykh/keystore-go"
 	"golang.org/x/mod/semver"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -47,7 +50,9 @@ var log = logs.GetLogger("kieapp.controller")
 // Reconciler reconciles a KieApp object
 type Reconciler struct {
 	Service    kubernetes.PlatformService
+	Recorder   record.EventRecorder
 	OcpVersion string
+	Options    Options
 }
 
 // Reconcile reads that state of the cluster for a KieApp object and makes changes based on the state read
@@ -55,7 +60,17 @@ type Reconciler struct {
 // Note:
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-func (reconciler *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+func (reconciler *Reconciler) Reconcile(request reconcile.Request) (result reconcile.Result, err error) {
+	start := time.Now()
+	deleted := false
+	defer func() {
+		if deleted {
+			forgetReconcile(request)
+		} else {
+			observeReconcile(request, start, result, err)
+		}
+	}()
+	options := reconciler.Options.withDefaults()
 	// The next several lines only execute if the operator is running in a pod, via deployment.
 	// Otherwise, embedded configs are used and no console is deployed.
 	if opName, depNameSpace, useEmbedded := defaults.UseEmbeddedFiles(reconciler.Service); !useEmbedded {
@@ -76,35 +91,69 @@ func (reconciler *Reconciler) Reconcile(request reconcile.Request) (reconcile.Re
 
 	// Fetch the KieApp instance
 	instance := &api.KieApp{}
-	err := reconciler.Service.Get(context.TODO(), request.NamespacedName, instance)
+	err = reconciler.Service.Get(context.TODO(), request.NamespacedName, instance)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			deleted = true
 			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Owned objects are garbage collected, and the other ones were cleaned up by the finalizer.
 			// Return and don't requeue
-			log.Infof("No Custom Resource found named %s. Checking for dependent objects to delete.", request.Name)
-			instance.ObjectMeta = metav1.ObjectMeta{
-				Name:      request.Name,
-				Namespace: request.Namespace,
-			}
-			deployed, err := reconciler.getDeployedResources(instance)
-			if err != nil {
-				return reconcile.Result{}, err
-			}
-			_, err = reconciler.reconcileResources(instance, nil, deployed)
-			return reconcile.Result{}, err
+			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		reconciler.setFailedStatus(instance, api.UnknownReason, err)
 		return reconcile.Result{}, err
 	}
 
+	// Leave the KieApps that do not match the selector to another operator
+	selector, err := options.Selector()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !selector.Matches(labels.Set(instance.GetLabels())) {
+		log.Debugf("Ignoring KieApp %s, its labels do not match the selector %s", instance.Name, selector)
+		return reconcile.Result{}, nil
+	}
+
+	// Clean up the resources that are not garbage collected along with the KieApp
+	if instance.GetDeletionTimestamp() != nil {
+		return reconciler.finalize(instance)
+	}
+	if defaults.IsPaused(instance) {
+		return reconciler.reconcilePaused(instance, request)
+	} else if instance.Status.Phase == api.PausedConditionType {
+		reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventResumed, "Reconciliation resumed")
+	}
+	if !hasFinalizer(instance) {
+		instance.SetFinalizers(append(instance.GetFinalizers(), constants.KieAppFinalizer))
+		if err = reconciler.Service.Update(context.TODO(), instance); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	//Obtain in-memory representation of basic environment being requested:
+	applied := instance.Status.Applied.DeepCopy()
+	available := instance.Status.UpgradeAvailable
 	env, err := defaults.GetEnvironment(instance, reconciler.Service)
 	if err != nil {
+		if conflictErr, blocked := err.(*defaults.UpgradeConflictError); blocked {
+			status.SetUpgradeConflicts(instance, conflictErr.Conflicts)
+			reconciler.setFailedStatus(instance, api.UpgradeConflictReason, err)
+			return reconcile.Result{}, err
+		}
 		reconciler.setFailedStatus(instance, api.ConfigurationErrorReason, err)
 		return reconcile.Result{}, err
 	}
+	status.SetUpgradeConflicts(instance, nil)
+	if pending := instance.Status.UpgradeAvailable; pending != nil && (available == nil || available.Version != pending.Version) {
+		reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventUpgradeAvailable, "%s", pending.Message)
+	}
+	if applied.Version != "" && applied.Version != instance.Status.Applied.Version {
+		if err = reconciler.setVersionChange(instance, *applied); err != nil {
+			reconciler.setFailedStatus(instance, api.DeploymentFailedReason, err)
+			return reconcile.Result{}, err
+		}
+	}
 
 	//Verify the external references exist
 	err = reconciler.verifyExternalReferences(instance)
@@ -116,23 +165,25 @@ func (reconciler *Reconciler) Reconcile(request reconcile.Request) (reconcile.Re
 	//Get requested routes based on environment template:
 	requestedRoutes := getRequestedRoutes(env, instance)
 	//Then check if all these routes are already created:
-	reader := read.New(reconciler.Service).WithNamespace(instance.Namespace).WithOwnerObject(instance)
-	deployedRoutes, err := reader.List(&routev1.RouteList{})
-	if err != nil {
-		return reconcile.Result{}, err
+	var deployedRoutes []resource.KubernetesResource
+	if !defaults.IsKubernetes(instance) {
+		reader := read.New(reconciler.Service).WithNamespace(instance.Namespace).WithOwnerObject(instance)
+		deployedRoutes, err = reader.List(&routev1.RouteList{})
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 	delta := compare.DefaultComparator().CompareArrays(deployedRoutes, requestedRoutes)
 
 	//If not all routes are created, then create the missing ones. Other changes can be applied later.
 	if len(delta.Added) > 0 {
 		log.Debugf("Will create %d routes that were not found", len(delta.Added))
-		writer := write.New(reconciler.Service).WithOwnerController(instance, reconciler.Service.GetScheme())
-		added, err := writer.AddResources(delta.Added)
+		added, _, err := reconciler.addResources(instance, reflect.TypeOf(routev1.Route{}), delta.Added)
 		if err != nil {
 			return reconcile.Result{}, err
 		} else if added {
 			//Requeue after a little while to load route and its hostname
-			return reconcile.Result{Requeue: true, RequeueAfter: time.Duration(500) * time.Millisecond}, err
+			return reconcile.Result{Requeue: true, RequeueAfter: options.RouteRequeueInterval}, err
 		}
 	}
 
@@ -141,7 +192,7 @@ func (reconciler *Reconciler) Reconcile(request reconcile.Request) (reconcile.Re
 	if err != nil {
 		// requeue if secret request throws an error
 		// we shouldn't reconcile the deployment with an incorrect or missing keystore secret
-		return reconcile.Result{Requeue: true, RequeueAfter: time.Duration(500) * time.Millisecond}, err
+		return reconcile.Result{Requeue: true, RequeueAfter: options.KeystoreRequeueInterval}, err
 	}
 	//Create a list of objects that should be deployed
 	requestedResources := reconciler.getKubernetesResources(instance, env)
@@ -150,6 +201,12 @@ func (reconciler *Reconciler) Reconcile(request reconcile.Request) (reconcile.Re
 			requestedResources[index].SetNamespace(instance.Namespace)
 		}
 	}
+	if instance.Spec.RollbackTo != "" {
+		if err = reconciler.restoreSnapshotImages(instance, requestedResources); err != nil {
+			reconciler.setFailedStatus(instance, api.ConfigurationErrorReason, err)
+			return reconcile.Result{}, err
+		}
+	}
 
 	//Obtain a list of objects that are actually deployed
 	deployed, err := reconciler.getDeployedResources(instance)
@@ -157,7 +214,11 @@ func (reconciler *Reconciler) Reconcile(request reconcile.Request) (reconcile.Re
 		reconciler.setFailedStatus(instance, api.UnknownReason, err)
 		return reconcile.Result{}, err
 	}
-	setDeploymentStatus(instance, deployed[reflect.TypeOf(oappsv1.DeploymentConfig{})])
+	setDeploymentStatus(instance, deployed)
+	if err = reconciler.checkDatabaseMigrations(instance, env, requestedResources, deployed); err != nil {
+		reconciler.setFailedStatus(instance, api.DeploymentFailedReason, err)
+		return reconcile.Result{}, err
+	}
 
 	hasUpdates, err := reconciler.reconcileResources(instance, requestedResources, deployed)
 	if err != nil {
@@ -183,20 +244,40 @@ func (reconciler *Reconciler) Reconcile(request reconcile.Request) (reconcile.Re
 	}
 
 	// Update CR Status if needed
-	return reconciler.checkStatus(instance, cachedInstance, hasUpdates)
+	result, err = reconciler.checkStatus(instance, cachedInstance, hasUpdates, getComponentConditions(instance, env, deployed))
+	if requeueAfter := defaults.GetUpgradeRequeueAfter(instance, time.Now()); err == nil && !result.Requeue && requeueAfter > 0 {
+		// Reconcile again once the upgrade window opens
+		result.RequeueAfter = requeueAfter
+	}
+	return result, err
 }
 
-func (reconciler *Reconciler) checkStatus(instance, cachedInstance *api.KieApp, hasUpdates bool) (reconcile.Result, error) {
+func (reconciler *Reconciler) checkStatus(instance, cachedInstance *api.KieApp, hasUpdates bool, components []api.Condition) (reconcile.Result, error) {
+	ready := true
+	for _, component := range components {
+		if component.Status != corev1.ConditionTrue {
+			ready = false
+		}
+	}
 	var requeue bool
-	if hasUpdates {
+	if hasUpdates || !ready {
 		requeue = status.SetProvisioning(instance)
 	} else {
+		deployedVersion := instance.Status.Version
 		requeue = status.SetDeployed(instance)
+		if requeue && deployedVersion != "" && deployedVersion != instance.Status.Version {
+			reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventUpgradeCompleted,
+				"Upgrade from version %s to %s completed", deployedVersion, instance.Status.Version)
+		}
 	}
+	requeue = reconciler.checkUpgradePhase(instance, components, ready && !hasUpdates) || requeue
+	requeue = status.SetComponentConditions(instance, components) || requeue
+	requeue = status.SetReady(instance) || requeue
 	return reconciler.updateStatus(instance, cachedInstance, requeue)
 }
 
 func (reconciler *Reconciler) updateStatus(instance, cachedInstance *api.KieApp, requeue bool) (reconcile.Result, error) {
+	defaults.RemoveCredentials(instance)
 	if reconciler.hasStatusChanges(instance, cachedInstance) {
 		if instance.ResourceVersion == cachedInstance.ResourceVersion {
 			if err := reconciler.Service.Status().Update(context.TODO(), instance); err != nil {
@@ -210,34 +291,280 @@ func (reconciler *Reconciler) updateStatus(instance, cachedInstance *api.KieApp,
 }
 
 func (reconciler *Reconciler) reconcileResources(instance *api.KieApp, requestedResources []resource.KubernetesResource, deployed map[reflect.Type][]resource.KubernetesResource) (bool, error) {
-	writer := write.New(reconciler.Service).WithOwnerController(instance, reconciler.Service.GetScheme())
+	setAutoscaledReplicas(requestedResources, deployed)
 	//Compare what's deployed with what should be deployed
 	requested := compare.NewMapBuilder().Add(requestedResources...).ResourceMap()
 	comparator := getComparator()
 	deltas := comparator.Compare(deployed, requested)
 	var hasUpdates bool
+	var conflicts []api.ResourceConflict
 	for resourceType, delta := range deltas {
 		if !delta.HasChanges() {
 			continue
 		}
 		log.Debugf("Will create %d, update %d, and delete %d instances of %v", len(delta.Added), len(delta.Updated), len(delta.Removed), resourceType)
-		added, err := writer.AddResources(delta.Added)
+		added, addConflicts, err := reconciler.addResources(instance, resourceType, delta.Added)
 		if err != nil {
 			return false, err
 		}
-		updated, err := writer.UpdateResources(deployed[resourceType], delta.Updated)
+		updated, updateConflicts, err := reconciler.updateResources(instance, resourceType, delta.Updated)
 		if err != nil {
 			return false, err
 		}
-		removed, err := writer.RemoveResources(delta.Removed)
+		removed, err := reconciler.removeResources(instance, resourceType, delta.Removed)
 		if err != nil {
 			return false, err
 		}
 		hasUpdates = hasUpdates || added || updated || removed
+		conflicts = append(conflicts, addConflicts...)
+		conflicts = append(conflicts, updateConflicts...)
 	}
+	status.SetConflicts(instance, conflicts)
+	status.SetDrift(instance, getDrift(deployed, deltas))
 	return hasUpdates, nil
 }
 
+// addResources creates the resources one at a time, so that an event is recorded for each of them
+func (reconciler *Reconciler) addResources(instance *api.KieApp, resourceType reflect.Type, resources []resource.KubernetesResource) (bool, []api.ResourceConflict, error) {
+	var hasUpdates bool
+	var conflicts []api.ResourceConflict
+	for _, res := range resources {
+		err := reconciler.applyResource(instance, res)
+		if errors.IsConflict(err) {
+			conflicts = append(conflicts, reconciler.getConflict(instance, resourceType, res, err))
+			continue
+		} else if err != nil {
+			reconciler.recordEvent(instance, corev1.EventTypeWarning, constants.EventCreateFailed, "Failed to create %s %s: %v", resourceType.Name(), res.GetName(), err)
+			return hasUpdates, conflicts, err
+		}
+		reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventCreated, "Created %s %s", resourceType.Name(), res.GetName())
+		hasUpdates = true
+	}
+	return hasUpdates, conflicts, nil
+}
+
+// updateResources updates the resources one at a time, so that an event is recorded for each of them
+func (reconciler *Reconciler) updateResources(instance *api.KieApp, resourceType reflect.Type, resources []resource.KubernetesResource) (bool, []api.ResourceConflict, error) {
+	var hasUpdates bool
+	var conflicts []api.ResourceConflict
+	for _, res := range resources {
+		err := reconciler.applyResource(instance, res)
+		if errors.IsConflict(err) {
+			conflicts = append(conflicts, reconciler.getConflict(instance, resourceType, res, err))
+			continue
+		} else if err != nil {
+			reconciler.recordEvent(instance, corev1.EventTypeWarning, constants.EventUpdateFailed, "Failed to update %s %s: %v", resourceType.Name(), res.GetName(), err)
+			return hasUpdates, conflicts, err
+		}
+		reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventUpdated, "Updated %s %s", resourceType.Name(), res.GetName())
+		hasUpdates = true
+	}
+	return hasUpdates, conflicts, nil
+}
+
+// applyResource creates or updates the resource with server-side apply. The operator only owns the fields it renders,
+// so that the fields set by other managers, e.g. the replicas of an HPA or injected sidecars, are retained.
+// Fields owned by other managers are not overwritten, the conflict is returned instead. The fields the operator
+// previously updated are migrated to its apply manager first.
+func (reconciler *Reconciler) applyResource(instance *api.KieApp, res resource.KubernetesResource) error {
+	scheme := reconciler.Service.GetScheme()
+	if isNamespaced(res) && instance.GetNamespace() != "" {
+		if err := controllerutil.SetControllerReference(instance, res, scheme); err != nil {
+			return err
+		}
+	}
+	if res.GetObjectKind().GroupVersionKind().Empty() {
+		gvk, err := apiutil.GVKForObject(res, scheme)
+		if err != nil {
+			return err
+		}
+		res.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+	if err := reconciler.migrateManagedFields(res); err != nil {
+		return err
+	}
+	res.SetResourceVersion("")
+	res.SetManagedFields(nil)
+	return reconciler.Service.Patch(context.TODO(), res, client.Apply, client.FieldOwner(constants.FieldManager))
+}
+
+func (reconciler *Reconciler) getConflict(instance *api.KieApp, resourceType reflect.Type, res resource.KubernetesResource, err error) api.ResourceConflict {
+	reconciler.recordEvent(instance, corev1.EventTypeWarning, constants.EventApplyConflict, "Not applying %s %s: %v", resourceType.Name(), res.GetName(), err)
+	return api.ResourceConflict{
+		Kind:    resourceType.Name(),
+		Name:    res.GetName(),
+		Message: err.Error(),
+	}
+}
+
+// removeResources deletes the resources one at a time, so that an event is recorded for each of them
+func (reconciler *Reconciler) removeResources(instance *api.KieApp, resourceType reflect.Type, resources []resource.KubernetesResource) (bool, error) {
+	writer := write.New(reconciler.Service).WithOwnerController(instance, reconciler.Service.GetScheme())
+	var hasUpdates bool
+	for _, res := range resources {
+		removed, err := writer.RemoveResources([]resource.KubernetesResource{res})
+		if err != nil {
+			reconciler.recordEvent(instance, corev1.EventTypeWarning, constants.EventDeleteFailed, "Failed to delete %s %s: %v", resourceType.Name(), res.GetName(), err)
+			return hasUpdates, err
+		}
+		if removed {
+			reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventDeleted, "Deleted %s %s", resourceType.Name(), res.GetName())
+		}
+		hasUpdates = hasUpdates || removed
+	}
+	return hasUpdates, nil
+}
+
+// recordEvent emits an event for the KieApp when a recorder is available
+func (reconciler *Reconciler) recordEvent(instance *api.KieApp, eventType, reason, messageFmt string, args ...interface{}) {
+	if reconciler.Recorder == nil || instance.GetUID() == "" {
+		return
+	}
+	reconciler.Recorder.Eventf(instance, eventType, reason, messageFmt, args...)
+}
+
+// finalize removes the ConsoleLink, the local ImageStreamTags and the generated keystore Secrets
+// before releasing the finalizer
+func (reconciler *Reconciler) finalize(instance *api.KieApp) (reconcile.Result, error) {
+	if !hasFinalizer(instance) {
+		return reconcile.Result{}, nil
+	}
+	log := log.With("kind", instance.Kind, "name", instance.Name, "namespace", instance.Namespace)
+	log.Info("Running finalizer")
+	if status.SetDeleting(instance) {
+		defaults.RemoveCredentials(instance)
+		if err := reconciler.Service.Status().Update(context.TODO(), instance); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if err := reconciler.cleanupResources(instance); err != nil {
+		reconciler.setFailedStatus(instance, api.CleanupFailedReason, err)
+		return reconcile.Result{}, err
+	}
+	var finalizers []string
+	for _, finalizer := range instance.GetFinalizers() {
+		if finalizer != constants.KieAppFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	instance.SetFinalizers(finalizers)
+	if err := reconciler.Service.Update(context.TODO(), instance); err != nil {
+		return reconcile.Result{}, err
+	}
+	log.Info("Finalizer completed")
+	return reconcile.Result{}, nil
+}
+
+func (reconciler *Reconciler) cleanupResources(instance *api.KieApp) error {
+	if reconciler.hasConsoleLink(instance) {
+		consoleLink := &consolev1.ConsoleLink{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: getConsoleLinkName(instance),
+			},
+		}
+		if err := reconciler.Service.Delete(context.TODO(), consoleLink); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		} else {
+			reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventDeleted, "Deleted ConsoleLink %s", consoleLink.Name)
+		}
+	}
+
+	if !defaults.IsKubernetes(instance) {
+		if err := reconciler.cleanupImageStreamTags(instance); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range getKeystoreSecretNames(instance) {
+		secret := &corev1.Secret{}
+		err := reconciler.Service.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.Namespace}, secret)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(secret, instance) {
+			continue
+		}
+		log.Debugf("Deleting keystore Secret %s/%s", instance.Namespace, name)
+		if err = reconciler.Service.Delete(context.TODO(), secret); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventDeleted, "Deleted Secret %s", name)
+	}
+	return nil
+}
+
+func (reconciler *Reconciler) cleanupImageStreamTags(instance *api.KieApp) error {
+	tags, err := reconciler.Service.ImageStreamTags(instance.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if tags != nil {
+		for _, tag := range tags.Items {
+			if !isOwnedImageStreamTag(tag, instance) {
+				continue
+			}
+			log.Debugf("Deleting ImageStreamTag %s/%s", instance.Namespace, tag.Name)
+			err = reconciler.Service.ImageStreamTags(instance.Namespace).Delete(context.TODO(), tag.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventDeleted, "Deleted ImageStreamTag %s", tag.Name)
+		}
+	}
+	return nil
+}
+
+func hasFinalizer(instance *api.KieApp) bool {
+	for _, finalizer := range instance.GetFinalizers() {
+		if finalizer == constants.KieAppFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func isOwnedImageStreamTag(tag oimagev1.ImageStreamTag, cr *api.KieApp) bool {
+	if tag.Annotations[constants.KieAppOwnerAnnotation] == cr.Name {
+		return true
+	}
+	return tag.Tag != nil && tag.Tag.Annotations[constants.KieAppOwnerAnnotation] == cr.Name
+}
+
+// getKeystoreSecretNames returns the names of the keystore secrets generated by the operator
+func getKeystoreSecretNames(cr *api.KieApp) []string {
+	var names []string
+	appName := cr.Status.Applied.CommonConfig.ApplicationName
+	if appName == "" {
+		return names
+	}
+	if cr.Status.Applied.Objects.Console.KeystoreSecret == "" {
+		names = append(names, fmt.Sprintf(constants.KeystoreSecret, strings.Join([]string{appName, "businesscentral"}, "-")))
+	}
+	// the server sets are only named once their deployments are defaulted
+	defaulted := true
+	for _, serverSet := range cr.Status.Applied.Objects.Servers {
+		defaulted = defaulted && serverSet.Deployments != nil
+	}
+	for i := 0; defaulted; i++ {
+		serverSet, kieDeploymentName := defaults.GetServerSet(cr, i)
+		if kieDeploymentName == "" {
+			break
+		}
+		if serverSet.KeystoreSecret == "" {
+			names = append(names, fmt.Sprintf(constants.KeystoreSecret, kieDeploymentName))
+		}
+	}
+	if cr.Status.Applied.Objects.SmartRouter == nil || cr.Status.Applied.Objects.SmartRouter.KeystoreSecret == "" {
+		names = append(names, fmt.Sprintf(constants.KeystoreSecret, strings.Join([]string{appName, "smartrouter"}, "-")))
+	}
+	return names
+}
+
 func isNamespaced(resource resource.KubernetesResource) bool {
 	if reflect.TypeOf(resource) == reflect.TypeOf(&consolev1.ConsoleLink{}) {
 		return false
@@ -268,6 +595,68 @@ func getComparator() compare.MapComparator {
 		return defaultDCComparator(deployed, requested)
 	})
 
+	deploymentType := reflect.TypeOf(appsv1.Deployment{})
+	defaultDeploymentComparator := resourceComparator.GetComparator(deploymentType)
+	resourceComparator.SetComparator(deploymentType, func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+		deployment1 := deployed.(*appsv1.Deployment)
+		deployment2 := requested.(*appsv1.Deployment)
+		if deployment2.Spec.Strategy.Type == "" {
+			//This is a default generated strategy that should be ignored
+			deployment1 = deployment1.DeepCopy()
+			deployment1.Spec.Strategy.Type = ""
+		}
+		return defaultDeploymentComparator(deployment1, deployment2)
+	})
+
+	ingressType := reflect.TypeOf(networkingv1beta1.Ingress{})
+	resourceComparator.SetComparator(ingressType, func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+		ingress1 := deployed.(*networkingv1beta1.Ingress)
+		ingress2 := requested.(*networkingv1beta1.Ingress)
+		var pairs [][2]interface{}
+		pairs = append(pairs, [2]interface{}{ingress1.Name, ingress2.Name})
+		pairs = append(pairs, [2]interface{}{ingress1.Namespace, ingress2.Namespace})
+		pairs = append(pairs, [2]interface{}{ingress1.Labels, ingress2.Labels})
+		pairs = append(pairs, [2]interface{}{ingress1.Annotations, ingress2.Annotations})
+		pairs = append(pairs, [2]interface{}{ingress1.Spec, ingress2.Spec})
+		equal := compare.EqualPairs(pairs)
+		if !equal {
+			log.Debugf("Ingress %s differs from the requested one in %v", requested.GetName(), getDriftFields(deployed, requested))
+		}
+		return equal
+	})
+
+	hpaType := reflect.TypeOf(autoscalingv2beta2.HorizontalPodAutoscaler{})
+	resourceComparator.SetComparator(hpaType, func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+		hpa1 := deployed.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+		hpa2 := requested.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+		var pairs [][2]interface{}
+		pairs = append(pairs, [2]interface{}{hpa1.Name, hpa2.Name})
+		pairs = append(pairs, [2]interface{}{hpa1.Namespace, hpa2.Namespace})
+		pairs = append(pairs, [2]interface{}{hpa1.Labels, hpa2.Labels})
+		pairs = append(pairs, [2]interface{}{hpa1.Spec, hpa2.Spec})
+		equal := compare.EqualPairs(pairs)
+		if !equal {
+			log.Debugf("HorizontalPodAutoscaler %s differs from the requested one in %v", requested.GetName(), getDriftFields(deployed, requested))
+		}
+		return equal
+	})
+
+	pdbType := reflect.TypeOf(policyv1beta1.PodDisruptionBudget{})
+	resourceComparator.SetComparator(pdbType, func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+		pdb1 := deployed.(*policyv1beta1.PodDisruptionBudget)
+		pdb2 := requested.(*policyv1beta1.PodDisruptionBudget)
+		var pairs [][2]interface{}
+		pairs = append(pairs, [2]interface{}{pdb1.Name, pdb2.Name})
+		pairs = append(pairs, [2]interface{}{pdb1.Namespace, pdb2.Namespace})
+		pairs = append(pairs, [2]interface{}{pdb1.Labels, pdb2.Labels})
+		pairs = append(pairs, [2]interface{}{pdb1.Spec, pdb2.Spec})
+		equal := compare.EqualPairs(pairs)
+		if !equal {
+			log.Debugf("PodDisruptionBudget %s differs from the requested one in %v", requested.GetName(), getDriftFields(deployed, requested))
+		}
+		return equal
+	})
+
 	bcType := reflect.TypeOf(buildv1.BuildConfig{})
 	defaultBCComparator := resourceComparator.GetComparator(bcType)
 	resourceComparator.SetComparator(bcType, func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
@@ -297,19 +686,81 @@ func getComparator() compare.MapComparator {
 		pairs = append(pairs, [2]interface{}{configMap1.BinaryData, configMap2.BinaryData})
 		equal := compare.EqualPairs(pairs)
 		if !equal {
-			log.Info("Resources are not equal", "deployed", deployed, "requested", requested)
+			log.Debugf("ConfigMap %s differs from the requested one in %v", requested.GetName(), getDriftFields(deployed, requested))
 		}
 		return equal
 	})
 
+	statefulSetType := reflect.TypeOf(appsv1.StatefulSet{})
+	resourceComparator.SetComparator(statefulSetType, func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+		statefulSet1 := deployed.(*appsv1.StatefulSet)
+		statefulSet2 := requested.(*appsv1.StatefulSet)
+		var pairs [][2]interface{}
+		pairs = append(pairs, [2]interface{}{statefulSet1.Name, statefulSet2.Name})
+		pairs = append(pairs, [2]interface{}{statefulSet1.Namespace, statefulSet2.Namespace})
+		pairs = append(pairs, [2]interface{}{statefulSet1.Labels, statefulSet2.Labels})
+		pairs = append(pairs, [2]interface{}{statefulSet1.Annotations, statefulSet2.Annotations})
+		//The pod templates are compared as the ones of Deployments, ignoring the values generated by the server
+		template1 := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: statefulSet1.Spec.Replicas, Template: *statefulSet1.Spec.Template.DeepCopy()}}
+		template2 := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: statefulSet2.Spec.Replicas, Template: *statefulSet2.Spec.Template.DeepCopy()}}
+		if statefulSet2.Spec.Replicas == nil {
+			//This is a default generated number of replicas that should be ignored
+			template1.Spec.Replicas = nil
+		}
+		for i := range template1.Spec.Template.Spec.Containers {
+			container1 := &template1.Spec.Template.Spec.Containers[i]
+			if i < len(template2.Spec.Template.Spec.Containers) && equality.Semantic.DeepEqual(container1.Resources, template2.Spec.Template.Spec.Containers[i].Resources) {
+				//Quantities are stored in their canonical form, e.g. 1000m as 1
+				container1.Resources = template2.Spec.Template.Spec.Containers[i].Resources
+			}
+		}
+		equal := compare.EqualPairs(pairs) && defaultDeploymentComparator(template1, template2)
+		if !equal {
+			log.Debugf("StatefulSet %s differs from the requested one in %v", requested.GetName(), getDriftFields(deployed, requested))
+		}
+		return equal
+	})
+
+	pvcType := reflect.TypeOf(corev1.PersistentVolumeClaim{})
+	resourceComparator.SetComparator(pvcType, func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+		pvc1 := deployed.(*corev1.PersistentVolumeClaim)
+		pvc2 := requested.(*corev1.PersistentVolumeClaim)
+		var pairs [][2]interface{}
+		pairs = append(pairs, [2]interface{}{pvc1.Name, pvc2.Name})
+		pairs = append(pairs, [2]interface{}{pvc1.Namespace, pvc2.Namespace})
+		pairs = append(pairs, [2]interface{}{pvc1.Labels, pvc2.Labels})
+		pairs = append(pairs, [2]interface{}{pvc1.Annotations, pvc2.Annotations})
+		equal := compare.EqualPairs(pairs)
+		if !equal {
+			log.Debugf("PersistentVolumeClaim %s differs from the requested one in %v", requested.GetName(), getDriftFields(deployed, requested))
+		}
+		return equal
+	})
+
+	//Only the labels and annotations applied by the operator are compared, so that changes to the ones of the CR propagate
+	for _, resourceType := range []reflect.Type{dcType, deploymentType, statefulSetType, reflect.TypeOf(corev1.Service{}),
+		reflect.TypeOf(routev1.Route{}), ingressType, pvcType, reflect.TypeOf(corev1.Secret{})} {
+		compareFunc := resourceComparator.GetComparator(resourceType)
+		resourceComparator.SetComparator(resourceType, func(deployed resource.KubernetesResource, requested resource.KubernetesResource) bool {
+			return compareFunc(getOwnedMetadata(deployed, requested), requested)
+		})
+	}
+
 	return compare.MapComparator{Comparator: resourceComparator}
 }
 
-func setDeploymentStatus(instance *api.KieApp, resources []resource.KubernetesResource) {
+func setDeploymentStatus(instance *api.KieApp, deployed map[reflect.Type][]resource.KubernetesResource) {
+	if defaults.IsKubernetes(instance) {
+		var deployments []appsv1.Deployment
+		for _, res := range deployed[reflect.TypeOf(appsv1.Deployment{})] {
+			deployments = append(deployments, *res.(*appsv1.Deployment))
+		}
+		instance.Status.Deployments = olm.GetDeploymentStatus(deployments)
+		return
+	}
 	var dcs []oappsv1.DeploymentConfig
-	for index := range resources {
-		dc := resources[index].(*oappsv1.DeploymentConfig)
-		dcs = append(dcs, *dc)
+	for _, res := range deployed[reflect.TypeOf(oappsv1.DeploymentConfig{})] {
+		dcs = append(dcs, *res.(*oappsv1.DeploymentConfig))
 	}
 	instance.Status.Deployments = olm.GetDeploymentConfigStatus(dcs)
 }
@@ -322,6 +773,21 @@ func (reconciler *Reconciler) verifyExternalReferences(cr *api.KieApp) error {
 	if err == nil && cr.Status.Applied.Objects.Console.GitHooks != nil {
 		err = reconciler.verifyExternalReference(cr.GetNamespace(), cr.Status.Applied.Objects.Console.GitHooks.From)
 	}
+	routes := []*api.RouteObject{cr.Status.Applied.Objects.Console.Route}
+	for _, serverSet := range cr.Status.Applied.Objects.Servers {
+		routes = append(routes, serverSet.Route)
+	}
+	if cr.Status.Applied.Objects.SmartRouter != nil {
+		routes = append(routes, cr.Status.Applied.Objects.SmartRouter.Route)
+	}
+	if cr.Status.Applied.Objects.ProcessMigration != nil {
+		routes = append(routes, cr.Status.Applied.Objects.ProcessMigration.Route)
+	}
+	for _, route := range routes {
+		if err == nil && route != nil && route.TLSSecret != "" {
+			err = reconciler.verifyExternalReference(cr.GetNamespace(), &api.ObjRef{Kind: "Secret", ObjectReference: api.ObjectReference{Name: route.TLSSecret}})
+		}
+	}
 	return err
 }
 
@@ -345,6 +811,10 @@ func (reconciler *Reconciler) verifyExternalReference(namespace string, ref *api
 }
 
 func getRequestedRoutes(env api.Environment, instance *api.KieApp) []resource.KubernetesResource {
+	if defaults.IsKubernetes(instance) {
+		// Ingresses are created along with the other resources, there is no generated hostname to wait for
+		return nil
+	}
 	//Derive routes that should be created:
 	objects := filterOmittedObjects(getCustomObjects(env))
 	var requestedRoutes []resource.KubernetesResource
@@ -377,7 +847,9 @@ func (reconciler *Reconciler) hasStatusChanges(instance, cachedInstance *api.Kie
 }
 
 func (reconciler *Reconciler) setFailedStatus(instance *api.KieApp, reason api.ReasonType, err error) {
+	reconciler.recordEvent(instance, corev1.EventTypeWarning, string(reason), "%s", err.Error())
 	status.SetFailed(instance, reason, err)
+	defaults.RemoveCredentials(instance)
 	if updateError := reconciler.Service.Status().Update(context.TODO(), instance); updateError != nil {
 		log.Warn("Unable to update object after receiving failed status. ", err)
 	}
@@ -401,46 +873,8 @@ func (reconciler *Reconciler) checkImageStreamTag(name, namespace string) bool {
 
 // Create local ImageStreamTag
 func (reconciler *Reconciler) createLocalImageTag(tagRefName, imageURL string, cr *api.KieApp) error {
-	result := strings.Split(tagRefName, ":")
-	if len(result) == 1 {
-		result = append(result, "latest")
-	}
-	product := defaults.GetProduct(cr.Status.Applied.Environment)
-	tagName := fmt.Sprintf("%s:%s", result[0], result[1])
-	imageName := tagName
-	major, _, _ := defaults.GetMajorMinorMicro(cr.Status.Applied.Version)
-	regContext := fmt.Sprintf("%s-%s", product, major)
-	if _, _, imageContext := defaults.GetImage(imageURL); imageContext != "" {
-		regContext = imageContext
-	}
-
-	// default registry settings
-	registry := &api.KieAppRegistry{
-		Insecure: logs.GetBoolEnv("INSECURE"),
-	}
-	if cr.Status.Applied.ImageRegistry != nil {
-		registry = cr.Status.Applied.ImageRegistry
-	}
-	if registry.Registry == "" {
-		registry.Registry = logs.GetEnv("REGISTRY", constants.ImageRegistry)
-	}
-	registryAddress := registry.Registry
-	if strings.Contains(result[0], "datagrid") {
-		registryAddress = constants.ImageRegistry
-		regContext = "jboss-datagrid-7"
-	} else if strings.Contains(result[0], "amq-broker-7") {
-		registryAddress = constants.ImageRegistry
-		regContext = "amq-broker-7"
-		if strings.Contains(result[0], "scaledown") {
-			regContext = "amq-broker-7-tech-preview"
-		}
-	} else if result[0] == "postgresql" || result[0] == "mysql" {
-		registryAddress = constants.ImageRegistry
-		regContext = "rhscl"
-		pattern := regexp.MustCompile("[0-9]+")
-		imageName = fmt.Sprintf("%s-%s-rhel7:%s", result[0], strings.Join(pattern.FindAllString(result[1], -1), ""), "latest")
-	}
-	registryURL := fmt.Sprintf("%s/%s/%s", registryAddress, regContext, imageName)
+	tagName, registryURL := defaults.GetImageRegistryURL(tagRefName, imageURL, cr)
+	registry := defaults.GetImageRegistry(cr)
 
 	isnew := &oimagev1.ImageStreamTag{
 		ObjectMeta: metav1.ObjectMeta{
@@ -448,7 +882,10 @@ func (reconciler *Reconciler) createLocalImageTag(tagRefName, imageURL string, c
 			Namespace: cr.Namespace,
 		},
 		Tag: &oimagev1.TagReference{
-			Name: result[1],
+			Name: strings.Split(tagName, ":")[1],
+			Annotations: map[string]string{
+				constants.KieAppOwnerAnnotation: cr.Name,
+			},
 			From: &corev1.ObjectReference{
 				Kind: "DockerImage",
 				Name: registryURL,
@@ -474,7 +911,7 @@ func (reconciler *Reconciler) createLocalImageTag(tagRefName, imageURL string, c
 	return nil
 }
 
-//loadRoutes attempts to load as many of the specified routes as it can find
+// loadRoutes attempts to load as many of the specified routes as it can find
 func (reconciler *Reconciler) loadRoutes(requestedRoutes []resource.KubernetesResource) (map[types.NamespacedName]routev1.Route, error) {
 	deployedRoutes := make(map[types.NamespacedName]routev1.Route)
 	for _, requested := range requestedRoutes {
@@ -495,16 +932,14 @@ func (reconciler *Reconciler) setEnvironmentProperties(cr *api.KieApp, env api.E
 	if !env.Console.Omit {
 		consoleCN := reconciler.setConsoleHost(cr, env, routes)
 		defaults.ConfigureHostname(&env.Console, cr, consoleCN)
-		if cr.Status.Applied.Objects.Console.KeystoreSecret == "" {
-			secret, err := reconciler.generateKeystoreSecret(
-				fmt.Sprintf(constants.KeystoreSecret, strings.Join([]string{cr.Status.Applied.CommonConfig.ApplicationName, "businesscentral"}, "-")),
-				consoleCN,
-				cr,
-			)
-			if err != nil {
-				return api.Environment{}, err
-			}
-			env.Console.Secrets = append(env.Console.Secrets, secret)
+		err := reconciler.setComponentTLS(cr, &env.Console,
+			fmt.Sprintf(constants.KeystoreSecret, strings.Join([]string{cr.Status.Applied.CommonConfig.ApplicationName, "businesscentral"}, "-")),
+			consoleCN,
+			cr.Status.Applied.Objects.Console.KeystoreSecret,
+			cr.Status.Applied.Objects.Console.Route,
+		)
+		if err != nil {
+			return api.Environment{}, err
 		}
 	}
 
@@ -526,16 +961,14 @@ func (reconciler *Reconciler) setEnvironmentProperties(cr *api.KieApp, env api.E
 		}
 		defaults.ConfigureHostname(&server, cr, serverCN)
 		serverSet, kieDeploymentName := defaults.GetServerSet(cr, i)
-		if serverSet.KeystoreSecret == "" {
-			secret, err := reconciler.generateKeystoreSecret(
-				fmt.Sprintf(constants.KeystoreSecret, kieDeploymentName),
-				serverCN,
-				cr,
-			)
-			if err != nil {
-				return api.Environment{}, err
-			}
-			server.Secrets = append(server.Secrets, secret)
+		err := reconciler.setComponentTLS(cr, &server,
+			fmt.Sprintf(constants.KeystoreSecret, kieDeploymentName),
+			serverCN,
+			serverSet.KeystoreSecret,
+			serverSet.Route,
+		)
+		if err != nil {
+			return api.Environment{}, err
 		}
 		env.Servers[i] = server
 	}
@@ -555,17 +988,30 @@ func (reconciler *Reconciler) setEnvironmentProperties(cr *api.KieApp, env api.E
 		}
 
 		defaults.ConfigureHostname(&env.SmartRouter, cr, smartCN)
-		if cr.Status.Applied.Objects.SmartRouter == nil || cr.Status.Applied.Objects.SmartRouter.KeystoreSecret == "" {
-			secret, err := reconciler.generateKeystoreSecret(
-				fmt.Sprintf(constants.KeystoreSecret, strings.Join([]string{cr.Status.Applied.CommonConfig.ApplicationName, "smartrouter"}, "-")),
-				smartCN,
-				cr,
-			)
-			if err != nil {
-				return api.Environment{}, err
-			}
-			env.SmartRouter.Secrets = append(env.SmartRouter.Secrets, secret)
+		var keystoreSecret string
+		var route *api.RouteObject
+		if cr.Status.Applied.Objects.SmartRouter != nil {
+			keystoreSecret = cr.Status.Applied.Objects.SmartRouter.KeystoreSecret
+			route = cr.Status.Applied.Objects.SmartRouter.Route
+		}
+		err := reconciler.setComponentTLS(cr, &env.SmartRouter,
+			fmt.Sprintf(constants.KeystoreSecret, strings.Join([]string{cr.Status.Applied.CommonConfig.ApplicationName, "smartrouter"}, "-")),
+			smartCN,
+			keystoreSecret,
+			route,
+		)
+		if err != nil {
+			return api.Environment{}, err
+		}
+	}
+
+	// process migration only serves http, its routes terminate TLS at the router
+	if !env.ProcessMigration.Omit && cr.Status.Applied.Objects.ProcessMigration != nil {
+		tlsSecret, err := reconciler.getRouteTLSSecret(cr, cr.Status.Applied.Objects.ProcessMigration.Route)
+		if err != nil {
+			return api.Environment{}, err
 		}
+		setRouteCertificates(&env.ProcessMigration, tlsSecret, nil)
 	}
 	return defaults.ConsolidateObjects(env, cr), nil
 }
@@ -660,16 +1106,30 @@ func filterOmittedObjects(objects []api.CustomObject) []api.CustomObject {
 	return objs
 }
 
-func (reconciler *Reconciler) generateKeystoreSecret(secretName, keystoreCN string, cr *api.KieApp) (secret corev1.Secret, err error) {
+func (reconciler *Reconciler) generateKeystoreSecret(secretName, keystoreCN string, tlsSecret *corev1.Secret, cr *api.KieApp) (secret corev1.Secret, err error) {
 	keyStorePassword := []byte(cr.Status.Applied.CommonConfig.KeyStorePassword)
 	existingSecret := corev1.Secret{}
 	err = reconciler.Service.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, &existingSecret)
 	if err != nil && !errors.IsNotFound(err) {
 		return secret, err
 	}
-	if isValidKeyStoreSecret(existingSecret, keystoreCN, keyStorePassword) {
+	if tlsSecret != nil && isKeyStoreOfCertificate(existingSecret, tlsSecret.Data[corev1.TLSCertKey], keyStorePassword) {
+		secret = existingSecret
+	} else if tlsSecret == nil && isValidKeyStoreSecret(existingSecret, keystoreCN, keyStorePassword) {
 		secret = existingSecret
 	} else {
+		keyStore := shared.GenerateKeystore(keystoreCN, keyStorePassword)
+		if tlsSecret != nil {
+			keyStore, err = shared.GenerateKeystoreFromPEM(tlsSecret.Data[corev1.TLSCertKey], tlsSecret.Data[corev1.TLSPrivateKeyKey], keyStorePassword)
+			if err != nil {
+				return secret, fmt.Errorf("invalid certificate in Secret %s: %v", tlsSecret.Name, err)
+			}
+		}
+		if existingSecret.Name != "" {
+			reconciler.recordEvent(cr, corev1.EventTypeNormal, constants.EventKeystoreRegenerated, "Regenerating keystore in Secret %s for %s", secretName, keystoreCN)
+		} else {
+			reconciler.recordEvent(cr, corev1.EventTypeNormal, constants.EventKeystoreGenerated, "Generating keystore in Secret %s for %s", secretName, keystoreCN)
+		}
 		secret = corev1.Secret{
 			Type: corev1.SecretTypeOpaque,
 			ObjectMeta: metav1.ObjectMeta{
@@ -680,32 +1140,28 @@ func (reconciler *Reconciler) generateKeystoreSecret(secretName, keystoreCN stri
 				},
 			},
 			Data: map[string][]byte{
-				constants.KeystoreName: shared.GenerateKeystore(keystoreCN, keyStorePassword),
+				constants.KeystoreName: keyStore,
 			},
 		}
 		secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
 	}
+	if defaults.IsKubernetes(cr) && len(secret.Data[corev1.TLSCertKey]) == 0 {
+		// the ingresses serve the certificate of the keystore
+		certPEM, keyPEM, err := shared.GetKeystorePEM(secret.Data[constants.KeystoreName], keyStorePassword)
+		if err != nil {
+			return secret, err
+		}
+		secret.Data[corev1.TLSCertKey] = certPEM
+		secret.Data[corev1.TLSPrivateKeyKey] = keyPEM
+	}
 	return secret, nil
 }
 
 func isValidKeyStoreSecret(secret corev1.Secret, keystoreCN string, keyStorePassword []byte) bool {
 	if secret.Data[constants.KeystoreName] != nil {
-		b := bytes.NewReader(secret.Data[constants.KeystoreName])
-		existingKeyStore, err := keystore.Decode(b, keyStorePassword)
+		certs, err := shared.GetKeystoreCertificates(secret.Data[constants.KeystoreName], keyStorePassword)
 		if err == nil {
-			entryJSON, err := json.Marshal(existingKeyStore[constants.KeystoreAlias])
-			if err != nil {
-				log.Error(err)
-			}
-			keyEntry := keystore.PrivateKeyEntry{}
-			if err = json.Unmarshal(entryJSON, &keyEntry); err != nil {
-				log.Error(err)
-			}
-			for _, certEntry := range keyEntry.CertChain {
-				cert, err := x509.ParseCertificate(certEntry.Content)
-				if err != nil {
-					log.Error(err)
-				}
+			for _, cert := range certs {
 				if cert.Subject.CommonName == keystoreCN {
 					return true
 				}
@@ -749,6 +1205,10 @@ func (reconciler *Reconciler) getCustomObjectResources(object api.CustomObject,
 		object.Services[index].SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
 		allObjects = append(allObjects, &object.Services[index])
 	}
+	for index := range object.Deployments {
+		object.Deployments[index].SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+		allObjects = append(allObjects, &object.Deployments[index])
+	}
 	for index := range object.StatefulSets {
 		object.StatefulSets[index].SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
 		allObjects = append(allObjects, &object.StatefulSets[index])
@@ -757,6 +1217,10 @@ func (reconciler *Reconciler) getCustomObjectResources(object api.CustomObject,
 		object.Routes[index].SetGroupVersionKind(routev1.GroupVersion.WithKind("Route"))
 		allObjects = append(allObjects, &object.Routes[index])
 	}
+	for index := range object.Ingresses {
+		object.Ingresses[index].SetGroupVersionKind(networkingv1beta1.SchemeGroupVersion.WithKind("Ingress"))
+		allObjects = append(allObjects, &object.Ingresses[index])
+	}
 	for index := range object.ImageStreams {
 		object.ImageStreams[index].SetGroupVersionKind(oimagev1.GroupVersion.WithKind("ImageStream"))
 		allObjects = append(allObjects, &object.ImageStreams[index])
@@ -769,6 +1233,14 @@ func (reconciler *Reconciler) getCustomObjectResources(object api.CustomObject,
 		object.ConfigMaps[index].SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
 		allObjects = append(allObjects, &object.ConfigMaps[index])
 	}
+	for index := range object.HorizontalPodAutoscalers {
+		object.HorizontalPodAutoscalers[index].SetGroupVersionKind(autoscalingv2beta2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"))
+		allObjects = append(allObjects, &object.HorizontalPodAutoscalers[index])
+	}
+	for index := range object.PodDisruptionBudgets {
+		object.PodDisruptionBudgets[index].SetGroupVersionKind(policyv1beta1.SchemeGroupVersion.WithKind("PodDisruptionBudget"))
+		allObjects = append(allObjects, &object.PodDisruptionBudgets[index])
+	}
 	return allObjects
 }
 
@@ -814,7 +1286,7 @@ func (reconciler *Reconciler) createObj(object resource.KubernetesResource, err
 			return reconcile.Result{}, err
 		}
 		// Object created successfully - return and requeue
-		return reconcile.Result{RequeueAfter: time.Duration(200) * time.Millisecond}, nil
+		return reconcile.Result{RequeueAfter: reconciler.Options.withDefaults().CreateRequeueInterval}, nil
 	} else if err != nil {
 		log.Error("Failed to get object. ", err)
 		return reconcile.Result{}, err
@@ -843,8 +1315,11 @@ func checkTLS(tls *routev1.TLSConfig) bool {
 	return false
 }
 
-// GetRouteHost returns the Hostname of the route provided
+// GetRouteHost returns the Hostname of the route provided, either requested or generated for the deployed route
 func (reconciler *Reconciler) GetRouteHost(route routev1.Route, routes []resource.KubernetesResource) string {
+	if route.Spec.Host != "" {
+		return route.Spec.Host
+	}
 	for index := range routes {
 		candidate := routes[index].(*routev1.Route)
 		if candidate.Name == route.Name && candidate.Namespace == route.Namespace {
@@ -926,17 +1401,16 @@ func (reconciler *Reconciler) checkKieServerConfigMap(instance *api.KieApp, env
 			for _, sDc := range server.DeploymentConfigs {
 				serverDcList[sDc.Name] = sDc.Spec.Replicas
 			}
+			for _, deployment := range server.Deployments {
+				serverDcList[deployment.Name] = *deployment.Spec.Replicas
+			}
 		}
 		// sort through ConfigMap list, focus on ones owned by kie servers whose replicas setting is zero
 		for _, cm := range cmList.Items {
 			for _, ownerRef := range cm.OwnerReferences {
-				if serverDcList[ownerRef.Name] == 0 && ownerRef.Kind == "DeploymentConfig" && cm.Labels[constants.KieServerCMLabel] != "" && cm.Labels[constants.KieServerCMLabel] != "DETACHED" {
-					dcObj := &oappsv1.DeploymentConfig{}
-					if err := reconciler.Service.Get(context.TODO(), types.NamespacedName{Name: ownerRef.Name, Namespace: cm.Namespace}, dcObj); err != nil {
-						log.Error(err)
-					}
+				if serverDcList[ownerRef.Name] == 0 && (ownerRef.Kind == "DeploymentConfig" || ownerRef.Kind == "Deployment") && cm.Labels[constants.KieServerCMLabel] != "" && cm.Labels[constants.KieServerCMLabel] != "DETACHED" {
 					// if server DC replicas equal zero, execute DELETE against console
-					if dcObj.Status.AvailableReplicas == 0 {
+					if reconciler.getAvailableReplicas(ownerRef.Kind, types.NamespacedName{Name: ownerRef.Name, Namespace: cm.Namespace}) == 0 {
 						cmObj := &corev1.ConfigMap{}
 						if err := reconciler.Service.Get(context.TODO(), types.NamespacedName{Name: ownerRef.Name, Namespace: cm.Namespace}, cmObj); err != nil {
 							log.Error(err)
@@ -953,23 +1427,51 @@ func (reconciler *Reconciler) checkKieServerConfigMap(instance *api.KieApp, env
 	}
 }
 
+// getAvailableReplicas returns the available replicas of the named DeploymentConfig or Deployment
+func (reconciler *Reconciler) getAvailableReplicas(kind string, namespacedName types.NamespacedName) int32 {
+	if kind == "Deployment" {
+		deployment := &appsv1.Deployment{}
+		if err := reconciler.Service.Get(context.TODO(), namespacedName, deployment); err != nil {
+			log.Error(err)
+		}
+		return deployment.Status.AvailableReplicas
+	}
+	dcObj := &oappsv1.DeploymentConfig{}
+	if err := reconciler.Service.Get(context.TODO(), namespacedName, dcObj); err != nil {
+		log.Error(err)
+	}
+	return dcObj.Status.AvailableReplicas
+}
+
 func (reconciler *Reconciler) getDeployedResources(instance *api.KieApp) (map[reflect.Type][]resource.KubernetesResource, error) {
 	log := log.With("kind", instance.Kind, "name", instance.Name, "namespace", instance.Namespace)
 
-	reader := read.New(reconciler.Service).WithNamespace(instance.Namespace).WithOwnerObject(instance)
-	resourceMap, err := reader.ListAll(
-		&oappsv1.DeploymentConfigList{},
+	listObjects := []runtime.Object{
 		&corev1.PersistentVolumeClaimList{},
 		&corev1.ServiceAccountList{},
 		&rbacv1.RoleList{},
 		&rbacv1.RoleBindingList{},
 		&corev1.ServiceList{},
 		&appsv1.StatefulSetList{},
-		&routev1.RouteList{},
-		&oimagev1.ImageStreamList{},
-		&buildv1.BuildConfigList{},
 		&corev1.ConfigMapList{},
-	)
+		&autoscalingv2beta2.HorizontalPodAutoscalerList{},
+		&policyv1beta1.PodDisruptionBudgetList{},
+	}
+	if defaults.IsKubernetes(instance) {
+		listObjects = append(listObjects,
+			&appsv1.DeploymentList{},
+			&networkingv1beta1.IngressList{},
+		)
+	} else {
+		listObjects = append(listObjects,
+			&oappsv1.DeploymentConfigList{},
+			&routev1.RouteList{},
+			&oimagev1.ImageStreamList{},
+			&buildv1.BuildConfigList{},
+		)
+	}
+	reader := read.New(reconciler.Service).WithNamespace(instance.Namespace).WithOwnerObject(instance)
+	resourceMap, err := reader.ListAll(listObjects...)
 	if err != nil {
 		log.Warn("Failed to list deployed objects. ", err)
 		return nil, err
@@ -982,30 +1484,40 @@ func (reconciler *Reconciler) getDeployedResources(instance *api.KieApp) (map[re
 	// Will work around by loading known secrets instead
 
 	var secrets []resource.KubernetesResource
-	dcs := resourceMap[reflect.TypeOf(oappsv1.DeploymentConfig{})]
-	for _, res := range dcs {
-		dc := res.(*oappsv1.DeploymentConfig)
-		for _, volume := range dc.Spec.Template.Spec.Volumes {
-			if volume.Secret != nil {
-				name := volume.Secret.SecretName
-				secret := &corev1.Secret{}
-				err := reconciler.Service.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, secret)
-				if err != nil && !errors.IsNotFound(err) {
-					log.Warn("Failed to load Secret", err)
-					return nil, err
-				}
-				for _, ownerRef := range secret.GetOwnerReferences() {
-					if ownerRef.UID == instance.UID {
-						secrets = append(secrets, secret)
-						break
-					}
+	var podSpecs []corev1.PodSpec
+	for _, res := range resourceMap[reflect.TypeOf(oappsv1.DeploymentConfig{})] {
+		podSpecs = append(podSpecs, res.(*oappsv1.DeploymentConfig).Spec.Template.Spec)
+	}
+	for _, res := range resourceMap[reflect.TypeOf(appsv1.Deployment{})] {
+		podSpecs = append(podSpecs, res.(*appsv1.Deployment).Spec.Template.Spec)
+	}
+	for _, res := range resourceMap[reflect.TypeOf(appsv1.StatefulSet{})] {
+		podSpecs = append(podSpecs, res.(*appsv1.StatefulSet).Spec.Template.Spec)
+	}
+	loaded := map[string]bool{}
+	for _, podSpec := range podSpecs {
+		for _, name := range getSecretNames(podSpec) {
+			if loaded[name] {
+				continue
+			}
+			loaded[name] = true
+			secret := &corev1.Secret{}
+			err := reconciler.Service.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: instance.GetNamespace()}, secret)
+			if err != nil && !errors.IsNotFound(err) {
+				log.Warn("Failed to load Secret", err)
+				return nil, err
+			}
+			for _, ownerRef := range secret.GetOwnerReferences() {
+				if ownerRef.UID == instance.UID {
+					secrets = append(secrets, secret)
+					break
 				}
 			}
 		}
 	}
 	resourceMap[reflect.TypeOf(corev1.Secret{})] = secrets
 
-	if semver.Compare(reconciler.OcpVersion, "v4.2") >= 0 || reconciler.OcpVersion == "" {
+	if reconciler.hasConsoleLink(instance) {
 		consoleLink := &consolev1.ConsoleLink{}
 		err = reconciler.Service.Get(context.TODO(), types.NamespacedName{Name: getConsoleLinkName(instance)}, consoleLink)
 		if err != nil {
@@ -1021,6 +1533,24 @@ func (reconciler *Reconciler) getDeployedResources(instance *api.KieApp) (map[re
 	return resourceMap, nil
 }
 
+// getSecretNames returns the names of the Secrets mounted as volumes or read by the env vars of a pod
+func getSecretNames(podSpec corev1.PodSpec) []string {
+	var names []string
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil {
+			names = append(names, volume.Secret.SecretName)
+		}
+	}
+	for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				names = append(names, env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	return names
+}
+
 func (reconciler *Reconciler) getCSV(operator *appsv1.Deployment) *operatorsv1alpha1.ClusterServiceVersion {
 	csv := &operatorsv1alpha1.ClusterServiceVersion{}
 	for _, ref := range operator.GetOwnerReferences() {
@@ -1039,7 +1569,7 @@ func (reconciler *Reconciler) getCSV(operator *appsv1.Deployment) *operatorsv1al
 
 func (reconciler *Reconciler) getConsoleLinkResource(cr *api.KieApp) resource.KubernetesResource {
 	if cr.GetDeletionTimestamp() != nil || cr.Status.ConsoleHost == "" || !strings.HasPrefix(cr.Status.ConsoleHost, "https://") ||
-		(reconciler.OcpVersion != "" && semver.Compare(reconciler.OcpVersion, "v4.2") < 0) {
+		!reconciler.hasConsoleLink(cr) {
 		return nil
 	}
 	consoleLink := &consolev1.ConsoleLink{
@@ -1064,6 +1594,14 @@ func (reconciler *Reconciler) getConsoleLinkResource(cr *api.KieApp) resource.Ku
 	return consoleLink
 }
 
+// hasConsoleLink returns true when the KieApp is deployed to an OpenShift version that supports ConsoleLinks
+func (reconciler *Reconciler) hasConsoleLink(cr *api.KieApp) bool {
+	if defaults.IsKubernetes(cr) {
+		return false
+	}
+	return semver.Compare(reconciler.OcpVersion, "v4.2") >= 0 || reconciler.OcpVersion == ""
+}
+
 func getConsoleLinkName(cr *api.KieApp) string {
 	return fmt.Sprintf("%s-link-%s", cr.Namespace, cr.Name)
 }
@@ -16,13 +16,21 @@ import (
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
 	oappsv1 "github.com/openshift/api/apps/v1"
+	buildv1 "github.com/openshift/api/build/v1"
 	consolev1 "github.com/openshift/api/console/v1"
+	oimagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	clientv1 "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -92,6 +100,7 @@ func TestGenerateSecret(t *testing.T) {
 	secret, err := reconciler.generateKeystoreSecret(
 		fmt.Sprintf(constants.KeystoreSecret, strings.Join([]string{cr.Status.Applied.CommonConfig.ApplicationName, "businesscentral"}, "-")),
 		consoleCN,
+		nil,
 		cr,
 	)
 	assert.Nil(t, err)
@@ -411,7 +420,7 @@ func TestVerifyExternalReferencesRoleMapper(t *testing.T) {
 	reconciler := &Reconciler{Service: mockService}
 	for _, test := range tests {
 		mockService.GetFunc = func(ctx context.Context, key clientv1.ObjectKey, obj runtime.Object) error {
-			if test.errMsg == "" {
+			if test.errMsg == "" || key.Name == defaults.GetCredentialsSecretName(cr) {
 				return nil
 			}
 			return fmt.Errorf("Mock: Not found")
@@ -724,6 +733,9 @@ func TestStatusDeploymentsProgression(t *testing.T) {
 
 	cr = reloadCR(t, service, crNamespacedName)
 	assert.Equal(t, api.ProvisioningConditionType, cr.Status.Conditions[0].Type)
+	assert.Equal(t, api.ProvisioningConditionType, cr.Status.Phase, "Phase must not be deployed while pods are not ready")
+	assert.Equal(t, corev1.ConditionFalse, getCondition(cr, api.ConsoleReadyConditionType).Status)
+	assert.Equal(t, corev1.ConditionFalse, getCondition(cr, api.ReadyConditionType).Status)
 	assert.Len(t, cr.Status.Deployments.Stopped, 1, "Expect 1 stopped deployments")
 	assert.Len(t, cr.Status.Deployments.Starting, 1, "Expect 1 deployment starting up")
 
@@ -742,10 +754,12 @@ func TestStatusDeploymentsProgression(t *testing.T) {
 
 	result, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
 	assert.Nil(t, err)
-	assert.Equal(t, reconcile.Result{}, result, depMessage)
+	assert.Equal(t, reconcile.Result{Requeue: true}, result, "All components ready, custom Resource status set to deployed, and requeued")
 
 	cr = reloadCR(t, service, crNamespacedName)
 	assert.Equal(t, api.ProvisioningConditionType, cr.Status.Conditions[0].Type)
+	assert.Equal(t, api.DeployedConditionType, cr.Status.Phase)
+	assert.Equal(t, corev1.ConditionTrue, getCondition(cr, api.ReadyConditionType).Status)
 	assert.Len(t, cr.Status.Deployments.Stopped, 0, "Expect 0 stopped deployments")
 	assert.Len(t, cr.Status.Deployments.Starting, 0, "Expect 0 deployment starting up")
 	assert.Len(t, cr.Status.Deployments.Ready, 2, "Expect 2 deployment to be ready")
@@ -803,6 +817,9 @@ func TestConsoleLinkCreation(t *testing.T) {
 
 	cr = reloadCR(t, service, crNamespacedName)
 	assert.Equal(t, api.ProvisioningConditionType, cr.Status.Conditions[0].Type)
+	assert.Equal(t, api.ProvisioningConditionType, cr.Status.Phase, "Phase must not be deployed while pods are not ready")
+	assert.Equal(t, corev1.ConditionFalse, getCondition(cr, api.ConsoleReadyConditionType).Status)
+	assert.Equal(t, corev1.ConditionFalse, getCondition(cr, api.ReadyConditionType).Status)
 	assert.Len(t, cr.Status.Deployments.Stopped, 1, "Expect 1 stopped deployments")
 	assert.Len(t, cr.Status.Deployments.Starting, 1, "Expect 1 deployment starting up")
 
@@ -821,10 +838,12 @@ func TestConsoleLinkCreation(t *testing.T) {
 
 	result, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
 	assert.Nil(t, err)
-	assert.Equal(t, reconcile.Result{}, result, depMessage)
+	assert.Equal(t, reconcile.Result{Requeue: true}, result, "All components ready, custom Resource status set to deployed, and requeued")
 
 	cr = reloadCR(t, service, crNamespacedName)
 	assert.Equal(t, api.ProvisioningConditionType, cr.Status.Conditions[0].Type)
+	assert.Equal(t, api.DeployedConditionType, cr.Status.Phase)
+	assert.Equal(t, corev1.ConditionTrue, getCondition(cr, api.ReadyConditionType).Status)
 	assert.Len(t, cr.Status.Deployments.Stopped, 0, "Expect 0 stopped deployments")
 	assert.Len(t, cr.Status.Deployments.Starting, 0, "Expect 0 deployment starting up")
 	assert.Len(t, cr.Status.Deployments.Ready, 2, "Expect 2 deployment to be ready")
@@ -852,6 +871,212 @@ func TestConsoleLinkCreation(t *testing.T) {
 	assert.Error(t, err, "ConsoleLink must have been removed by the Finalizer")
 }
 
+func TestKeystoreSecretNamesBeforeServerDefaults(t *testing.T) {
+	cr := &api.KieApp{
+		Status: api.KieAppStatus{
+			Applied: api.KieAppSpec{
+				CommonConfig: api.CommonConfig{ApplicationName: "cr"},
+				Objects: api.KieAppObjects{
+					Servers: []api.KieServerSet{{}},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []string{"cr-businesscentral-app-secret", "cr-smartrouter-app-secret"}, getKeystoreSecretNames(cr))
+}
+
+func TestFinalizerCleanup(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+	}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	reconciler := Reconciler{Service: service}
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, []string{constants.KieAppFinalizer}, cr.GetFinalizers())
+
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	cr = reloadCR(t, service, crNamespacedName)
+	secretNames := []string{"cr-businesscentral-app-secret", "cr-kieserver-app-secret"}
+	assert.Subset(t, getKeystoreSecretNames(cr), secretNames)
+	for _, name := range secretNames {
+		assert.Nil(t, service.Get(context.TODO(), getNamespacedName(cr.Namespace, name), &corev1.Secret{}), "Keystore secret %s should have been generated", name)
+	}
+
+	image := fmt.Sprintf("rhpam-businesscentral-openshift:%s", cr.Status.Applied.Version)
+	assert.Nil(t, reconciler.createLocalImageTag(image, constants.ImageRegistry+"/rhpam-7/"+image, cr))
+	isTags := service.ImageStreamTags(cr.Namespace)
+	_, err = isTags.Create(context.TODO(), &oimagev1.ImageStreamTag{ObjectMeta: metav1.ObjectMeta{Name: "other:1.0", Namespace: cr.Namespace}}, metav1.CreateOptions{})
+	assert.Nil(t, err)
+
+	deletionTimestamp := metav1.Now()
+	cr.SetDeletionTimestamp(&deletionTimestamp)
+	err = service.Update(context.TODO(), cr)
+	assert.Nil(t, err)
+
+	result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Len(t, cr.GetFinalizers(), 0)
+	assert.Equal(t, api.DeletingConditionType, cr.Status.Phase)
+	assert.Equal(t, api.DeletingConditionType, cr.Status.Conditions[len(cr.Status.Conditions)-1].Type)
+	for _, name := range secretNames {
+		assert.Error(t, service.Get(context.TODO(), getNamespacedName(cr.Namespace, name), &corev1.Secret{}), "Keystore secret %s must have been removed by the Finalizer", name)
+	}
+	tags, err := isTags.List(context.TODO(), metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, tags.Items, 1, "Only the ImageStreamTag created by the operator must have been removed")
+	assert.Equal(t, "other:1.0", tags.Items[0].Name)
+}
+
+func TestRecordEvents(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+	}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	recorder := record.NewFakeRecorder(100)
+	reconciler := Reconciler{Service: service, Recorder: recorder}
+
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	events := readEvents(recorder)
+	assert.Contains(t, events, "Normal Created Created Route cr-rhpamcentr")
+	assert.Contains(t, events, "Normal Created Created Route cr-kieserver")
+
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	events = readEvents(recorder)
+	assert.Contains(t, events, "Normal KeystoreGenerated Generating keystore in Secret cr-businesscentral-app-secret for cr")
+	assert.Contains(t, events, "Normal Created Created DeploymentConfig cr-rhpamcentr")
+	assert.Contains(t, events, "Normal Created Created Secret cr-kieserver-app-secret")
+
+	cr = reloadCR(t, service, crNamespacedName)
+	cr.Spec.Objects.Console.GitHooks = &api.GitHooksVolume{From: &api.ObjRef{
+		Kind:            "ConfigMap",
+		ObjectReference: api.ObjectReference{Name: "missing"},
+	}}
+	err = service.Update(context.TODO(), cr)
+	assert.Nil(t, err)
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Error(t, err)
+	events = readEvents(recorder)
+	assert.Equal(t, []string{"Warning MissingDependencies " + err.Error()}, events)
+}
+
+func TestRecordUpgradeConflictEvent(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+		Version:     constants.PriorVersion1,
+		Upgrades:    api.KieAppUpgrades{Enabled: true, Minor: true},
+	}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	// the same field is customized in the applied version and changed in the upgraded version
+	for version, replicas := range map[string]string{constants.PriorVersion1: "5", constants.CurrentVersion: "3"} {
+		err = service.Create(context.TODO(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: strings.Join([]string{constants.ConfigMapPrefix, version}, "-")},
+			Data:       map[string]string{"common.yaml": "console:\n  replicas: " + replicas + "\n"},
+		})
+		assert.Nil(t, err)
+	}
+	recorder := record.NewFakeRecorder(100)
+	reconciler := Reconciler{Service: service, Recorder: recorder}
+
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Error(t, err)
+	events := readEvents(recorder)
+	assert.Equal(t, []string{"Warning UpgradeConflict " + err.Error()}, events)
+
+	err = service.Get(context.TODO(), crNamespacedName, cr)
+	assert.Nil(t, err)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version, "The applied version should not be upgraded")
+	assert.Equal(t, api.UpgradeConflictReason, cr.Status.Conditions[len(cr.Status.Conditions)-1].Reason)
+	assert.Len(t, cr.Status.UpgradeConflicts, 1)
+	assert.Equal(t, "console.replicas", cr.Status.UpgradeConflicts[0].Path)
+	assert.Equal(t, "5", cr.Status.UpgradeConflicts[0].Customized)
+	assert.Equal(t, "3", cr.Status.UpgradeConflicts[0].Upgraded)
+}
+
+func TestApplyConflicts(t *testing.T) {
+	cr := getInstance(getNamespacedName("testns", "cr"))
+	service := test.MockService()
+	recorder := record.NewFakeRecorder(100)
+	reconciler := Reconciler{Service: service, Recorder: recorder}
+	conflict := errors.NewApplyConflict([]metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: "conflict with \"istio\": .spec.ports",
+		Field:   ".spec.ports",
+	}}, "Apply failed with 1 conflict: conflict with \"istio\": .spec.ports")
+	applyPatch := service.PatchFunc
+	service.PatchFunc = func(ctx context.Context, obj runtime.Object, patch clientv1.Patch, opts ...clientv1.PatchOption) error {
+		assert.Equal(t, types.ApplyPatchType, patch.Type())
+		options := &clientv1.PatchOptions{}
+		options.ApplyOptions(opts)
+		assert.Equal(t, constants.FieldManager, options.FieldManager)
+		assert.Nil(t, options.Force, "Fields owned by other managers should not be overwritten")
+		if _, ok := obj.(*corev1.Service); ok {
+			return conflict
+		}
+		return applyPatch(ctx, obj, patch, opts...)
+	}
+	requested := []resource.KubernetesResource{
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "cr-kieserver", Namespace: "testns"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cr-kieserver", Namespace: "testns"}},
+	}
+
+	hasUpdates, err := reconciler.reconcileResources(cr, requested, map[reflect.Type][]resource.KubernetesResource{})
+	assert.Nil(t, err, "Conflicts should not fail the reconciliation")
+	assert.True(t, hasUpdates)
+	assert.Equal(t, []api.ResourceConflict{{Kind: "Service", Name: "cr-kieserver", Message: conflict.Error()}}, cr.Status.Conflicts)
+	events := readEvents(recorder)
+	assert.Contains(t, events, "Warning ApplyConflict Not applying Service cr-kieserver: "+conflict.Error())
+	assert.Contains(t, events, "Normal Created Created ConfigMap cr-kieserver")
+
+	cm := &corev1.ConfigMap{}
+	assert.Nil(t, service.Get(context.TODO(), getNamespacedName("testns", "cr-kieserver"), cm))
+	assert.Equal(t, cr.Name, cm.OwnerReferences[0].Name)
+
+	service.PatchFunc = applyPatch
+	_, err = reconciler.reconcileResources(cr, requested, map[reflect.Type][]resource.KubernetesResource{})
+	assert.Nil(t, err)
+	assert.Empty(t, cr.Status.Conflicts, "Resolved conflicts should be removed from the status")
+}
+
+func readEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func getCondition(cr *api.KieApp, conditionType api.ConditionType) api.Condition {
+	for _, condition := range cr.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition
+		}
+	}
+	return api.Condition{}
+}
+
 func getNamespacedName(namespace string, name string) types.NamespacedName {
 	return types.NamespacedName{
 		Name:      name,
@@ -1261,3 +1486,144 @@ func TestGetComparatorConfigMap(t *testing.T) {
 		})
 	}
 }
+
+func TestGetComparatorStatefulSet(t *testing.T) {
+	statefulSetType := reflect.TypeOf(appsv1.StatefulSet{})
+	requested := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-amq", Namespace: "test"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"application": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:      "test-amq",
+						Image:     "amq-broker:7.7",
+						Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: apiresource.MustParse("1000m")}},
+					}},
+				},
+			},
+		},
+	}
+	deployed := requested.DeepCopy()
+	deployed.Spec.Replicas = defaults.Pint32(1)
+	deployed.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	deployed.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+	deployed.Spec.Template.Spec.SchedulerName = corev1.DefaultSchedulerName
+	deployed.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullAlways
+	deployed.Spec.Template.Spec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	deployed.Spec.Template.Spec.Containers[0].TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	deployed.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU] = apiresource.MustParse("1")
+	comparator := getComparator()
+	compareStatefulSets := func(requested *appsv1.StatefulSet) compare.ResourceDelta {
+		return comparator.Compare(
+			map[reflect.Type][]resource.KubernetesResource{statefulSetType: {deployed}},
+			map[reflect.Type][]resource.KubernetesResource{statefulSetType: {requested}},
+		)[statefulSetType]
+	}
+	delta := compareStatefulSets(requested)
+	assert.False(t, delta.HasChanges(), "The values generated by the server should be ignored")
+
+	image := requested.DeepCopy()
+	image.Spec.Template.Spec.Containers[0].Image = "amq-broker:7.8"
+	delta = compareStatefulSets(image)
+	assert.Len(t, delta.Updated, 1, "The changed pod template should be updated")
+
+	replicas := requested.DeepCopy()
+	replicas.Spec.Replicas = defaults.Pint32(3)
+	delta = compareStatefulSets(replicas)
+	assert.Len(t, delta.Updated, 1, "The changed replicas should be updated")
+}
+
+func TestKubernetesPlatform(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment:   api.RhpamAuthoring,
+		Platform:      api.PlatformKubernetes,
+		IngressDomain: "apps.example.com",
+		UseImageTags:  true,
+	}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	// OpenShift APIs are not served on a vanilla Kubernetes cluster
+	service.ListFunc = func(ctx context.Context, list runtime.Object, opts ...clientv1.ListOption) error {
+		switch list.(type) {
+		case *oappsv1.DeploymentConfigList, *routev1.RouteList, *oimagev1.ImageStreamList, *buildv1.BuildConfigList:
+			return fmt.Errorf("no matches for kind %T", list)
+		}
+		return service.Client.List(ctx, list, opts...)
+	}
+	service.GetFunc = func(ctx context.Context, key clientv1.ObjectKey, obj runtime.Object) error {
+		if _, ok := obj.(*consolev1.ConsoleLink); ok {
+			return fmt.Errorf("no matches for kind %T", obj)
+		}
+		return service.Client.Get(ctx, key, obj)
+	}
+	reconciler := Reconciler{Service: service}
+	result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{Requeue: true}, result, "All resources created at once, no route hostname to wait for")
+
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, "https://cr-rhpamcentr-testns.apps.example.com", cr.Status.ConsoleHost)
+
+	deployment := &appsv1.Deployment{}
+	err = service.Get(context.TODO(), getNamespacedName(cr.Namespace, "cr-rhpamcentr"), deployment)
+	assert.Nil(t, err)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	assert.Equal(t, map[string]string{"deploymentConfig": "cr-rhpamcentr"}, deployment.Spec.Selector.MatchLabels)
+	assert.Equal(t, fmt.Sprintf("%s/rhpam-7/rhpam-businesscentral-rhel8:%s", constants.ImageRegistry, cr.Status.Applied.Version), deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.True(t, metav1.IsControlledBy(deployment, cr))
+
+	ingress := &networkingv1beta1.Ingress{}
+	err = service.Get(context.TODO(), getNamespacedName(cr.Namespace, "cr-rhpamcentr"), ingress)
+	assert.Nil(t, err)
+	assert.Equal(t, "true", ingress.Annotations[constants.IngressSSLPassthroughAnnotation])
+	assert.Equal(t, "cr-rhpamcentr", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.ServiceName)
+	assert.Equal(t, "https", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.ServicePort.String())
+	assert.Equal(t, "cr-rhpamcentr-testns.apps.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "cr-businesscentral-app-secret", ingress.Spec.TLS[0].SecretName)
+	keystoreSecret := &corev1.Secret{}
+	err = service.Get(context.TODO(), getNamespacedName(cr.Namespace, "cr-businesscentral-app-secret"), keystoreSecret)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, keystoreSecret.Data[corev1.TLSCertKey], "The ingress serves the certificate of the keystore")
+	assert.NotEmpty(t, keystoreSecret.Data[corev1.TLSPrivateKeyKey])
+
+	assert.Error(t, service.Client.Get(context.TODO(), getNamespacedName(cr.Namespace, "cr-rhpamcentr"), &oappsv1.DeploymentConfig{}))
+	assert.Error(t, service.Client.Get(context.TODO(), getNamespacedName(cr.Namespace, "cr-rhpamcentr"), &routev1.Route{}))
+	tags, err := service.ImageStreamTags(cr.Namespace).List(context.TODO(), metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Nil(t, tags, "No ImageStreamTags expected on Kubernetes")
+
+	result, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Len(t, cr.Status.Deployments.Stopped, 2, "Expect 2 stopped deployments")
+	assert.Equal(t, corev1.ConditionFalse, getCondition(cr, api.ConsoleReadyConditionType).Status)
+	assert.Equal(t, "Deployment cr-rhpamcentr rollout in progress", getCondition(cr, api.ConsoleReadyConditionType).Message)
+}
+
+func TestReconcileDisruptionBudgets(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "ha")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{Environment: api.RhpamAuthoringHA}
+	service := test.MockService()
+	assert.Nil(t, service.Create(context.TODO(), cr))
+	reconciler := Reconciler{Service: service}
+	for i := 0; i < 3; i++ {
+		_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		assert.Nil(t, err)
+	}
+
+	pdbs := &policyv1beta1.PodDisruptionBudgetList{}
+	assert.Nil(t, service.List(context.TODO(), pdbs))
+	var names []string
+	for _, pdb := range pdbs.Items {
+		names = append(names, pdb.Name)
+		assert.Equal(t, "ha", pdb.OwnerReferences[0].Name, "The PodDisruptionBudget should be owned by the KieApp")
+	}
+	assert.ElementsMatch(t, []string{"ha-rhpamcentr", "ha-kieserver", "ha-datagrid", "ha-amq"}, names)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Empty(t, cr.Status.Drift, "The PodDisruptionBudgets should not be updated again")
+}
@@ -0,0 +1,144 @@
+package kieapp
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/RHsyseng/operator-utils/pkg/resource"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v3/fieldpath"
+)
+
+// getOwnedMetadata returns a copy of the deployed resource keeping only the labels and annotations that are requested
+// or were applied by the operator. Those added by other managers are not compared, while the ones removed from the CR
+// are still detected, to be removed by the next apply. The deployed resource is returned as is if it was not applied
+// by the operator, e.g. if created before server-side apply was used.
+func getOwnedMetadata(deployed, requested resource.KubernetesResource) resource.KubernetesResource {
+	ownedLabels, ownedAnnotations, applied := getAppliedMetadata(deployed)
+	if !applied {
+		return deployed
+	}
+	owned := deployed.DeepCopyObject().(resource.KubernetesResource)
+	owned.SetLabels(filterMetadata(deployed.GetLabels(), requested.GetLabels(), ownedLabels))
+	owned.SetAnnotations(filterMetadata(deployed.GetAnnotations(), requested.GetAnnotations(), ownedAnnotations))
+	return owned
+}
+
+// getAppliedMetadata returns the names of the labels and annotations of the resource managed by the operator,
+// and whether the resource was applied by it at all
+func getAppliedMetadata(res metav1.Object) (labels, annotations map[string]bool, applied bool) {
+	labels = map[string]bool{}
+	annotations = map[string]bool{}
+	for _, entry := range res.GetManagedFields() {
+		if entry.Manager != constants.FieldManager || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := &fieldpath.Set{}
+		if err := fields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			log.Warnf("Unable to read the managed fields of %s: %v", res.GetName(), err)
+			continue
+		}
+		applied = true
+		metadata := fields.WithPrefix(getFieldPathElement("metadata"))
+		addFieldNames(labels, metadata.WithPrefix(getFieldPathElement("labels")))
+		addFieldNames(annotations, metadata.WithPrefix(getFieldPathElement("annotations")))
+	}
+	return labels, annotations, applied
+}
+
+func getFieldPathElement(name string) fieldpath.PathElement {
+	return fieldpath.PathElement{FieldName: &name}
+}
+
+// addFieldNames adds the names of the fields of the set, e.g. the keys of a map
+func addFieldNames(names map[string]bool, fields *fieldpath.Set) {
+	fields.Members.Iterate(func(element fieldpath.PathElement) {
+		if element.FieldName != nil {
+			names[*element.FieldName] = true
+		}
+	})
+}
+
+// filterMetadata returns the deployed values that are requested or owned by the operator
+func filterMetadata(deployed, requested map[string]string, owned map[string]bool) map[string]string {
+	var filtered map[string]string
+	for key, value := range deployed {
+		if _, found := requested[key]; found || owned[key] {
+			if filtered == nil {
+				filtered = map[string]string{}
+			}
+			filtered[key] = value
+		}
+	}
+	if filtered == nil && requested != nil {
+		return map[string]string{}
+	}
+	return filtered
+}
+
+// migrateManagedFields hands over to the apply manager of the operator the fields it owns through updates,
+// e.g. of the resources deployed before server-side apply was used. Otherwise, the fields still owned by the
+// update manager would conflict, or would be retained once no longer rendered, when the resource is applied.
+func (reconciler *Reconciler) migrateManagedFields(res resource.KubernetesResource) error {
+	deployed := res.DeepCopyObject().(resource.KubernetesResource)
+	err := reconciler.Service.Get(context.TODO(), types.NamespacedName{Namespace: res.GetNamespace(), Name: res.GetName()}, deployed)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	managedFields, migrated, err := getMigratedManagedFields(deployed.GetManagedFields())
+	if err != nil || !migrated {
+		return err
+	}
+	log.Debugf("Migrating the fields of %s updated by %s to server-side apply", res.GetName(), constants.FieldManager)
+	patch := client.MergeFromWithOptions(deployed.DeepCopyObject(), client.MergeFromWithOptimisticLock{})
+	deployed.SetManagedFields(managedFields)
+	return reconciler.Service.Patch(context.TODO(), deployed, patch)
+}
+
+// getMigratedManagedFields merges the fields updated by the operator into the fields it applies,
+// and returns whether there were any to migrate
+func getMigratedManagedFields(entries []metav1.ManagedFieldsEntry) ([]metav1.ManagedFieldsEntry, bool, error) {
+	var managedFields []metav1.ManagedFieldsEntry
+	var apply *metav1.ManagedFieldsEntry
+	var migrated bool
+	owned := &fieldpath.Set{}
+	for _, entry := range entries {
+		if entry.Manager != constants.FieldManager {
+			managedFields = append(managedFields, entry)
+			continue
+		}
+		if entry.Operation == metav1.ManagedFieldsOperationApply {
+			apply = entry.DeepCopy()
+		} else {
+			migrated = true
+			if apply == nil {
+				apply = entry.DeepCopy()
+			}
+		}
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		fields := &fieldpath.Set{}
+		if err := fields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, false, err
+		}
+		owned = owned.Union(fields)
+	}
+	if !migrated {
+		return entries, false, nil
+	}
+	raw, err := owned.ToJSON()
+	if err != nil {
+		return nil, false, err
+	}
+	apply.Operation = metav1.ManagedFieldsOperationApply
+	apply.FieldsType = "FieldsV1"
+	apply.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+	return append(managedFields, *apply), true, nil
+}
@@ -0,0 +1,174 @@
+package kieapp
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/RHsyseng/operator-utils/pkg/resource"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientv1 "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetComparatorOwnedMetadata(t *testing.T) {
+	deployed := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "test",
+			Labels:      map[string]string{"application": "test", "cost-center": "1234"},
+			Annotations: map[string]string{"description": "test", "openshift.io/generated-by": "other"},
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:   constants.FieldManager,
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:application":{},"f:cost-center":{}},` +
+						`"f:annotations":{"f:description":{}}},"f:spec":{"f:ports":{}}}`)},
+				},
+				{
+					Manager:   "other",
+					Operation: metav1.ManagedFieldsOperationUpdate,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:openshift.io/generated-by":{}}}}`)},
+				},
+			},
+		},
+	}
+	requested := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "test",
+			Labels:      map[string]string{"application": "test", "cost-center": "1234"},
+			Annotations: map[string]string{"description": "test"},
+		},
+	}
+	serviceType := reflect.TypeOf(corev1.Service{})
+	comparator := getComparator()
+	compareServices := func(requested *corev1.Service) bool {
+		deltas := comparator.Compare(
+			map[reflect.Type][]resource.KubernetesResource{serviceType: {deployed}},
+			map[reflect.Type][]resource.KubernetesResource{serviceType: {requested}},
+		)
+		delta := deltas[serviceType]
+		return !delta.HasChanges()
+	}
+	assert.True(t, compareServices(requested), "The annotations of other managers should be ignored")
+
+	changed := requested.DeepCopy()
+	changed.Labels["cost-center"] = "5678"
+	assert.False(t, compareServices(changed), "The changed labels should be updated")
+
+	removed := requested.DeepCopy()
+	delete(removed.Labels, "cost-center")
+	assert.False(t, compareServices(removed), "The labels removed from the CR should be removed")
+
+	added := requested.DeepCopy()
+	added.Annotations["owner"] = "kie-team"
+	assert.False(t, compareServices(added), "The added annotations should be applied")
+
+	assert.Equal(t, map[string]string{"description": "test", "openshift.io/generated-by": "other"}, deployed.Annotations, "The deployed resource should not be changed")
+}
+
+func TestGetOwnedMetadataNotApplied(t *testing.T) {
+	deployed := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test",
+			Labels: map[string]string{"application": "test", "other": "value"},
+		},
+	}
+	requested := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test",
+			Labels: map[string]string{"application": "test"},
+		},
+	}
+	assert.Equal(t, deployed, getOwnedMetadata(deployed, requested), "Resources not applied by the operator should be compared as is")
+}
+
+func TestApplyResourceMigratesUpdatedFields(t *testing.T) {
+	deployed := &oappsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rhpamcentr",
+			Namespace: "test",
+			Labels:    map[string]string{"application": "test", "cost-center": "1234"},
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:    constants.FieldManager,
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					APIVersion: "apps.openshift.io/v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:application":{}}},"f:spec":{"f:replicas":{}}}`)},
+				},
+				{
+					Manager:    "oc",
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					APIVersion: "apps.openshift.io/v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:cost-center":{}}}}`)},
+				},
+			},
+		},
+		Spec: oappsv1.DeploymentConfigSpec{Replicas: 1},
+	}
+	service := test.MockService()
+	assert.Nil(t, service.Create(context.TODO(), deployed))
+
+	var appliedOver []metav1.ManagedFieldsEntry
+	patchFunc := service.PatchFunc
+	service.PatchFunc = func(ctx context.Context, obj runtime.Object, patch clientv1.Patch, opts ...clientv1.PatchOption) error {
+		if patch.Type() == types.ApplyPatchType {
+			current := &oappsv1.DeploymentConfig{}
+			assert.Nil(t, service.Get(ctx, types.NamespacedName{Namespace: "test", Name: "test-rhpamcentr"}, current))
+			appliedOver = current.ManagedFields
+		}
+		return patchFunc(ctx, obj, patch, opts...)
+	}
+	reconciler := &Reconciler{Service: service}
+	requested := &oappsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rhpamcentr",
+			Namespace: "test",
+			Labels:    map[string]string{"application": "test"},
+		},
+		Spec: oappsv1.DeploymentConfigSpec{Replicas: 2},
+	}
+	assert.Nil(t, reconciler.applyResource(&api.KieApp{}, requested))
+
+	assert.Len(t, appliedOver, 2, "The fields should be migrated before the resource is applied")
+	assert.Equal(t, "oc", appliedOver[0].Manager)
+	assert.Equal(t, metav1.ManagedFieldsOperationUpdate, appliedOver[0].Operation, "The fields of other managers should be retained")
+	assert.Equal(t, constants.FieldManager, appliedOver[1].Manager)
+	assert.Equal(t, metav1.ManagedFieldsOperationApply, appliedOver[1].Operation)
+	assert.Equal(t, "apps.openshift.io/v1", appliedOver[1].APIVersion)
+	assert.JSONEq(t, `{"f:metadata":{"f:labels":{"f:application":{}}},"f:spec":{"f:replicas":{}}}`, string(appliedOver[1].FieldsV1.Raw))
+}
+
+func TestGetMigratedManagedFields(t *testing.T) {
+	applied := metav1.ManagedFieldsEntry{
+		Manager:   constants.FieldManager,
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+	}
+	managedFields, migrated, err := getMigratedManagedFields([]metav1.ManagedFieldsEntry{applied})
+	assert.Nil(t, err)
+	assert.False(t, migrated, "Applied fields should not be migrated")
+	assert.Equal(t, []metav1.ManagedFieldsEntry{applied}, managedFields)
+
+	updated := metav1.ManagedFieldsEntry{
+		Manager:   constants.FieldManager,
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:application":{}}}}`)},
+	}
+	managedFields, migrated, err = getMigratedManagedFields([]metav1.ManagedFieldsEntry{updated, applied})
+	assert.Nil(t, err)
+	assert.True(t, migrated)
+	assert.Len(t, managedFields, 1, "The updated fields should be merged into the applied ones")
+	assert.Equal(t, metav1.ManagedFieldsOperationApply, managedFields[0].Operation)
+	assert.JSONEq(t, `{"f:metadata":{"f:labels":{"f:application":{}}},"f:spec":{"f:replicas":{}}}`, string(managedFields[0].FieldsV1.Raw))
+}
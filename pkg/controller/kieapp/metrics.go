@@ -0,0 +1,44 @@
+package kieapp
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	resultSuccess = "success"
+	resultRequeue = "requeue"
+	resultError   = "error"
+)
+
+// reconcileDuration measures the duration of the reconciliations of each KieApp, by their result
+var reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "kieapp_reconcile_duration_seconds",
+	Help:    "Duration of the reconciliations of each KieApp",
+	Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+}, []string{"namespace", "name", "result"})
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration)
+}
+
+// observeReconcile records the duration of the reconciliation of a KieApp
+func observeReconcile(request reconcile.Request, start time.Time, result reconcile.Result, err error) {
+	reconcileResult := resultSuccess
+	if err != nil {
+		reconcileResult = resultError
+	} else if result.Requeue || result.RequeueAfter > 0 {
+		reconcileResult = resultRequeue
+	}
+	reconcileDuration.WithLabelValues(request.Namespace, request.Name, reconcileResult).Observe(time.Since(start).Seconds())
+}
+
+// forgetReconcile removes the durations of a deleted KieApp
+func forgetReconcile(request reconcile.Request) {
+	for _, result := range []string{resultSuccess, resultRequeue, resultError} {
+		reconcileDuration.DeleteLabelValues(request.Namespace, request.Name, result)
+	}
+}
@@ -0,0 +1,162 @@
+package kieapp
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+// Options tune how often the KieApps are reconciled
+type Options struct {
+	// Number of KieApps reconciled concurrently
+	MaxConcurrentReconciles int
+	// Delay before a failed KieApp is reconciled again, doubled on each consecutive failure
+	RateLimiterBaseDelay time.Duration
+	// Maximum delay the backoff of a failing KieApp grows to
+	RateLimiterMaxDelay time.Duration
+	// Overall number of reconciliations queued per second, across all KieApps
+	RateLimiterQPS float64
+	// Overall number of reconciliations queued in a burst, across all KieApps
+	RateLimiterBurst int
+	// Delay before checking the hostnames of the created routes
+	RouteRequeueInterval time.Duration
+	// Delay before retrying to generate the keystores
+	KeystoreRequeueInterval time.Duration
+	// Delay before checking an object the operator created
+	CreateRequeueInterval time.Duration
+	// Label selector of the KieApps handled by the operator, all KieApps are handled if empty
+	KieAppSelector string
+}
+
+// DefaultOptions returns the options used when no flag nor environment variable is set
+func DefaultOptions() Options {
+	return Options{
+		MaxConcurrentReconciles: 1,
+		RateLimiterBaseDelay:    5 * time.Millisecond,
+		RateLimiterMaxDelay:     1000 * time.Second,
+		RateLimiterQPS:          10,
+		RateLimiterBurst:        100,
+		RouteRequeueInterval:    500 * time.Millisecond,
+		KeystoreRequeueInterval: 500 * time.Millisecond,
+		CreateRequeueInterval:   200 * time.Millisecond,
+	}
+}
+
+// AddFlags registers the options on the flag set. The defaults are read from the environment variables.
+func (options *Options) AddFlags(flags *pflag.FlagSet) {
+	defaults := DefaultOptions()
+	flags.IntVar(&options.MaxConcurrentReconciles, "max-concurrent-reconciles", getEnvInt(constants.MaxConcurrentReconcilesEnv, defaults.MaxConcurrentReconciles),
+		"Number of KieApps reconciled concurrently")
+	flags.DurationVar(&options.RateLimiterBaseDelay, "reconcile-base-delay", getEnvDuration(constants.RateLimiterBaseDelayEnv, defaults.RateLimiterBaseDelay),
+		"Delay before a failed KieApp is reconciled again, doubled on each consecutive failure")
+	flags.DurationVar(&options.RateLimiterMaxDelay, "reconcile-max-delay", getEnvDuration(constants.RateLimiterMaxDelayEnv, defaults.RateLimiterMaxDelay),
+		"Maximum delay the backoff of a failing KieApp grows to")
+	flags.Float64Var(&options.RateLimiterQPS, "reconcile-qps", getEnvFloat(constants.RateLimiterQPSEnv, defaults.RateLimiterQPS),
+		"Overall number of reconciliations queued per second")
+	flags.IntVar(&options.RateLimiterBurst, "reconcile-burst", getEnvInt(constants.RateLimiterBurstEnv, defaults.RateLimiterBurst),
+		"Overall number of reconciliations queued in a burst")
+	flags.DurationVar(&options.RouteRequeueInterval, "route-requeue-interval", getEnvDuration(constants.RouteRequeueIntervalEnv, defaults.RouteRequeueInterval),
+		"Delay before checking the hostnames of the created routes")
+	flags.DurationVar(&options.KeystoreRequeueInterval, "keystore-requeue-interval", getEnvDuration(constants.KeystoreRequeueIntervalEnv, defaults.KeystoreRequeueInterval),
+		"Delay before retrying to generate the keystores")
+	flags.DurationVar(&options.CreateRequeueInterval, "create-requeue-interval", getEnvDuration(constants.CreateRequeueIntervalEnv, defaults.CreateRequeueInterval),
+		"Delay before checking an object the operator created")
+	flags.StringVar(&options.KieAppSelector, "kieapp-selector", getEnvString(constants.KieAppSelectorEnv, defaults.KieAppSelector),
+		"Label selector of the KieApps handled by the operator, e.g. to run several operators side by side")
+}
+
+// RateLimiter returns the exponential backoff of each failing KieApp, bounded by the overall rate of reconciliations
+func (options Options) RateLimiter() ratelimiter.RateLimiter {
+	options = options.withDefaults()
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(options.RateLimiterBaseDelay, options.RateLimiterMaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(options.RateLimiterQPS), options.RateLimiterBurst)},
+	)
+}
+
+// Selector returns the label selector of the KieApps handled by the operator
+func (options Options) Selector() (labels.Selector, error) {
+	if len(options.KieAppSelector) == 0 {
+		return labels.Everything(), nil
+	}
+	selector, err := labels.Parse(options.KieAppSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KieApp selector %s: %v", options.KieAppSelector, err)
+	}
+	return selector, nil
+}
+
+// withDefaults replaces the options that are not set with their default value
+func (options Options) withDefaults() Options {
+	defaults := DefaultOptions()
+	if options.MaxConcurrentReconciles <= 0 {
+		options.MaxConcurrentReconciles = defaults.MaxConcurrentReconciles
+	}
+	if options.RateLimiterBaseDelay <= 0 {
+		options.RateLimiterBaseDelay = defaults.RateLimiterBaseDelay
+	}
+	if options.RateLimiterMaxDelay <= 0 {
+		options.RateLimiterMaxDelay = defaults.RateLimiterMaxDelay
+	}
+	if options.RateLimiterQPS <= 0 {
+		options.RateLimiterQPS = defaults.RateLimiterQPS
+	}
+	if options.RateLimiterBurst <= 0 {
+		options.RateLimiterBurst = defaults.RateLimiterBurst
+	}
+	if options.RouteRequeueInterval <= 0 {
+		options.RouteRequeueInterval = defaults.RouteRequeueInterval
+	}
+	if options.KeystoreRequeueInterval <= 0 {
+		options.KeystoreRequeueInterval = defaults.KeystoreRequeueInterval
+	}
+	if options.CreateRequeueInterval <= 0 {
+		options.CreateRequeueInterval = defaults.CreateRequeueInterval
+	}
+	return options
+}
+
+func getEnvString(name string, defaultValue string) string {
+	if val, exists := os.LookupEnv(name); exists {
+		return val
+	}
+	return defaultValue
+}
+
+func getEnvInt(name string, defaultValue int) int {
+	if val, exists := os.LookupEnv(name); exists {
+		if value, err := strconv.Atoi(val); err == nil {
+			return value
+		}
+		log.Warnf("Ignoring invalid value %s of environment variable %s", val, name)
+	}
+	return defaultValue
+}
+
+func getEnvFloat(name string, defaultValue float64) float64 {
+	if val, exists := os.LookupEnv(name); exists {
+		if value, err := strconv.ParseFloat(val, 64); err == nil {
+			return value
+		}
+		log.Warnf("Ignoring invalid value %s of environment variable %s", val, name)
+	}
+	return defaultValue
+}
+
+func getEnvDuration(name string, defaultValue time.Duration) time.Duration {
+	if val, exists := os.LookupEnv(name); exists {
+		if value, err := time.ParseDuration(val); err == nil {
+			return value
+		}
+		log.Warnf("Ignoring invalid value %s of environment variable %s", val, name)
+	}
+	return defaultValue
+}
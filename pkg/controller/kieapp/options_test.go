@@ -0,0 +1,117 @@
+package kieapp
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestOptionsFlags(t *testing.T) {
+	os.Setenv(constants.MaxConcurrentReconcilesEnv, "4")
+	os.Setenv(constants.RouteRequeueIntervalEnv, "2s")
+	os.Setenv(constants.RateLimiterQPSEnv, "invalid")
+	defer os.Unsetenv(constants.MaxConcurrentReconcilesEnv)
+	defer os.Unsetenv(constants.RouteRequeueIntervalEnv)
+	defer os.Unsetenv(constants.RateLimiterQPSEnv)
+
+	options := Options{}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options.AddFlags(flags)
+	assert.Equal(t, 4, options.MaxConcurrentReconciles, "Default should be read from the environment")
+	assert.Equal(t, 2*time.Second, options.RouteRequeueInterval, "Default should be read from the environment")
+	assert.Equal(t, DefaultOptions().RateLimiterQPS, options.RateLimiterQPS, "Invalid environment values should be ignored")
+
+	assert.Nil(t, flags.Parse([]string{"--max-concurrent-reconciles=8", "--reconcile-max-delay=5m"}))
+	assert.Equal(t, 8, options.MaxConcurrentReconciles, "Flags should take precedence over the environment")
+	assert.Equal(t, 5*time.Minute, options.RateLimiterMaxDelay)
+	assert.Equal(t, DefaultOptions().KeystoreRequeueInterval, options.KeystoreRequeueInterval)
+}
+
+func TestOptionsRateLimiter(t *testing.T) {
+	options := Options{RateLimiterBaseDelay: time.Second, RateLimiterMaxDelay: 3 * time.Second}
+	limiter := options.RateLimiter()
+	assert.Equal(t, time.Second, limiter.When("cr"))
+	assert.Equal(t, 2*time.Second, limiter.When("cr"))
+	assert.Equal(t, 3*time.Second, limiter.When("cr"), "The backoff should not exceed the max delay")
+	assert.Equal(t, time.Second, limiter.When("other"), "Each KieApp should have its own backoff")
+	limiter.Forget("cr")
+	assert.Equal(t, time.Second, limiter.When("cr"))
+}
+
+func TestRequeueIntervals(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "requeue")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{Environment: api.RhpamTrial}
+	service := test.MockService()
+	assert.Nil(t, service.Create(context.TODO(), cr))
+	reconciler := Reconciler{Service: service, Options: Options{RouteRequeueInterval: 3 * time.Second}}
+
+	result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{Requeue: true, RequeueAfter: 3 * time.Second}, result, "Routes should be requeued after the configured interval")
+	assert.Equal(t, uint64(1), getReconcileCount(t, crNamespacedName.Namespace, crNamespacedName.Name, resultRequeue))
+
+	assert.Nil(t, service.Delete(context.TODO(), cr))
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), getReconcileCount(t, crNamespacedName.Namespace, crNamespacedName.Name, resultRequeue), "Metrics of deleted KieApps should be removed")
+}
+
+func TestOptionsSelector(t *testing.T) {
+	selector, err := Options{}.Selector()
+	assert.Nil(t, err)
+	assert.True(t, selector.Empty(), "All KieApps should be handled by default")
+
+	_, err = Options{KieAppSelector: "operator in (,"}.Selector()
+	assert.NotNil(t, err)
+
+	crNamespacedName := getNamespacedName("testns", "unselected")
+	cr := getInstance(crNamespacedName)
+	cr.Labels = map[string]string{"operator": "v1"}
+	cr.Spec = api.KieAppSpec{Environment: api.RhpamTrial}
+	service := test.MockService()
+	assert.Nil(t, service.Create(context.TODO(), cr))
+	reconciler := Reconciler{Service: service, Options: Options{KieAppSelector: "operator=v2"}}
+
+	result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Empty(t, cr.GetFinalizers(), "KieApps not matching the selector should be left untouched")
+	assert.Empty(t, cr.Status.Conditions)
+
+	cr.Labels["operator"] = "v2"
+	assert.Nil(t, service.Update(context.TODO(), cr))
+	result, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.True(t, result.Requeue, "KieApps matching the selector should be reconciled")
+}
+
+func getReconcileCount(t *testing.T, namespace, name, result string) uint64 {
+	families, err := metrics.Registry.Gather()
+	assert.Nil(t, err)
+	for _, family := range families {
+		if family.GetName() != "kieapp_reconcile_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["namespace"] == namespace && labels["name"] == name && labels["result"] == result {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
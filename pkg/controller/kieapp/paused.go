@@ -0,0 +1,101 @@
+package kieapp
+
+import (
+	"context"
+
+	"github.com/RHsyseng/operator-utils/pkg/utils/kubernetes"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/status"
+	oimagev1 "github.com/openshift/api/image/v1"
+	imagev1 "github.com/openshift/client-go/image/clientset/versioned/typed/image/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcilePaused records in the status how the deployed resources differ from the requested ones, without writing any of them.
+// The applied version is rendered, upgrades and rollbacks are only applied once resumed.
+func (reconciler *Reconciler) reconcilePaused(instance *api.KieApp, request reconcile.Request) (reconcile.Result, error) {
+	if status.SetPaused(instance) {
+		reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventPaused, "Reconciliation paused, no resource is written until it is resumed")
+	}
+	readOnly := &Reconciler{
+		Service:    &readOnlyService{reconciler.Service},
+		OcpVersion: reconciler.OcpVersion,
+		Options:    reconciler.Options,
+	}
+	requested, err := readOnly.RenderResources(instance)
+	if err != nil {
+		reconciler.setFailedStatus(instance, api.ConfigurationErrorReason, err)
+		return reconcile.Result{}, err
+	}
+	drift, err := readOnly.DriftResources(instance, requested)
+	if err != nil {
+		reconciler.setFailedStatus(instance, api.UnknownReason, err)
+		return reconcile.Result{}, err
+	}
+	status.SetDrift(instance, drift)
+
+	cachedInstance := &api.KieApp{}
+	err = reconciler.Service.GetCached(context.TODO(), request.NamespacedName, cachedInstance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		reconciler.setFailedStatus(instance, api.UnknownReason, err)
+		return reconcile.Result{}, err
+	}
+	return reconciler.updateStatus(instance, cachedInstance, false)
+}
+
+// readOnlyService is a PlatformService that reads from the cluster but silently drops all writes, except to the status
+type readOnlyService struct {
+	kubernetes.PlatformService
+}
+
+var _ kubernetes.PlatformService = &readOnlyService{}
+
+func (service *readOnlyService) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	return nil
+}
+
+func (service *readOnlyService) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	return nil
+}
+
+func (service *readOnlyService) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	return nil
+}
+
+func (service *readOnlyService) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return nil
+}
+
+func (service *readOnlyService) DeleteAllOf(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
+	return nil
+}
+
+func (service *readOnlyService) ImageStreamTags(namespace string) imagev1.ImageStreamTagInterface {
+	return &readOnlyImageStreamTags{service.PlatformService.ImageStreamTags(namespace)}
+}
+
+// readOnlyImageStreamTags reads the ImageStreamTags from the cluster but silently drops all writes
+type readOnlyImageStreamTags struct {
+	imagev1.ImageStreamTagInterface
+}
+
+func (tags *readOnlyImageStreamTags) Create(ctx context.Context, imageStreamTag *oimagev1.ImageStreamTag, opts metav1.CreateOptions) (*oimagev1.ImageStreamTag, error) {
+	return imageStreamTag, nil
+}
+
+func (tags *readOnlyImageStreamTags) Update(ctx context.Context, imageStreamTag *oimagev1.ImageStreamTag, opts metav1.UpdateOptions) (*oimagev1.ImageStreamTag, error) {
+	return imageStreamTag, nil
+}
+
+func (tags *readOnlyImageStreamTags) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return nil
+}
@@ -0,0 +1,82 @@
+package kieapp
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcilePaused(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "paused")
+	cr := getInstance(crNamespacedName)
+	cr.Annotations = map[string]string{constants.PausedAnnotation: "true"}
+	cr.Spec = api.KieAppSpec{Environment: api.RhpamTrial}
+	service := test.MockService()
+	assert.Nil(t, service.Create(context.TODO(), cr))
+	reconciler := Reconciler{Service: service}
+
+	result, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result, "A paused KieApp should not be requeued")
+
+	routes := &routev1.RouteList{}
+	assert.Nil(t, service.List(context.TODO(), routes))
+	assert.Empty(t, routes.Items, "No route should be created while paused")
+	dcs := &oappsv1.DeploymentConfigList{}
+	assert.Nil(t, service.List(context.TODO(), dcs))
+	assert.Empty(t, dcs.Items, "No DeploymentConfig should be created while paused")
+
+	assert.Nil(t, service.Get(context.TODO(), crNamespacedName, cr))
+	assert.Equal(t, api.PausedConditionType, cr.Status.Phase)
+	assert.Empty(t, cr.GetFinalizers(), "The KieApp should not be updated while paused")
+	assert.Contains(t, cr.Status.Drift, api.ResourceDrift{Kind: "DeploymentConfig", Name: "paused-rhpamcentr", Action: api.DriftCreate})
+	assert.Contains(t, cr.Status.Drift, api.ResourceDrift{Kind: "Route", Name: "paused-rhpamcentr", Action: api.DriftCreate})
+
+	cr.Annotations[constants.PausedAnnotation] = "false"
+	assert.Nil(t, service.Update(context.TODO(), cr))
+	result, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.True(t, result.Requeue, "Routes should be created once resumed")
+	assert.Nil(t, service.List(context.TODO(), routes))
+	assert.NotEmpty(t, routes.Items)
+}
+
+func TestReconcilePausedUpgrade(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "paused")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+		Paused:      true,
+		Upgrades:    api.KieAppUpgrades{Enabled: true, Minor: true},
+	}
+	cr.Status.Applied = api.KieAppSpec{Environment: api.RhpamTrial, Version: constants.PriorVersion1}
+	service := test.MockService()
+	assert.Nil(t, service.Create(context.TODO(), cr))
+	reconciler := Reconciler{Service: service}
+
+	_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, api.PausedConditionType, cr.Status.Phase)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version, "The version should not be upgraded while paused")
+	assert.Equal(t, []string{constants.CurrentVersion}, cr.Status.UpgradePath)
+	assert.Empty(t, cr.Status.UpgradeHistory)
+
+	cr.Spec.Paused = false
+	assert.Nil(t, service.Update(context.TODO(), cr))
+	for i := 0; i < 2; i++ {
+		_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		assert.Nil(t, err)
+	}
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version, "The version should be upgraded once resumed")
+	assert.Len(t, cr.Status.UpgradeHistory, 1)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.UpgradeHistory[0].FromVersion)
+}
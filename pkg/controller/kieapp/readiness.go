@@ -0,0 +1,185 @@
+package kieapp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/RHsyseng/operator-utils/pkg/resource"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// componentReadiness aggregates the rollout state of the workloads belonging to each component
+type componentReadiness struct {
+	types        []api.ConditionType
+	components   map[api.ConditionType]*api.Condition
+	dcs          map[string]*oappsv1.DeploymentConfig
+	deployments  map[string]*appsv1.Deployment
+	statefulSets map[string]*appsv1.StatefulSet
+}
+
+// getComponentConditions returns a readiness condition for each component requested in the environment,
+// computed from the rollout state of its DeploymentConfigs, Deployments and StatefulSets
+func getComponentConditions(cr *api.KieApp, env api.Environment, deployed map[reflect.Type][]resource.KubernetesResource) []api.Condition {
+	readiness := &componentReadiness{
+		components:   map[api.ConditionType]*api.Condition{},
+		dcs:          map[string]*oappsv1.DeploymentConfig{},
+		deployments:  map[string]*appsv1.Deployment{},
+		statefulSets: map[string]*appsv1.StatefulSet{},
+	}
+	for _, res := range deployed[reflect.TypeOf(oappsv1.DeploymentConfig{})] {
+		dc := res.(*oappsv1.DeploymentConfig)
+		readiness.dcs[dc.Name] = dc
+	}
+	for _, res := range deployed[reflect.TypeOf(appsv1.Deployment{})] {
+		deployment := res.(*appsv1.Deployment)
+		readiness.deployments[deployment.Name] = deployment
+	}
+	for _, res := range deployed[reflect.TypeOf(appsv1.StatefulSet{})] {
+		statefulSet := res.(*appsv1.StatefulSet)
+		readiness.statefulSets[statefulSet.Name] = statefulSet
+	}
+
+	readiness.add(api.ConsoleReadyConditionType, env.Console)
+	for i := range env.Servers {
+		serverSet, _ := defaults.GetServerSet(cr, i)
+		readiness.add(getKieServerReadyConditionType(serverSet.Name), env.Servers[i])
+	}
+	readiness.add(api.SmartRouterReadyConditionType, env.SmartRouter)
+	readiness.add(api.ProcessMigrationReadyConditionType, env.ProcessMigration)
+	for _, db := range env.Databases {
+		readiness.add(api.DatabaseReadyConditionType, db)
+	}
+	for _, other := range env.Others {
+		readiness.add("", other)
+	}
+
+	var conditions []api.Condition
+	for _, conditionType := range readiness.types {
+		conditions = append(conditions, *readiness.components[conditionType])
+	}
+	return conditions
+}
+
+func (readiness *componentReadiness) add(conditionType api.ConditionType, object api.CustomObject) {
+	if object.Omit {
+		return
+	}
+	for _, dc := range object.DeploymentConfigs {
+		reason, message := getDeploymentConfigReadiness(dc.Name, readiness.dcs[dc.Name])
+		readiness.update(getWorkloadConditionType(conditionType, dc.Name), reason, message)
+	}
+	for _, deployment := range object.Deployments {
+		reason, message := getDeploymentReadiness(deployment.Name, readiness.deployments[deployment.Name])
+		readiness.update(getWorkloadConditionType(conditionType, deployment.Name), reason, message)
+	}
+	for _, statefulSet := range object.StatefulSets {
+		reason, message := getStatefulSetReadiness(statefulSet.Name, readiness.statefulSets[statefulSet.Name])
+		readiness.update(getWorkloadConditionType(conditionType, statefulSet.Name), reason, message)
+	}
+}
+
+func (readiness *componentReadiness) update(conditionType api.ConditionType, reason api.ReasonType, message string) {
+	if conditionType == "" {
+		return
+	}
+	condition, found := readiness.components[conditionType]
+	if !found {
+		condition = &api.Condition{Type: conditionType, Status: corev1.ConditionTrue}
+		readiness.components[conditionType] = condition
+		readiness.types = append(readiness.types, conditionType)
+	}
+	if reason == "" {
+		return
+	}
+	condition.Status = corev1.ConditionFalse
+	if condition.Reason != api.RolloutFailedReason {
+		condition.Reason = reason
+	}
+	if condition.Message == "" {
+		condition.Message = message
+	} else {
+		condition.Message = strings.Join([]string{condition.Message, message}, "; ")
+	}
+}
+
+// getWorkloadConditionType assigns the databases and brokers deployed along with other components to their own condition
+func getWorkloadConditionType(conditionType api.ConditionType, name string) api.ConditionType {
+	if strings.HasSuffix(name, "-"+string(api.DatabaseMySQL)) || strings.HasSuffix(name, "-"+string(api.DatabasePostgreSQL)) {
+		return api.DatabaseReadyConditionType
+	}
+	if strings.HasSuffix(name, "-amq") {
+		return api.BrokerReadyConditionType
+	}
+	return conditionType
+}
+
+func getKieServerReadyConditionType(serverSetName string) api.ConditionType {
+	var name string
+	for _, part := range strings.FieldsFunc(serverSetName, func(r rune) bool { return r == '-' || r == '.' }) {
+		name += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return api.ConditionType(fmt.Sprintf(api.KieServerReadyConditionFormat, name))
+}
+
+func getDeploymentConfigReadiness(name string, dc *oappsv1.DeploymentConfig) (api.ReasonType, string) {
+	if dc == nil {
+		return api.NotReadyReason, fmt.Sprintf("DeploymentConfig %s not found", name)
+	}
+	for _, condition := range dc.Status.Conditions {
+		if condition.Type == oappsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse {
+			return api.RolloutFailedReason, fmt.Sprintf("DeploymentConfig %s rollout failed: %s", name, condition.Message)
+		}
+	}
+	if dc.Status.ObservedGeneration < dc.Generation {
+		return api.NotReadyReason, fmt.Sprintf("DeploymentConfig %s rollout in progress", name)
+	}
+	if dc.Status.ReadyReplicas < dc.Spec.Replicas {
+		return api.NotReadyReason, fmt.Sprintf("DeploymentConfig %s has %d/%d replicas ready", name, dc.Status.ReadyReplicas, dc.Spec.Replicas)
+	}
+	return "", ""
+}
+
+func getDeploymentReadiness(name string, deployment *appsv1.Deployment) (api.ReasonType, string) {
+	if deployment == nil {
+		return api.NotReadyReason, fmt.Sprintf("Deployment %s not found", name)
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse {
+			return api.RolloutFailedReason, fmt.Sprintf("Deployment %s rollout failed: %s", name, condition.Message)
+		}
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation || deployment.Status.UpdatedReplicas < replicas {
+		return api.NotReadyReason, fmt.Sprintf("Deployment %s rollout in progress", name)
+	}
+	if deployment.Status.ReadyReplicas < replicas {
+		return api.NotReadyReason, fmt.Sprintf("Deployment %s has %d/%d replicas ready", name, deployment.Status.ReadyReplicas, replicas)
+	}
+	return "", ""
+}
+
+func getStatefulSetReadiness(name string, statefulSet *appsv1.StatefulSet) (api.ReasonType, string) {
+	if statefulSet == nil {
+		return api.NotReadyReason, fmt.Sprintf("StatefulSet %s not found", name)
+	}
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation ||
+		(statefulSet.Status.UpdateRevision != "" && statefulSet.Status.CurrentRevision != statefulSet.Status.UpdateRevision) {
+		return api.NotReadyReason, fmt.Sprintf("StatefulSet %s rollout in progress", name)
+	}
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return api.NotReadyReason, fmt.Sprintf("StatefulSet %s has %d/%d replicas ready", name, statefulSet.Status.ReadyReplicas, replicas)
+	}
+	return "", ""
+}
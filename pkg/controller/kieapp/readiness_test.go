@@ -0,0 +1,127 @@
+package kieapp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RHsyseng/operator-utils/pkg/resource"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComponentConditions(t *testing.T) {
+	cr := &api.KieApp{
+		Status: api.KieAppStatus{
+			Applied: api.KieAppSpec{
+				Objects: api.KieAppObjects{
+					Servers: []api.KieServerSet{{Name: "test-kieserver", Deployments: defaults.Pint(1)}},
+				},
+			},
+		},
+	}
+	env := api.Environment{
+		Console: api.CustomObject{DeploymentConfigs: []oappsv1.DeploymentConfig{getDC("test-rhpamcentr")}},
+		Servers: []api.CustomObject{{DeploymentConfigs: []oappsv1.DeploymentConfig{
+			getDC("test-kieserver"),
+			getDC("test-kieserver-postgresql"),
+			getDC("test-kieserver-amq"),
+		}}},
+		SmartRouter: api.CustomObject{Omit: true, DeploymentConfigs: []oappsv1.DeploymentConfig{getDC("test-smartrouter")}},
+		Others: []api.CustomObject{{StatefulSets: []appsv1.StatefulSet{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-datagrid"}},
+		}}},
+	}
+
+	console := getDC("test-rhpamcentr")
+	console.Status.ReadyReplicas = 1
+	server := getDC("test-kieserver")
+	server.Status.Conditions = []oappsv1.DeploymentCondition{{
+		Type:    oappsv1.DeploymentProgressing,
+		Status:  corev1.ConditionFalse,
+		Message: "replication controller timed out",
+	}}
+	db := getDC("test-kieserver-postgresql")
+	deployed := map[reflect.Type][]resource.KubernetesResource{
+		reflect.TypeOf(oappsv1.DeploymentConfig{}): {&console, &server, &db},
+	}
+
+	conditions := getComponentConditions(cr, env, deployed)
+	assert.Len(t, conditions, 4, "Expect console, server, database and broker conditions")
+
+	assert.Equal(t, api.ConsoleReadyConditionType, conditions[0].Type)
+	assert.Equal(t, corev1.ConditionTrue, conditions[0].Status)
+
+	assert.Equal(t, api.ConditionType("KieServerTestKieserverReady"), conditions[1].Type)
+	assert.Equal(t, corev1.ConditionFalse, conditions[1].Status)
+	assert.Equal(t, api.RolloutFailedReason, conditions[1].Reason)
+	assert.Equal(t, "DeploymentConfig test-kieserver rollout failed: replication controller timed out", conditions[1].Message)
+
+	assert.Equal(t, api.DatabaseReadyConditionType, conditions[2].Type)
+	assert.Equal(t, corev1.ConditionFalse, conditions[2].Status)
+	assert.Equal(t, api.NotReadyReason, conditions[2].Reason)
+	assert.Equal(t, "DeploymentConfig test-kieserver-postgresql has 0/1 replicas ready", conditions[2].Message)
+
+	assert.Equal(t, api.BrokerReadyConditionType, conditions[3].Type)
+	assert.Equal(t, corev1.ConditionFalse, conditions[3].Status)
+	assert.Equal(t, "DeploymentConfig test-kieserver-amq not found", conditions[3].Message)
+}
+
+func TestStatefulSetReadiness(t *testing.T) {
+	replicas := int32(2)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-amq"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status: appsv1.StatefulSetStatus{
+			ReadyReplicas:   2,
+			CurrentRevision: "test-amq-1",
+			UpdateRevision:  "test-amq-2",
+		},
+	}
+	reason, message := getStatefulSetReadiness(statefulSet.Name, statefulSet)
+	assert.Equal(t, api.NotReadyReason, reason)
+	assert.Equal(t, "StatefulSet test-amq rollout in progress", message)
+
+	statefulSet.Status.CurrentRevision = statefulSet.Status.UpdateRevision
+	reason, message = getStatefulSetReadiness(statefulSet.Name, statefulSet)
+	assert.Empty(t, reason)
+	assert.Empty(t, message)
+}
+
+func getDC(name string) oappsv1.DeploymentConfig {
+	return oappsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       oappsv1.DeploymentConfigSpec{Replicas: 1},
+	}
+}
+
+func TestDeploymentReadiness(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rhpamcentr"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{UpdatedReplicas: 2, ReadyReplicas: 1},
+	}
+	reason, message := getDeploymentReadiness(deployment.Name, deployment)
+	assert.Equal(t, api.NotReadyReason, reason)
+	assert.Equal(t, "Deployment test-rhpamcentr has 1/2 replicas ready", message)
+
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:    appsv1.DeploymentProgressing,
+		Status:  corev1.ConditionFalse,
+		Message: "ReplicaSet has timed out progressing",
+	}}
+	reason, message = getDeploymentReadiness(deployment.Name, deployment)
+	assert.Equal(t, api.RolloutFailedReason, reason)
+	assert.Equal(t, "Deployment test-rhpamcentr rollout failed: ReplicaSet has timed out progressing", message)
+
+	deployment.Status.Conditions = nil
+	deployment.Status.ReadyReplicas = 2
+	reason, message = getDeploymentReadiness(deployment.Name, deployment)
+	assert.Empty(t, reason)
+	assert.Empty(t, message)
+}
@@ -0,0 +1,58 @@
+package kieapp
+
+import (
+	"github.com/RHsyseng/operator-utils/pkg/resource"
+	"github.com/RHsyseng/operator-utils/pkg/resource/compare"
+	"github.com/RHsyseng/operator-utils/pkg/resource/read"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	routev1 "github.com/openshift/api/route/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// RenderResources returns the resources the operator would create for the KieApp, without applying them.
+// Route hostnames are read from the routes already deployed for the KieApp, if any.
+func (reconciler *Reconciler) RenderResources(cr *api.KieApp) ([]resource.KubernetesResource, error) {
+	env, err := defaults.GetEnvironment(cr, reconciler.Service)
+	if err != nil {
+		return nil, err
+	}
+	var deployedRoutes []resource.KubernetesResource
+	if cr.UID != "" && !defaults.IsKubernetes(cr) {
+		reader := read.New(reconciler.Service).WithNamespace(cr.Namespace).WithOwnerObject(cr)
+		deployedRoutes, err = reader.List(&routev1.RouteList{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	env, err = reconciler.setEnvironmentProperties(cr, env, deployedRoutes)
+	if err != nil {
+		return nil, err
+	}
+	resources := reconciler.getKubernetesResources(cr, env)
+	for index := range resources {
+		if isNamespaced(resources[index]) {
+			resources[index].SetNamespace(cr.Namespace)
+		}
+		if resources[index].GetObjectKind().GroupVersionKind().Kind == "" {
+			gvk, err := apiutil.GVKForObject(resources[index], reconciler.Service.GetScheme())
+			if err != nil {
+				return nil, err
+			}
+			resources[index].GetObjectKind().SetGroupVersionKind(gvk)
+		}
+	}
+	return resources, nil
+}
+
+// DriftResources lists the resources deployed for the KieApp that differ from the given requested resources,
+// along with the paths of the fields that differ
+func (reconciler *Reconciler) DriftResources(cr *api.KieApp, requested []resource.KubernetesResource) ([]api.ResourceDrift, error) {
+	deployed, err := reconciler.getDeployedResources(cr)
+	if err != nil {
+		return nil, err
+	}
+	setAutoscaledReplicas(requested, deployed)
+	comparator := getComparator()
+	return getDrift(deployed, comparator.Compare(deployed, compare.NewMapBuilder().Add(requested...).ResourceMap())), nil
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"context"
+
+	"github.com/RHsyseng/operator-utils/pkg/utils/kubernetes"
+	"github.com/kiegroup/kie-cloud-operator/pkg/apis"
+	oimagev1 "github.com/openshift/api/image/v1"
+	imagev1 "github.com/openshift/client-go/image/clientset/versioned/typed/image/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientv1 "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// NewScheme returns a Scheme with the Kubernetes, OpenShift and KieApp types the operator generates
+func NewScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return scheme, nil
+}
+
+// offlineService is a PlatformService holding the objects in memory, so that the resources of a KieApp can be
+// rendered from the embedded configs without a cluster
+type offlineService struct {
+	clientv1.Client
+	scheme *runtime.Scheme
+}
+
+var _ kubernetes.PlatformService = &offlineService{}
+
+// NewOfflineService returns an empty in-memory PlatformService. Its ImageStreamTags are all assumed to exist, as
+// installed in the openshift namespace, and are never written.
+func NewOfflineService() (kubernetes.PlatformService, error) {
+	scheme, err := NewScheme()
+	if err != nil {
+		return nil, err
+	}
+	return &offlineService{
+		Client: fake.NewFakeClientWithScheme(scheme),
+		scheme: scheme,
+	}, nil
+}
+
+func (service *offlineService) GetCached(ctx context.Context, key clientv1.ObjectKey, obj runtime.Object) error {
+	return service.Client.Get(ctx, key, obj)
+}
+
+func (service *offlineService) ImageStreamTags(namespace string) imagev1.ImageStreamTagInterface {
+	return &offlineImageStreamTags{namespace: namespace}
+}
+
+func (service *offlineService) GetScheme() *runtime.Scheme {
+	return service.scheme
+}
+
+// IsMockService returns true so that the embedded configs are used instead of the ConfigMaps of the operator
+func (service *offlineService) IsMockService() bool {
+	return true
+}
+
+// offlineImageStreamTags finds any ImageStreamTag and drops all writes
+type offlineImageStreamTags struct {
+	imagev1.ImageStreamTagInterface
+	namespace string
+}
+
+func (tags *offlineImageStreamTags) Get(ctx context.Context, name string, opts metav1.GetOptions) (*oimagev1.ImageStreamTag, error) {
+	return &oimagev1.ImageStreamTag{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: tags.namespace}}, nil
+}
+
+func (tags *offlineImageStreamTags) List(ctx context.Context, opts metav1.ListOptions) (*oimagev1.ImageStreamTagList, error) {
+	return &oimagev1.ImageStreamTagList{}, nil
+}
+
+func (tags *offlineImageStreamTags) Create(ctx context.Context, imageStreamTag *oimagev1.ImageStreamTag, opts metav1.CreateOptions) (*oimagev1.ImageStreamTag, error) {
+	return imageStreamTag, nil
+}
+
+func (tags *offlineImageStreamTags) Update(ctx context.Context, imageStreamTag *oimagev1.ImageStreamTag, opts metav1.UpdateOptions) (*oimagev1.ImageStreamTag, error) {
+	return imageStreamTag, nil
+}
+
+func (tags *offlineImageStreamTags) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return nil
+}
@@ -0,0 +1,35 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOfflineRender(t *testing.T) {
+	service, err := NewOfflineService()
+	assert.Nil(t, err)
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "cr", Namespace: "testns"},
+		Spec:       api.KieAppSpec{Environment: api.RhpamTrial},
+	}
+	reconciler := kieapp.Reconciler{Service: service}
+	resources, err := reconciler.RenderResources(cr)
+	assert.Nil(t, err)
+
+	kinds := map[string]int{}
+	for _, res := range resources {
+		kinds[res.GetObjectKind().GroupVersionKind().Kind]++
+	}
+	assert.Equal(t, 2, kinds["DeploymentConfig"])
+	assert.Equal(t, 4, kinds["Route"], "Secure and insecure routes are expected for the console and the server")
+
+	dcs := &oappsv1.DeploymentConfigList{}
+	assert.Nil(t, service.List(context.TODO(), dcs))
+	assert.Empty(t, dcs.Items, "Rendering should not create any object")
+}
@@ -0,0 +1,38 @@
+package kieapp
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderResources(t *testing.T) {
+	cr := &api.KieApp{}
+	cr.Name = "cr"
+	cr.Namespace = "testns"
+	cr.Spec = api.KieAppSpec{Environment: api.RhpamTrial}
+	reconciler := Reconciler{Service: test.MockService()}
+	resources, err := reconciler.RenderResources(cr)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, resources)
+
+	kinds := map[string]int{}
+	for _, res := range resources {
+		assert.Equal(t, "testns", res.GetNamespace())
+		assert.NotEmpty(t, res.GetObjectKind().GroupVersionKind().Kind)
+		kinds[res.GetObjectKind().GroupVersionKind().Kind]++
+	}
+	assert.Equal(t, 2, kinds["DeploymentConfig"])
+	assert.Equal(t, 3, kinds["Secret"], "Keystores should be generated for the console and the server, along with the credentials")
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version)
+	assert.Equal(t, "http://cr", cr.Status.ConsoleHost)
+
+	dcs := &oappsv1.DeploymentConfigList{}
+	assert.Nil(t, reconciler.Service.List(context.TODO(), dcs))
+	assert.Empty(t, dcs.Items, "Rendering should not create any object")
+}
@@ -0,0 +1,106 @@
+package kieapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/shared"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// setComponentTLS generates the keystore of a component, unless a keystore Secret is provided, and sets the
+// certificates of its routes. The keystore holds the certificate of the route TLS Secret if any, or a self-signed one.
+func (reconciler *Reconciler) setComponentTLS(cr *api.KieApp, object *api.CustomObject, secretName, keystoreCN, keystoreSecret string, route *api.RouteObject) error {
+	tlsSecret, err := reconciler.getRouteTLSSecret(cr, route)
+	if err != nil {
+		return err
+	}
+	if keystoreSecret != "" {
+		// the certificate served by the pods is unknown, reencrypt routes rely on the router defaults
+		setRouteCertificates(object, tlsSecret, nil)
+		return nil
+	}
+	secret, err := reconciler.generateKeystoreSecret(secretName, keystoreCN, tlsSecret, cr)
+	if err != nil {
+		return err
+	}
+	object.Secrets = append(object.Secrets, secret)
+
+	destinationCA, err := getDestinationCACertificate(tlsSecret, secret, []byte(cr.Status.Applied.CommonConfig.KeyStorePassword))
+	if err != nil {
+		return err
+	}
+	setRouteCertificates(object, tlsSecret, destinationCA)
+	return nil
+}
+
+// getRouteTLSSecret returns the Secret holding the certificate of the routes of a component, if any
+func (reconciler *Reconciler) getRouteTLSSecret(cr *api.KieApp, route *api.RouteObject) (*corev1.Secret, error) {
+	if route == nil || route.TLSSecret == "" {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	err := reconciler.Service.Get(context.TODO(), types.NamespacedName{Name: route.TLSSecret, Namespace: cr.Namespace}, secret)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return nil, fmt.Errorf("secret %s must hold the %s and %s keys", route.TLSSecret, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	return secret, nil
+}
+
+// getDestinationCACertificate returns the PEM encoded certificate the router trusts when reencrypting the requests
+// to the pods, the CA of the route TLS Secret or the certificate of the keystore
+func getDestinationCACertificate(tlsSecret *corev1.Secret, keystoreSecret corev1.Secret, keyStorePassword []byte) ([]byte, error) {
+	if tlsSecret != nil {
+		if len(tlsSecret.Data[constants.TLSCACertKey]) > 0 {
+			return tlsSecret.Data[constants.TLSCACertKey], nil
+		}
+		return tlsSecret.Data[corev1.TLSCertKey], nil
+	}
+	certs, err := shared.GetKeystoreCertificates(keystoreSecret.Data[constants.KeystoreName], keyStorePassword)
+	if err != nil {
+		return nil, err
+	}
+	var destinationCA []byte
+	for _, cert := range certs {
+		destinationCA = append(destinationCA, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return destinationCA, nil
+}
+
+// setRouteCertificates sets the certificate of the TLS Secret, if any, on the edge and reencrypt routes of the object,
+// and the certificate trusted by the reencrypt routes
+func setRouteCertificates(object *api.CustomObject, tlsSecret *corev1.Secret, destinationCA []byte) {
+	for index := range object.Routes {
+		tls := object.Routes[index].Spec.TLS
+		if tls == nil || tls.Termination == routev1.TLSTerminationPassthrough {
+			continue
+		}
+		if tlsSecret != nil {
+			tls.Certificate = string(tlsSecret.Data[corev1.TLSCertKey])
+			tls.Key = string(tlsSecret.Data[corev1.TLSPrivateKeyKey])
+			tls.CACertificate = string(tlsSecret.Data[constants.TLSCACertKey])
+		}
+		if tls.Termination == routev1.TLSTerminationReencrypt && len(destinationCA) > 0 {
+			tls.DestinationCACertificate = string(destinationCA)
+		}
+	}
+}
+
+// isKeyStoreOfCertificate returns whether the keystore of the Secret holds the first certificate of the PEM encoded chain
+func isKeyStoreOfCertificate(secret corev1.Secret, certPEM []byte, keyStorePassword []byte) bool {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || secret.Data[constants.KeystoreName] == nil {
+		return false
+	}
+	certs, err := shared.GetKeystoreCertificates(secret.Data[constants.KeystoreName], keyStorePassword)
+	return err == nil && len(certs) > 0 && bytes.Equal(certs[0].Raw, block.Bytes)
+}
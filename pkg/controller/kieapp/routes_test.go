@@ -0,0 +1,132 @@
+package kieapp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/shared"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRouteTLSSecret(t *testing.T) {
+	scheme, err := api.SchemeBuilder.Build()
+	assert.Nil(t, err, "Failed to get scheme")
+	mockService := test.MockService()
+	mockService.GetSchemeFunc = func() *runtime.Scheme {
+		return scheme
+	}
+	reconciler := Reconciler{
+		Service: mockService,
+	}
+	tlsSecret := getTestTLSSecret(t, "corporate-tls", "console.example.com")
+	err = reconciler.Service.Create(context.TODO(), &tlsSecret)
+	assert.Nil(t, err)
+
+	cr := &api.KieApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: api.KieAppSpec{
+			Environment: api.RhpamTrial,
+			Auth: &api.KieAppAuthObject{
+				SSO: &api.SSOAuthConfig{
+					URL:   "https://sso.example.com:8080",
+					Realm: "rhpam-test",
+				},
+			},
+			Objects: api.KieAppObjects{
+				Console: api.ConsoleObject{
+					KieAppObject: api.KieAppObject{Route: &api.RouteObject{
+						Host:        "console.example.com",
+						Termination: routev1.TLSTerminationReencrypt,
+						TLSSecret:   tlsSecret.Name,
+					}},
+					SSOClient: &api.SSOAuthClient{Name: "test-rhpamcentr-client", Secret: "supersecret"},
+				},
+			},
+		},
+	}
+	env, err := defaults.GetEnvironment(cr, mockService)
+	assert.Nil(t, err, "Error getting a new environment")
+	env, err = reconciler.setEnvironmentProperties(cr, env, getRequestedRoutes(env, cr))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "https://console.example.com", cr.Status.ConsoleHost)
+	assert.Contains(t, env.Console.DeploymentConfigs[0].Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "HOSTNAME_HTTPS", Value: "console.example.com"})
+	assert.Len(t, env.Console.Secrets, 1)
+	certs, err := shared.GetKeystoreCertificates(env.Console.Secrets[0].Data[constants.KeystoreName], []byte(cr.Status.Applied.CommonConfig.KeyStorePassword))
+	assert.Nil(t, err)
+	assert.Equal(t, "console.example.com", certs[0].Subject.CommonName, "The keystore should hold the certificate of the TLS Secret")
+	for _, route := range env.Console.Routes {
+		if route.Spec.TLS == nil {
+			continue
+		}
+		assert.Equal(t, string(tlsSecret.Data[corev1.TLSCertKey]), route.Spec.TLS.Certificate)
+		assert.Equal(t, string(tlsSecret.Data[corev1.TLSPrivateKeyKey]), route.Spec.TLS.Key)
+		assert.Equal(t, string(tlsSecret.Data[corev1.TLSCertKey]), route.Spec.TLS.DestinationCACertificate)
+	}
+	for _, route := range env.Servers[0].Routes {
+		if route.Spec.TLS == nil {
+			continue
+		}
+		assert.Equal(t, routev1.TLSTerminationPassthrough, route.Spec.TLS.Termination)
+		assert.Empty(t, route.Spec.TLS.Certificate, "Passthrough routes should not have a certificate")
+	}
+
+	// the keystore is kept between reconciles
+	err = reconciler.Service.Create(context.TODO(), &env.Console.Secrets[0])
+	assert.Nil(t, err)
+	keystoreSecret := env.Console.Secrets[0]
+	env, err = defaults.GetEnvironment(cr, mockService)
+	assert.Nil(t, err, "Error getting a new environment")
+	env, err = reconciler.setEnvironmentProperties(cr, env, getRequestedRoutes(env, cr))
+	assert.Nil(t, err)
+	assert.Equal(t, keystoreSecret, env.Console.Secrets[0])
+
+	// a missing TLS Secret fails the reconcile
+	cr.Spec.Objects.Console.Route.TLSSecret = "missing"
+	env, err = defaults.GetEnvironment(cr, mockService)
+	assert.Nil(t, err, "Error getting a new environment")
+	_, err = reconciler.setEnvironmentProperties(cr, env, getRequestedRoutes(env, cr))
+	assert.NotNil(t, err)
+}
+
+func getTestTLSSecret(t *testing.T, name, commonName string) corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	derKey, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: derKey}),
+		},
+	}
+}
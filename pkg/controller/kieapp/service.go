@@ -0,0 +1,30 @@
+package kieapp
+
+import (
+	"context"
+
+	"github.com/RHsyseng/operator-utils/pkg/utils/kubernetes"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewPlatformService returns a PlatformService reading the cluster-scoped objects, like ConsoleLinks, with the given
+// uncached reader. When several namespaces are watched, the cache only holds the objects of these namespaces.
+func NewPlatformService(service kubernetes.PlatformService, reader client.Reader) kubernetes.PlatformService {
+	return &clusterReaderService{PlatformService: service, reader: reader}
+}
+
+// clusterReaderService is a PlatformService that reads the cluster-scoped objects without the cache
+type clusterReaderService struct {
+	kubernetes.PlatformService
+	reader client.Reader
+}
+
+var _ kubernetes.PlatformService = &clusterReaderService{}
+
+func (service *clusterReaderService) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if key.Namespace == "" {
+		return service.reader.Get(ctx, key, obj)
+	}
+	return service.PlatformService.Get(ctx, key, obj)
+}
@@ -0,0 +1,49 @@
+package kieapp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	consolev1 "github.com/openshift/api/console/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileMultiNamespace(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{Environment: api.RhpamTrial}
+	service := test.MockService()
+	assert.Nil(t, service.Create(context.TODO(), cr))
+	// the cache of several watched namespaces holds no cluster-scoped object
+	service.GetFunc = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+		if key.Namespace == "" {
+			return fmt.Errorf("unable to get: %v because of unknown namespace for the cache", key)
+		}
+		return service.Client.Get(ctx, key, obj)
+	}
+	reconcileCR := func(reconciler Reconciler) error {
+		_, err := reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		if err != nil {
+			return err
+		}
+		route := &routev1.Route{}
+		assert.Nil(t, service.Get(context.TODO(), getNamespacedName("testns", "cr-rhpamcentr"), route))
+		route.Spec.Host = "example"
+		assert.Nil(t, service.Update(context.TODO(), route))
+		_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		return err
+	}
+
+	assert.NotNil(t, reconcileCR(Reconciler{Service: service}), "Cluster-scoped objects can't be read from the cache")
+	assert.Nil(t, reconcileCR(Reconciler{Service: NewPlatformService(service, service.Client)}))
+	consoleLink := &consolev1.ConsoleLink{}
+	assert.Nil(t, service.Client.Get(context.TODO(), getNamespacedName("", getConsoleLinkName(cr)), consoleLink))
+	assert.Equal(t, "https://example", consoleLink.Spec.Href)
+}
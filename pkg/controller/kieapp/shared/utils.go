@@ -7,8 +7,12 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"math/big"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
@@ -26,27 +30,114 @@ func GenerateKeystore(commonName string, password []byte) []byte {
 		log.Error("Error generating certificate. ", err)
 	}
 
+	b, err := encodeKeystore(derPK, [][]byte{cert}, password)
+	if err != nil {
+		log.Error("Error encrypting and signing keystore. ", err)
+	}
+	return b
+}
+
+// GenerateKeystoreFromPEM returns a Java Keystore with the PEM encoded certificate chain and private key,
+// e.g. the tls.crt and tls.key of a kubernetes.io/tls Secret
+func GenerateKeystoreFromPEM(certPEM, keyPEM, password []byte) ([]byte, error) {
+	var certs [][]byte
+	for block, rest := pem.Decode(certPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "CERTIFICATE" {
+			certs = append(certs, block.Bytes)
+		}
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	derPK, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return encodeKeystore(derPK, certs, password)
+}
+
+// GetKeystoreCertificates returns the certificate chain of the private key entry of a Java Keystore
+func GetKeystoreCertificates(keyStoreBytes, password []byte) ([]*x509.Certificate, error) {
+	keyStore, err := keystore.Decode(bytes.NewReader(keyStoreBytes), password)
+	if err != nil {
+		return nil, err
+	}
+	keyEntry, ok := keyStore[constants.KeystoreAlias].(*keystore.PrivateKeyEntry)
+	if !ok {
+		return nil, fmt.Errorf("no private key entry %s found in keystore", constants.KeystoreAlias)
+	}
+	var certs []*x509.Certificate
+	for _, certEntry := range keyEntry.CertChain {
+		cert, err := x509.ParseCertificate(certEntry.Content)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// GetKeystorePEM returns the PEM encoded certificate chain and private key of the private key entry of a Java Keystore,
+// e.g. for the tls.crt and tls.key of an ingress TLS Secret
+func GetKeystorePEM(keyStoreBytes, password []byte) (certPEM, keyPEM []byte, err error) {
+	keyStore, err := keystore.Decode(bytes.NewReader(keyStoreBytes), password)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyEntry, ok := keyStore[constants.KeystoreAlias].(*keystore.PrivateKeyEntry)
+	if !ok {
+		return nil, nil, fmt.Errorf("no private key entry %s found in keystore", constants.KeystoreAlias)
+	}
+	for _, certEntry := range keyEntry.CertChain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certEntry.Content})...)
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyEntry.PrivKey})
+	return certPEM, keyPEM, nil
+}
+
+func encodeKeystore(derPK []byte, certs [][]byte, password []byte) ([]byte, error) {
 	var chain []keystore.Certificate
+	for _, cert := range certs {
+		chain = append(chain, keystore.Certificate{
+			Type:    "X509",
+			Content: cert,
+		})
+	}
 	keyStore := keystore.KeyStore{
 		constants.KeystoreAlias: &keystore.PrivateKeyEntry{
 			Entry: keystore.Entry{
 				CreationDate: time.Now(),
 			},
-			PrivKey: derPK,
-			CertChain: append(chain, keystore.Certificate{
-				Type:    "X509",
-				Content: cert,
-			}),
+			PrivKey:   derPK,
+			CertChain: chain,
 		},
 	}
 
 	var b bytes.Buffer
-	err = keystore.Encode(&b, keyStore, password)
-	if err != nil {
-		log.Error("Error encrypting and signing keystore. ", err)
-	}
+	err := keystore.Encode(&b, keyStore, password)
+	return b.Bytes(), err
+}
 
-	return b.Bytes()
+// parsePrivateKey parses a PKCS#8, PKCS#1 or EC DER encoded private key
+func parsePrivateKey(der []byte) (interface{}, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("unsupported private key format")
 }
 
 // ????????????????
@@ -185,3 +276,13 @@ func Find(slice []string, val string) (int, bool) {
 	}
 	return -1, false
 }
+
+// GetFieldPath appends the key to the path of a field, between brackets if it would be ambiguous, e.g. for label names
+func GetFieldPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%s]", path, key)
+	} else if path == "" {
+		return key
+	}
+	return path + "." + key
+}
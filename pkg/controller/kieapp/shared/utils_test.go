@@ -2,8 +2,15 @@ package shared
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
 	keystore "github.com/pavel-v-chernykh/keystore-go"
@@ -76,6 +83,49 @@ func TestGenerateKeystore(t *testing.T) {
 	assert.Equal(t, commonName, certificate.Subject.CommonName)
 }
 
+func TestGenerateKeystoreFromPEM(t *testing.T) {
+	password := GeneratePassword(8)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "console.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	derKey, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: derKey})
+
+	keyBytes, err := GenerateKeystoreFromPEM(certPEM, keyPEM, password)
+	assert.Nil(t, err)
+	certs, err := GetKeystoreCertificates(keyBytes, password)
+	assert.Nil(t, err)
+	assert.Len(t, certs, 1)
+	assert.Equal(t, "console.example.com", certs[0].Subject.CommonName)
+
+	_, err = GetKeystoreCertificates(keyBytes, []byte("wrongPwd"))
+	assert.NotNil(t, err)
+	_, err = GenerateKeystoreFromPEM(certPEM, certPEM, password)
+	assert.NotNil(t, err, "A certificate is not a private key")
+	_, err = GenerateKeystoreFromPEM(keyPEM, keyPEM, password)
+	assert.NotNil(t, err, "No certificate is provided")
+}
+
+func TestGetKeystorePEM(t *testing.T) {
+	password := GeneratePassword(8)
+	certPEM, keyPEM, err := GetKeystorePEM(GenerateKeystore("console.example.com", password), password)
+	assert.Nil(t, err)
+	keyBytes, err := GenerateKeystoreFromPEM(certPEM, keyPEM, password)
+	assert.Nil(t, err, "The PEM encoded certificate and key should round trip")
+	certs, err := GetKeystoreCertificates(keyBytes, password)
+	assert.Nil(t, err)
+	assert.Equal(t, "console.example.com", certs[0].Subject.CommonName)
+}
+
 func TestEnvVarCheck(t *testing.T) {
 	empty := []corev1.EnvVar{}
 	a := []corev1.EnvVar{
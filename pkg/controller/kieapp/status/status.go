@@ -1,8 +1,13 @@
 package status
 
 import (
+	"reflect"
+	"sort"
+	"strings"
+
 	"github.com/RHsyseng/operator-utils/pkg/logs"
 	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -14,9 +19,8 @@ const maxBuffer = 30
 // SetProvisioning - Sets the condition type to Provisioning and status True if not yet set.
 func SetProvisioning(cr *api.KieApp) bool {
 	log := log.With("kind", cr.Kind, "name", cr.Name, "namespace", cr.Namespace)
-	size := len(cr.Status.Conditions)
-	if size > 0 && cr.Status.Conditions[size-1].Type == api.ProvisioningConditionType &&
-		cr.Status.Conditions[size-1].Version == cr.Status.Applied.Version {
+	if last := lastCondition(cr); last != nil && last.Type == api.ProvisioningConditionType &&
+		last.Version == cr.Status.Applied.Version {
 		log.Debug("Status: unchanged status [provisioning].")
 		return false
 	}
@@ -28,9 +32,8 @@ func SetProvisioning(cr *api.KieApp) bool {
 // SetDeployed - Updates the condition with the DeployedCondition and True status
 func SetDeployed(cr *api.KieApp) bool {
 	log := log.With("kind", cr.Kind, "name", cr.Name, "namespace", cr.Namespace)
-	size := len(cr.Status.Conditions)
-	if size > 0 && cr.Status.Conditions[size-1].Type == api.DeployedConditionType &&
-		cr.Status.Conditions[size-1].Version == cr.Status.Applied.Version {
+	if last := lastCondition(cr); last != nil && last.Type == api.DeployedConditionType &&
+		last.Version == cr.Status.Applied.Version {
 		log.Debug("Status: unchanged status [deployed].")
 		return false
 	}
@@ -52,16 +55,270 @@ func SetFailed(cr *api.KieApp, reason api.ReasonType, err error) {
 	cr.Status.Conditions = addCondition(cr, condition)
 }
 
+// SetDeleting - Sets the condition type to Deleting and status True if not yet set.
+func SetDeleting(cr *api.KieApp) bool {
+	log := log.With("kind", cr.Kind, "name", cr.Name, "namespace", cr.Namespace)
+	if last := lastCondition(cr); last != nil && last.Type == api.DeletingConditionType {
+		log.Debug("Status: unchanged status [deleting].")
+		return false
+	}
+	log.Debug("Status: set deleting")
+	cr.Status.Conditions = addCondition(cr, api.Condition{Type: api.DeletingConditionType})
+	return true
+}
+
+// SetPaused - Sets the condition type to Paused and status True if not yet set.
+func SetPaused(cr *api.KieApp) bool {
+	log := log.With("kind", cr.Kind, "name", cr.Name, "namespace", cr.Namespace)
+	if last := lastCondition(cr); last != nil && last.Type == api.PausedConditionType {
+		log.Debug("Status: unchanged status [paused].")
+		return false
+	}
+	log.Debug("Status: set paused")
+	cr.Status.Conditions = addCondition(cr, api.Condition{Type: api.PausedConditionType})
+	return true
+}
+
+// SetComponentConditions - Replaces the readiness conditions of the components with the given ones.
+// Returns true if any of the conditions has changed.
+func SetComponentConditions(cr *api.KieApp, components []api.Condition) bool {
+	requested := map[api.ConditionType]api.Condition{}
+	for _, condition := range components {
+		requested[condition.Type] = condition
+	}
+	changed := false
+	var conditions []api.Condition
+	for _, condition := range cr.Status.Conditions {
+		if IsReadinessCondition(condition.Type) && condition.Type != api.ReadyConditionType {
+			if _, found := requested[condition.Type]; !found {
+				changed = true
+				continue
+			}
+		}
+		conditions = append(conditions, condition)
+	}
+	cr.Status.Conditions = conditions
+	for _, condition := range components {
+		changed = setReadinessCondition(cr, condition) || changed
+	}
+	return changed
+}
+
+// SetReady - Sets the Ready condition to True when the kieapp is deployed and all its components are ready.
+// Returns true if the condition has changed.
+func SetReady(cr *api.KieApp) bool {
+	condition := api.Condition{Type: api.ReadyConditionType, Status: corev1.ConditionTrue}
+	if notReady := getNotReadyComponents(cr); len(notReady) > 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = api.ComponentsNotReadyReason
+		condition.Message = "Components not ready: " + strings.Join(notReady, ", ")
+	} else if cr.Status.Phase != api.DeployedConditionType {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = api.NotReadyReason
+		condition.Message = "Status: " + string(cr.Status.Phase)
+	}
+	return setReadinessCondition(cr, condition)
+}
+
+// SetConflicts - Replaces the resources that could not be applied because of fields owned by other managers.
+// Returns true if the conflicts have changed.
+func SetConflicts(cr *api.KieApp, conflicts []api.ResourceConflict) bool {
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Kind != conflicts[j].Kind {
+			return conflicts[i].Kind < conflicts[j].Kind
+		}
+		return conflicts[i].Name < conflicts[j].Name
+	})
+	if len(conflicts) == 0 && len(cr.Status.Conflicts) == 0 {
+		return false
+	}
+	if reflect.DeepEqual(conflicts, cr.Status.Conflicts) {
+		return false
+	}
+	cr.Status.Conflicts = conflicts
+	return true
+}
+
+// SetUpgradeConflicts - Replaces the customized configuration fields that block the upgrade to the current version.
+// Returns true if the conflicts have changed.
+func SetUpgradeConflicts(cr *api.KieApp, conflicts []api.ConfigConflict) bool {
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if conflicts[i].ConfigMap != conflicts[j].ConfigMap {
+			return conflicts[i].ConfigMap < conflicts[j].ConfigMap
+		}
+		return conflicts[i].File < conflicts[j].File
+	})
+	if len(conflicts) == 0 && len(cr.Status.UpgradeConflicts) == 0 {
+		return false
+	}
+	if reflect.DeepEqual(conflicts, cr.Status.UpgradeConflicts) {
+		return false
+	}
+	cr.Status.UpgradeConflicts = conflicts
+	return true
+}
+
+// AddUpgrade - Records an upgrade in progress in the upgrade history, keeping the most recent ones.
+// Returns the records removed from the history.
+func AddUpgrade(cr *api.KieApp, fromVersion, toVersion, snapshot string) []api.UpgradeRecord {
+	log := log.With("kind", cr.Kind, "name", cr.Name, "namespace", cr.Namespace)
+	log.Debugf("Status: upgrade from %s to %s", fromVersion, toVersion)
+	cr.Status.UpgradeHistory = append(cr.Status.UpgradeHistory, api.UpgradeRecord{
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Phase:       api.UpgradeInProgress,
+		StartTime:   metav1.Now(),
+		Snapshot:    snapshot,
+	})
+	var removed []api.UpgradeRecord
+	if len(cr.Status.UpgradeHistory) > constants.MaxUpgradeHistory {
+		removed = cr.Status.UpgradeHistory[:len(cr.Status.UpgradeHistory)-constants.MaxUpgradeHistory]
+		cr.Status.UpgradeHistory = cr.Status.UpgradeHistory[len(removed):]
+	}
+	return removed
+}
+
+// SetUpgradePhase - Sets the phase of the last upgrade, to or from the applied version when rolled back. A completed
+// upgrade can only be rolled back, and a rolled back one no longer changes.
+// Returns true if the phase has changed.
+func SetUpgradePhase(cr *api.KieApp, phase api.UpgradePhase, message string) bool {
+	if len(cr.Status.UpgradeHistory) == 0 {
+		return false
+	}
+	last := &cr.Status.UpgradeHistory[len(cr.Status.UpgradeHistory)-1]
+	if last.Phase == phase || last.Phase == api.UpgradeRolledBack ||
+		(last.Phase == api.UpgradeCompleted && phase != api.UpgradeRolledBack) {
+		return false
+	}
+	if phase == api.UpgradeRolledBack && last.FromVersion != cr.Status.Applied.Version ||
+		phase != api.UpgradeRolledBack && last.ToVersion != cr.Status.Applied.Version {
+		return false
+	}
+	log.With("kind", cr.Kind, "name", cr.Name, "namespace", cr.Namespace).Debugf("Status: upgrade %s", phase)
+	last.Phase = phase
+	last.Message = message
+	if phase != api.UpgradeInProgress {
+		last.CompletionTime = metav1.Now()
+	}
+	return true
+}
+
+// SetDatabaseMigration - Sets the migration of the database of a KIE Server in the last upgrade, by server name.
+// Returns true if the migration has changed.
+func SetDatabaseMigration(cr *api.KieApp, migration api.DatabaseMigration) bool {
+	if len(cr.Status.UpgradeHistory) == 0 {
+		return false
+	}
+	last := &cr.Status.UpgradeHistory[len(cr.Status.UpgradeHistory)-1]
+	for index := range last.DatabaseMigrations {
+		if last.DatabaseMigrations[index].Server != migration.Server {
+			continue
+		}
+		if reflect.DeepEqual(last.DatabaseMigrations[index], migration) {
+			return false
+		}
+		last.DatabaseMigrations[index] = migration
+		return true
+	}
+	log.With("kind", cr.Kind, "name", cr.Name, "namespace", cr.Namespace).Debugf("Status: database migration of %s %s", migration.Server, migration.Phase)
+	last.DatabaseMigrations = append(last.DatabaseMigrations, migration)
+	sort.Slice(last.DatabaseMigrations, func(i, j int) bool {
+		return last.DatabaseMigrations[i].Server < last.DatabaseMigrations[j].Server
+	})
+	return true
+}
+
+// SetDrift - Replaces the deployed resources that differ from the requested ones.
+// Returns true if the drift has changed.
+func SetDrift(cr *api.KieApp, drift []api.ResourceDrift) bool {
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Kind != drift[j].Kind {
+			return drift[i].Kind < drift[j].Kind
+		}
+		return drift[i].Name < drift[j].Name
+	})
+	if len(drift) == 0 && len(cr.Status.Drift) == 0 {
+		return false
+	}
+	if reflect.DeepEqual(drift, cr.Status.Drift) {
+		return false
+	}
+	cr.Status.Drift = drift
+	return true
+}
+
+// IsReadinessCondition - Returns true for the conditions that reflect the current state of the kieapp
+// instead of its history
+func IsReadinessCondition(conditionType api.ConditionType) bool {
+	return strings.HasSuffix(string(conditionType), string(api.ReadyConditionType))
+}
+
+func getNotReadyComponents(cr *api.KieApp) []string {
+	var notReady []string
+	for _, condition := range cr.Status.Conditions {
+		if IsReadinessCondition(condition.Type) && condition.Type != api.ReadyConditionType && condition.Status != corev1.ConditionTrue {
+			notReady = append(notReady, string(condition.Type))
+		}
+	}
+	sort.Strings(notReady)
+	return notReady
+}
+
+func setReadinessCondition(cr *api.KieApp, condition api.Condition) bool {
+	condition.Version = cr.Status.Applied.Version
+	for i := range cr.Status.Conditions {
+		existing := &cr.Status.Conditions[i]
+		if existing.Type != condition.Type {
+			continue
+		}
+		if existing.Status == condition.Status && existing.Reason == condition.Reason &&
+			existing.Message == condition.Message && existing.Version == condition.Version {
+			return false
+		}
+		if existing.Status != condition.Status {
+			existing.LastTransitionTime = metav1.Now()
+		}
+		existing.Status = condition.Status
+		existing.Reason = condition.Reason
+		existing.Message = condition.Message
+		existing.Version = condition.Version
+		return true
+	}
+	condition.LastTransitionTime = metav1.Now()
+	cr.Status.Conditions = append(cr.Status.Conditions, condition)
+	return true
+}
+
+// lastCondition returns the most recent condition of the kieapp history
+func lastCondition(cr *api.KieApp) *api.Condition {
+	for i := len(cr.Status.Conditions) - 1; i >= 0; i-- {
+		if !IsReadinessCondition(cr.Status.Conditions[i].Type) {
+			return &cr.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 func addCondition(cr *api.KieApp, condition api.Condition) []api.Condition {
 	condition.Status = corev1.ConditionTrue
 	condition.LastTransitionTime = metav1.Now()
 	condition.Version = cr.Status.Applied.Version
-	conditions := cr.Status.Conditions
-	size := len(conditions) + 1
-	first := 0
-	if size > maxBuffer {
-		first = size - maxBuffer
+	conditions := append(cr.Status.Conditions, condition)
+	history := 0
+	for _, c := range conditions {
+		if !IsReadinessCondition(c.Type) {
+			history++
+		}
+	}
+	// only the history is kept in the buffer, readiness conditions are always retained
+	for i := 0; history > maxBuffer && i < len(conditions); {
+		if IsReadinessCondition(conditions[i].Type) {
+			i++
+			continue
+		}
+		conditions = append(conditions[:i], conditions[i+1:]...)
+		history--
 	}
 	cr.Status.Phase = condition.Type
-	return append(conditions, condition)[first:size]
+	return conditions
 }
@@ -102,6 +102,172 @@ func TestSetProvisioningAndThenDeployed(t *testing.T) {
 	assert.Equal(t, api.DeployedConditionType, cr.Status.Phase)
 }
 
+func TestSetDeleting(t *testing.T) {
+	cr := &api.KieApp{Status: api.KieAppStatus{Applied: api.KieAppSpec{Version: constants.CurrentVersion}}}
+	assert.True(t, SetDeployed(cr))
+	assert.True(t, SetDeleting(cr))
+	assert.False(t, SetDeleting(cr))
+
+	assert.Equal(t, 2, len(cr.Status.Conditions))
+	assert.Equal(t, api.DeletingConditionType, cr.Status.Conditions[1].Type)
+	assert.Equal(t, corev1.ConditionTrue, cr.Status.Conditions[1].Status)
+	assert.Equal(t, api.DeletingConditionType, cr.Status.Phase)
+}
+
+func TestSetComponentConditions(t *testing.T) {
+	cr := &api.KieApp{Status: api.KieAppStatus{Applied: api.KieAppSpec{Version: constants.CurrentVersion}}}
+	assert.True(t, SetProvisioning(cr))
+	components := []api.Condition{
+		{Type: api.ConsoleReadyConditionType, Status: corev1.ConditionTrue},
+		{Type: api.BrokerReadyConditionType, Status: corev1.ConditionFalse, Reason: api.NotReadyReason},
+	}
+	assert.True(t, SetComponentConditions(cr, components))
+	assert.True(t, SetReady(cr))
+	assert.Equal(t, 4, len(cr.Status.Conditions))
+	assert.Equal(t, api.ProvisioningConditionType, cr.Status.Phase)
+	ready := cr.Status.Conditions[3]
+	assert.Equal(t, api.ReadyConditionType, ready.Type)
+	assert.Equal(t, corev1.ConditionFalse, ready.Status)
+	assert.Equal(t, api.ComponentsNotReadyReason, ready.Reason)
+	assert.Equal(t, "Components not ready: BrokerReady", ready.Message)
+
+	assert.False(t, SetComponentConditions(cr, components), "Unchanged conditions must not be updated")
+	assert.False(t, SetReady(cr))
+
+	components = components[:1]
+	assert.True(t, SetComponentConditions(cr, components), "Broker condition must be removed")
+	assert.True(t, SetDeployed(cr))
+	assert.True(t, SetReady(cr))
+	assert.Equal(t, 4, len(cr.Status.Conditions))
+	assert.Equal(t, api.DeployedConditionType, cr.Status.Phase)
+	ready = cr.Status.Conditions[2]
+	assert.Equal(t, api.ReadyConditionType, ready.Type)
+	assert.Equal(t, corev1.ConditionTrue, ready.Status)
+	assert.Empty(t, ready.Message)
+
+	assert.False(t, SetDeployed(cr), "Readiness conditions must not be considered as the last status")
+}
+
+func TestSetConflicts(t *testing.T) {
+	cr := &api.KieApp{}
+	assert.False(t, SetConflicts(cr, nil))
+
+	conflicts := []api.ResourceConflict{
+		{Kind: "Service", Name: "test-kieserver", Message: "conflict with \"istio\""},
+		{Kind: "DeploymentConfig", Name: "test-kieserver", Message: "conflict with \"kubectl\""},
+	}
+	assert.True(t, SetConflicts(cr, conflicts))
+	assert.Len(t, cr.Status.Conflicts, 2)
+	assert.Equal(t, "DeploymentConfig", cr.Status.Conflicts[0].Kind, "Conflicts should be sorted")
+
+	assert.False(t, SetConflicts(cr, []api.ResourceConflict{conflicts[1], conflicts[0]}))
+	assert.True(t, SetConflicts(cr, nil))
+	assert.Empty(t, cr.Status.Conflicts)
+}
+
+func TestSetUpgradeConflicts(t *testing.T) {
+	cr := &api.KieApp{}
+	assert.False(t, SetUpgradeConflicts(cr, nil))
+
+	conflicts := []api.ConfigConflict{
+		{ConfigMap: "kieconfigs-7.8.0", File: "common.yaml", Path: "console.replicas", Customized: "2", Upgraded: "3"},
+		{ConfigMap: "kieconfigs-7.8.0", File: "common.yaml", Path: "console.image", Customized: "custom", Upgraded: "upgraded"},
+		{ConfigMap: "kieconfigs-7.8.0-dbs", File: "mysql.yaml"},
+	}
+	assert.True(t, SetUpgradeConflicts(cr, []api.ConfigConflict{conflicts[2], conflicts[0], conflicts[1]}))
+	assert.Equal(t, conflicts, cr.Status.UpgradeConflicts, "Conflicts should be sorted by ConfigMap and file")
+
+	assert.False(t, SetUpgradeConflicts(cr, []api.ConfigConflict{conflicts[2], conflicts[0], conflicts[1]}))
+	assert.True(t, SetUpgradeConflicts(cr, nil))
+	assert.Empty(t, cr.Status.UpgradeConflicts)
+}
+
+func TestUpgradeHistory(t *testing.T) {
+	cr := &api.KieApp{}
+	assert.False(t, SetUpgradePhase(cr, api.UpgradeCompleted, ""))
+
+	for i := 0; i < constants.MaxUpgradeHistory; i++ {
+		assert.Empty(t, AddUpgrade(cr, "7.8.0", "7.8.1", "test-upgrade-7.8.0"))
+	}
+	removed := AddUpgrade(cr, "7.8.1", "7.9.0", "test-upgrade-7.8.1")
+	assert.Len(t, removed, 1)
+	assert.Equal(t, "7.8.0", removed[0].FromVersion, "The oldest upgrade should be removed")
+	assert.Len(t, cr.Status.UpgradeHistory, constants.MaxUpgradeHistory)
+	assert.Equal(t, api.UpgradeInProgress, cr.Status.UpgradeHistory[constants.MaxUpgradeHistory-1].Phase)
+
+	cr.Status.Applied.Version = "7.8.1"
+	assert.False(t, SetUpgradePhase(cr, api.UpgradeCompleted, ""), "Only the upgrade to the applied version should complete")
+	cr.Status.Applied.Version = "7.9.0"
+	assert.True(t, SetUpgradePhase(cr, api.UpgradeCompleted, ""))
+	assert.False(t, SetUpgradePhase(cr, api.UpgradeFailed, "rollout failed"), "A completed upgrade should not fail")
+	assert.False(t, SetUpgradePhase(cr, api.UpgradeRolledBack, ""), "Only the version upgraded from should be rolled back to")
+	cr.Status.Applied.Version = "7.8.1"
+	assert.True(t, SetUpgradePhase(cr, api.UpgradeRolledBack, "rolled back"))
+	last := cr.Status.UpgradeHistory[constants.MaxUpgradeHistory-1]
+	assert.Equal(t, api.UpgradeRolledBack, last.Phase)
+	assert.Equal(t, "rolled back", last.Message)
+	assert.False(t, last.CompletionTime.IsZero())
+}
+
+func TestSetDatabaseMigration(t *testing.T) {
+	cr := &api.KieApp{}
+	migration := api.DatabaseMigration{Server: "test-kieserver2", Job: "test-kieserver2-migration-7.9.0", Phase: api.DatabaseMigrationRunning}
+	assert.False(t, SetDatabaseMigration(cr, migration), "Migrations should only be set on an upgrade")
+
+	AddUpgrade(cr, "7.8.1", "7.9.0", "")
+	assert.True(t, SetDatabaseMigration(cr, migration))
+	assert.False(t, SetDatabaseMigration(cr, migration))
+	assert.True(t, SetDatabaseMigration(cr, api.DatabaseMigration{Server: "test-kieserver", Phase: api.DatabaseMigrationSkipped}))
+	migration.Phase = api.DatabaseMigrationSucceeded
+	assert.True(t, SetDatabaseMigration(cr, migration))
+	migrations := cr.Status.UpgradeHistory[0].DatabaseMigrations
+	assert.Len(t, migrations, 2)
+	assert.Equal(t, "test-kieserver", migrations[0].Server)
+	assert.Equal(t, api.DatabaseMigrationSucceeded, migrations[1].Phase)
+}
+
+func TestSetPaused(t *testing.T) {
+	cr := &api.KieApp{}
+	SetProvisioning(cr)
+	assert.True(t, SetPaused(cr))
+	assert.Equal(t, api.PausedConditionType, cr.Status.Phase)
+	assert.False(t, SetPaused(cr), "Paused condition should not be duplicated")
+	assert.Len(t, cr.Status.Conditions, 2)
+
+	assert.True(t, SetProvisioning(cr), "Reconciliation should be resumed once unpaused")
+	assert.Equal(t, api.ProvisioningConditionType, cr.Status.Phase)
+}
+
+func TestSetDrift(t *testing.T) {
+	cr := &api.KieApp{}
+	assert.False(t, SetDrift(cr, nil))
+
+	drift := []api.ResourceDrift{
+		{Kind: "Service", Name: "test-kieserver", Action: api.DriftCreate},
+		{Kind: "DeploymentConfig", Name: "test-kieserver", Action: api.DriftUpdate},
+	}
+	assert.True(t, SetDrift(cr, drift))
+	assert.Len(t, cr.Status.Drift, 2)
+	assert.Equal(t, "DeploymentConfig", cr.Status.Drift[0].Kind, "Drift should be sorted")
+
+	assert.False(t, SetDrift(cr, []api.ResourceDrift{drift[1], drift[0]}))
+	assert.True(t, SetDrift(cr, nil))
+	assert.Empty(t, cr.Status.Drift)
+}
+
+func TestBufferKeepsReadiness(t *testing.T) {
+	cr := &api.KieApp{Status: api.KieAppStatus{Applied: api.KieAppSpec{Version: constants.CurrentVersion}}}
+	SetComponentConditions(cr, []api.Condition{{Type: api.ConsoleReadyConditionType, Status: corev1.ConditionTrue}})
+	for i := 0; i < maxBuffer+2; i++ {
+		SetFailed(cr, api.UnknownReason, fmt.Errorf("Error %d", i))
+	}
+	size := len(cr.Status.Conditions)
+	assert.Equal(t, maxBuffer+1, size)
+	assert.Equal(t, api.ConsoleReadyConditionType, cr.Status.Conditions[0].Type)
+	assert.Equal(t, "Error 2", cr.Status.Conditions[1].Message)
+	assert.Equal(t, "Error 31", cr.Status.Conditions[size-1].Message)
+}
+
 func TestBuffer(t *testing.T) {
 	cr := &api.KieApp{Status: api.KieAppStatus{Applied: api.KieAppSpec{Version: constants.CurrentVersion}}}
 	for i := 0; i < maxBuffer+2; i++ {
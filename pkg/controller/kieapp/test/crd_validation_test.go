@@ -106,17 +106,62 @@ spec:
 func TestCompleteCRD(t *testing.T) {
 	schema := getSchema(t, api.SchemeGroupVersion.Version)
 	missingEntries := schema.GetMissingEntries(&api.KieApp{})
+	crdYAML, _ := packr.New("deploy/crds", "../../../../deploy/crds").FindString("kieapp.crd.yaml")
+	crdJSON, _ := yaml.YAMLToJSON([]byte(crdYAML))
+	crdSchema := gjson.Get(string(crdJSON), "spec.versions.#(name=="+api.SchemeGroupVersion.Version+").schema.openAPIV3Schema")
 	for _, missing := range missingEntries {
 		if strings.HasPrefix(missing.Path, "/status/conditions/lastTransitionTime") {
 			// ...
 		} else if strings.Contains(missing.Path, "/env/valueFrom/") {
 			//The valueFrom is not expected to be used and is not fully defined TODO: verify
+		} else if isIntOrString(crdSchema, missing.Path) {
+			//Quantities and IntOrStrings are defined as int-or-string rather than by their struct fields
+		} else if isDateTime(crdSchema, missing.Path) {
+			//Times are defined as date-time strings rather than by their struct fields
+		} else if isPreserveUnknownFields(crdSchema, missing.Path) {
+			//Kubernetes types such as affinities are left schemaless to keep the size of the CRD down
 		} else {
 			assert.Fail(t, "Discrepancy between CRD and Struct", "Missing or incorrect schema validation at %v, expected type %v", missing.Path, missing.Type)
 		}
 	}
 }
 
+// isIntOrString returns true if the path is, or is within, a field defined as int-or-string in the CRD schema
+func isIntOrString(schema gjson.Result, path string) bool {
+	return isWithinField(schema, path, func(field gjson.Result) bool {
+		return field.Get("x-kubernetes-int-or-string").Bool()
+	})
+}
+
+// isDateTime returns true if the path is, or is within, a field defined as a date-time string in the CRD schema
+func isDateTime(schema gjson.Result, path string) bool {
+	return isWithinField(schema, path, func(field gjson.Result) bool {
+		return field.Get("format").String() == "date-time"
+	})
+}
+
+// isPreserveUnknownFields returns true if the path is, or is within, a schemaless field of the CRD schema
+func isPreserveUnknownFields(schema gjson.Result, path string) bool {
+	return isWithinField(schema, path, func(field gjson.Result) bool {
+		return field.Get("x-kubernetes-preserve-unknown-fields").Bool()
+	})
+}
+
+func isWithinField(schema gjson.Result, path string, matches func(field gjson.Result) bool) bool {
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if schema.Get("items").Exists() {
+			schema = schema.Get("items")
+		}
+		schema = schema.Get("properties." + name)
+		if !schema.Exists() {
+			return false
+		} else if matches(schema) {
+			return true
+		}
+	}
+	return false
+}
+
 func deleteNestedMapEntry(object map[string]interface{}, keys ...string) {
 	for index := 0; index < len(keys)-1; index++ {
 		object = object[keys[index]].(map[string]interface{})
@@ -36,6 +36,11 @@ func (mock *MockImageStreamTag) Delete(ctx context.Context, name string, options
 		return nil
 	}
 	delete(mock.Tags, name)
+	for key, tag := range mock.Tags {
+		if tag.Name == name {
+			delete(mock.Tags, key)
+		}
+	}
 	return nil
 }
 
@@ -43,7 +48,15 @@ func (mock *MockImageStreamTag) Get(ctx context.Context, name string, options me
 	if mock.Tags == nil {
 		return nil, nil
 	}
-	return mock.Tags[name], nil
+	if tag, found := mock.Tags[name]; found {
+		return tag, nil
+	}
+	for _, tag := range mock.Tags {
+		if tag.Name == name {
+			return tag, nil
+		}
+	}
+	return nil, nil
 }
 
 func (mock *MockImageStreamTag) List(ctx context.Context, opts meta_v1.ListOptions) (*imagev1.ImageStreamTagList, error) {
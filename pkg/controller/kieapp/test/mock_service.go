@@ -12,10 +12,17 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	imagev1 "github.com/openshift/client-go/image/clientset/versioned/typed/image/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	clientv1 "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -59,9 +66,27 @@ var knownTypes = map[schema.GroupVersion][]runtime.Object{
 		&oappsv1.DeploymentConfigList{},
 	},
 	appsv1.SchemeGroupVersion: {
+		&appsv1.Deployment{},
+		&appsv1.DeploymentList{},
 		&appsv1.StatefulSet{},
 		&appsv1.StatefulSetList{},
 	},
+	autoscalingv2beta2.SchemeGroupVersion: {
+		&autoscalingv2beta2.HorizontalPodAutoscaler{},
+		&autoscalingv2beta2.HorizontalPodAutoscalerList{},
+	},
+	networkingv1beta1.SchemeGroupVersion: {
+		&networkingv1beta1.Ingress{},
+		&networkingv1beta1.IngressList{},
+	},
+	batchv1.SchemeGroupVersion: {
+		&batchv1.Job{},
+		&batchv1.JobList{},
+	},
+	policyv1beta1.SchemeGroupVersion: {
+		&policyv1beta1.PodDisruptionBudget{},
+		&policyv1beta1.PodDisruptionBudgetList{},
+	},
 	routev1.GroupVersion: {
 		&routev1.Route{},
 		&routev1.RouteList{},
@@ -120,6 +145,9 @@ func MockServiceWithExtraScheme(objs ...runtime.Object) *MockPlatformService {
 			return client.Update(ctx, obj, opts...)
 		},
 		PatchFunc: func(ctx context.Context, obj runtime.Object, patch clientv1.Patch, opts ...clientv1.PatchOption) error {
+			if patch.Type() == types.ApplyPatchType {
+				return applyPatch(ctx, client, obj)
+			}
 			return client.Patch(ctx, obj, patch, opts...)
 		},
 		DeleteAllOfFunc: func(ctx context.Context, obj runtime.Object, opts ...clientv1.DeleteAllOfOption) error {
@@ -140,6 +168,27 @@ func MockServiceWithExtraScheme(objs ...runtime.Object) *MockPlatformService {
 	}
 }
 
+// applyPatch emulates server-side apply, which the fake client does not support, by creating or replacing the object
+func applyPatch(ctx context.Context, client clientv1.Client, obj runtime.Object) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	existing := obj.DeepCopyObject()
+	err = client.Get(ctx, clientv1.ObjectKey{Namespace: accessor.GetNamespace(), Name: accessor.GetName()}, existing)
+	if errors.IsNotFound(err) {
+		return client.Create(ctx, obj)
+	} else if err != nil {
+		return err
+	}
+	existingAccessor, err := meta.Accessor(existing)
+	if err != nil {
+		return err
+	}
+	accessor.SetResourceVersion(existingAccessor.GetResourceVersion())
+	return client.Update(ctx, obj)
+}
+
 func (service *MockPlatformService) Create(ctx context.Context, obj runtime.Object, opts ...clientv1.CreateOption) error {
 	return service.CreateFunc(ctx, obj, opts...)
 }
@@ -0,0 +1,343 @@
+package kieapp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/RHsyseng/operator-utils/pkg/resource"
+	"github.com/ghodss/yaml"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/status"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// setVersionChange records the change of the applied version of a KieApp. Before an upgrade, the applied spec and the
+// deployed resources of the version upgraded from are kept in a snapshot Secret, so that it can be rolled back to.
+func (reconciler *Reconciler) setVersionChange(instance *api.KieApp, applied api.KieAppSpec) error {
+	if instance.Spec.RollbackTo != "" {
+		reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventUpgradeRolledBack,
+			"Rolling back from version %s to %s", applied.Version, instance.Status.Applied.Version)
+		status.SetUpgradePhase(instance, api.UpgradeRolledBack, fmt.Sprintf("Rolled back to version %s", instance.Status.Applied.Version))
+		return nil
+	}
+	deployed, err := reconciler.getDeployedResources(instance)
+	if err != nil {
+		return err
+	}
+	resources, err := getSnapshotResources(reconciler.Service.GetScheme(), deployed)
+	if err != nil {
+		return err
+	}
+	snapshot, err := defaults.NewUpgradeSnapshot(instance, applied, resources)
+	if err != nil {
+		return err
+	}
+	if err := reconciler.applyResource(instance, snapshot); err != nil {
+		return fmt.Errorf("Failed to save the snapshot of version %s before upgrading: %v", applied.Version, err)
+	}
+	reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventUpgradeStarted,
+		"Upgrading from version %s to %s", applied.Version, instance.Status.Applied.Version)
+	removed := status.AddUpgrade(instance, applied.Version, instance.Status.Applied.Version, snapshot.Name)
+	return reconciler.removeSnapshots(instance, removed)
+}
+
+// removeSnapshots deletes the snapshots of the upgrades removed from the history, unless still used by a newer upgrade
+func (reconciler *Reconciler) removeSnapshots(instance *api.KieApp, removed []api.UpgradeRecord) error {
+	for _, record := range removed {
+		if record.Snapshot == "" || hasSnapshot(instance.Status.UpgradeHistory, record.Snapshot) {
+			continue
+		}
+		snapshot := &corev1.Secret{}
+		snapshot.SetName(record.Snapshot)
+		snapshot.SetNamespace(instance.Namespace)
+		if err := reconciler.Service.Delete(context.TODO(), snapshot); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasSnapshot(history []api.UpgradeRecord, snapshot string) bool {
+	for _, record := range history {
+		if record.Snapshot == snapshot {
+			return true
+		}
+	}
+	return false
+}
+
+// checkUpgradePhase completes the last upgrade once all its components are ready and their databases migrated, or
+// fails it when the rollout of one of them or the migration of its database fails
+func (reconciler *Reconciler) checkUpgradePhase(instance *api.KieApp, components []api.Condition, ready bool) bool {
+	if history := instance.Status.UpgradeHistory; len(history) > 0 {
+		for _, migration := range history[len(history)-1].DatabaseMigrations {
+			if migration.Phase == api.DatabaseMigrationFailed {
+				return reconciler.failUpgrade(instance, fmt.Sprintf("Database migration job %s failed: %s", migration.Job, migration.Message))
+			} else if migration.Phase == api.DatabaseMigrationRunning {
+				ready = false
+			}
+		}
+	}
+	if ready {
+		return status.SetUpgradePhase(instance, api.UpgradeCompleted, "")
+	}
+	for _, component := range components {
+		if component.Reason == api.RolloutFailedReason {
+			return reconciler.failUpgrade(instance, component.Message)
+		}
+	}
+	return false
+}
+
+func (reconciler *Reconciler) failUpgrade(instance *api.KieApp, reason string) bool {
+	if !status.SetUpgradePhase(instance, api.UpgradeFailed, reason) {
+		return false
+	}
+	last := instance.Status.UpgradeHistory[len(instance.Status.UpgradeHistory)-1]
+	message := fmt.Sprintf("Upgrade from version %s to %s failed, set rollbackTo %s to roll back: %s", last.FromVersion, last.ToVersion, last.FromVersion, reason)
+	if len(instance.Status.UpgradePath) > 0 {
+		message = fmt.Sprintf("%s. The upgrade path through %s is stopped", message, strings.Join(instance.Status.UpgradePath, ", "))
+	}
+	reconciler.recordEvent(instance, corev1.EventTypeWarning, constants.EventUpgradeFailed, "%s", message)
+	return true
+}
+
+// checkDatabaseMigrations migrates the databases of the KIE Servers to the upgraded version before rolling them out.
+// Until the migration Job of a KIE Server succeeds, its deployed workload is kept instead of the requested one.
+func (reconciler *Reconciler) checkDatabaseMigrations(instance *api.KieApp, env api.Environment, requested []resource.KubernetesResource, deployed map[reflect.Type][]resource.KubernetesResource) error {
+	history := instance.Status.UpgradeHistory
+	if instance.Spec.RollbackTo != "" || len(history) == 0 {
+		return nil
+	}
+	upgrade := history[len(history)-1]
+	if upgrade.ToVersion != instance.Status.Applied.Version || upgrade.Phase != api.UpgradeInProgress && upgrade.Phase != api.UpgradeFailed {
+		return nil
+	}
+	migrations := map[string]api.DatabaseMigration{}
+	for _, migration := range upgrade.DatabaseMigrations {
+		migrations[migration.Server] = migration
+	}
+	servers := map[string]bool{}
+	for _, server := range env.Servers {
+		for _, dc := range server.DeploymentConfigs {
+			servers[dc.Name] = true
+		}
+		for _, deployment := range server.Deployments {
+			servers[deployment.Name] = true
+		}
+		for _, statefulSet := range server.StatefulSets {
+			servers[statefulSet.Name] = true
+		}
+	}
+	for index, res := range requested {
+		_, podSpec := getPodSpec(res)
+		if podSpec == nil || !servers[res.GetName()] {
+			continue
+		}
+		migration, found := migrations[res.GetName()]
+		if found && (migration.Phase == api.DatabaseMigrationSucceeded || migration.Phase == api.DatabaseMigrationSkipped) {
+			continue
+		}
+		migration.Server = res.GetName()
+		serverSpec, err := reconciler.getTriggeredPodSpec(res, *podSpec)
+		if err != nil {
+			return fmt.Errorf("Failed to resolve the image of %s: %v", res.GetName(), err)
+		}
+		job, err := defaults.NewDatabaseMigrationJob(instance, upgrade.FromVersion, res.GetName(), serverSpec)
+		if err != nil {
+			migration.Phase, migration.Message = api.DatabaseMigrationSkipped, err.Error()
+			reconciler.recordEvent(instance, corev1.EventTypeWarning, constants.EventDatabaseMigrationSkipped, "%s", err.Error())
+			status.SetDatabaseMigration(instance, migration)
+			continue
+		} else if job == nil {
+			continue
+		}
+		migration.Job = job.Name
+		deployedJob := &batchv1.Job{}
+		err = reconciler.Service.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: instance.Namespace}, deployedJob)
+		if errors.IsNotFound(err) {
+			if err = reconciler.applyResource(instance, job); err != nil {
+				return fmt.Errorf("Failed to create the database migration job of %s: %v", res.GetName(), err)
+			}
+			reconciler.recordEvent(instance, corev1.EventTypeNormal, constants.EventDatabaseMigrationStarted,
+				"Migrating the database of %s from version %s to %s", res.GetName(), upgrade.FromVersion, upgrade.ToVersion)
+			migration = api.DatabaseMigration{Server: res.GetName(), Job: job.Name, Phase: api.DatabaseMigrationRunning, StartTime: metav1.Now()}
+		} else if err != nil {
+			return err
+		} else {
+			setDatabaseMigrationPhase(&migration, deployedJob)
+		}
+		status.SetDatabaseMigration(instance, migration)
+		if migration.Phase != api.DatabaseMigrationSucceeded {
+			holdRollout(requested, index, deployed)
+		}
+	}
+	return nil
+}
+
+// getTriggeredPodSpec returns the pod spec of a workload with the images of the ImageStreamTags of its triggers, as
+// OpenShift resolves them when deploying a DeploymentConfig
+func (reconciler *Reconciler) getTriggeredPodSpec(res resource.KubernetesResource, podSpec corev1.PodSpec) (corev1.PodSpec, error) {
+	dc, ok := res.(*oappsv1.DeploymentConfig)
+	if !ok {
+		return podSpec, nil
+	}
+	podSpec = *podSpec.DeepCopy()
+	for _, trigger := range dc.Spec.Triggers {
+		params := trigger.ImageChangeParams
+		if trigger.Type != oappsv1.DeploymentTriggerOnImageChange || params == nil || params.From.Kind != "ImageStreamTag" {
+			continue
+		}
+		namespace := params.From.Namespace
+		if namespace == "" {
+			namespace = dc.Namespace
+		}
+		tag, err := reconciler.Service.ImageStreamTags(namespace).Get(context.TODO(), params.From.Name, metav1.GetOptions{})
+		if err != nil {
+			return podSpec, err
+		} else if tag == nil || tag.Image.DockerImageReference == "" {
+			return podSpec, fmt.Errorf("ImageStreamTag %s/%s has no image yet", namespace, params.From.Name)
+		}
+		for index := range podSpec.Containers {
+			for _, name := range params.ContainerNames {
+				if podSpec.Containers[index].Name == name {
+					podSpec.Containers[index].Image = tag.Image.DockerImageReference
+				}
+			}
+		}
+	}
+	return podSpec, nil
+}
+
+// setDatabaseMigrationPhase sets the phase of a database migration from the conditions of its Job
+func setDatabaseMigrationPhase(migration *api.DatabaseMigration, job *batchv1.Job) {
+	migration.Phase, migration.Message, migration.CompletionTime = api.DatabaseMigrationRunning, "", metav1.Time{}
+	if job.Status.StartTime != nil {
+		migration.StartTime = *job.Status.StartTime
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batchv1.JobComplete {
+			migration.Phase, migration.CompletionTime = api.DatabaseMigrationSucceeded, condition.LastTransitionTime
+		} else if condition.Type == batchv1.JobFailed {
+			migration.Phase, migration.Message, migration.CompletionTime = api.DatabaseMigrationFailed, condition.Message, condition.LastTransitionTime
+		}
+	}
+}
+
+// holdRollout replaces a requested workload with the deployed one, so that it is not rolled out
+func holdRollout(requested []resource.KubernetesResource, index int, deployed map[reflect.Type][]resource.KubernetesResource) {
+	for _, res := range deployed[reflect.TypeOf(requested[index]).Elem()] {
+		if res.GetName() == requested[index].GetName() {
+			log.Debugf("Holding the rollout of %s until its database is migrated", res.GetName())
+			requested[index] = res.DeepCopyObject().(resource.KubernetesResource)
+			return
+		}
+	}
+}
+
+func getPodSpec(res resource.KubernetesResource) (string, *corev1.PodSpec) {
+	switch obj := res.(type) {
+	case *oappsv1.DeploymentConfig:
+		if obj.Spec.Template != nil {
+			return "DeploymentConfig", &obj.Spec.Template.Spec
+		}
+	case *appsv1.Deployment:
+		return "Deployment", &obj.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		return "StatefulSet", &obj.Spec.Template.Spec
+	}
+	return "", nil
+}
+
+// getSnapshotResources returns the deployed resources as a YAML list, without their status and server-set metadata
+func getSnapshotResources(scheme *runtime.Scheme, deployed map[reflect.Type][]resource.KubernetesResource) ([]byte, error) {
+	var objects []map[string]interface{}
+	for _, resources := range deployed {
+		for _, res := range resources {
+			object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(res)
+			if err != nil {
+				return nil, err
+			}
+			gvk, err := apiutil.GVKForObject(res, scheme)
+			if err != nil {
+				return nil, err
+			}
+			snapshot := unstructured.Unstructured{Object: object}
+			snapshot.SetGroupVersionKind(gvk)
+			unstructured.RemoveNestedField(snapshot.Object, "status")
+			for _, field := range []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink", "ownerReferences"} {
+				unstructured.RemoveNestedField(snapshot.Object, "metadata", field)
+			}
+			objects = append(objects, snapshot.Object)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		iObject := unstructured.Unstructured{Object: objects[i]}
+		jObject := unstructured.Unstructured{Object: objects[j]}
+		if iObject.GetKind() != jObject.GetKind() {
+			return iObject.GetKind() < jObject.GetKind()
+		}
+		return iObject.GetName() < jObject.GetName()
+	})
+	return yaml.Marshal(objects)
+}
+
+// restoreSnapshotImages sets the images of the containers of the requested workloads to the ones deployed before the
+// upgrade from the version rolled back to
+func (reconciler *Reconciler) restoreSnapshotImages(instance *api.KieApp, requested []resource.KubernetesResource) error {
+	snapshot, err := defaults.GetUpgradeSnapshot(instance, instance.Spec.RollbackTo, reconciler.Service)
+	if err != nil {
+		return err
+	}
+	var objects []map[string]interface{}
+	if err := yaml.Unmarshal(snapshot.Data[constants.UpgradeSnapshotResources], &objects); err != nil {
+		return err
+	}
+	images := map[string]string{}
+	for _, object := range objects {
+		deployed := unstructured.Unstructured{Object: object}
+		for _, containersField := range []string{"containers", "initContainers"} {
+			containers, _, _ := unstructured.NestedSlice(deployed.Object, "spec", "template", "spec", containersField)
+			for _, container := range containers {
+				name, _, _ := unstructured.NestedString(container.(map[string]interface{}), "name")
+				image, _, _ := unstructured.NestedString(container.(map[string]interface{}), "image")
+				images[getSnapshotImageKey(deployed.GetKind(), deployed.GetName(), name)] = image
+			}
+		}
+	}
+	for _, res := range requested {
+		kind, podSpec := getPodSpec(res)
+		if podSpec == nil {
+			continue
+		}
+		for _, containers := range [][]corev1.Container{podSpec.Containers, podSpec.InitContainers} {
+			for index := range containers {
+				if image, found := images[getSnapshotImageKey(kind, res.GetName(), containers[index].Name)]; found && image != "" {
+					containers[index].Image = image
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func getSnapshotImageKey(kind, name, container string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, name, container)
+}
@@ -0,0 +1,381 @@
+package kieapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/test"
+	oappsv1 "github.com/openshift/api/apps/v1"
+	oimagev1 "github.com/openshift/api/image/v1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestUpgradeRollback(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+		Version:     constants.PriorVersion1,
+		Upgrades:    api.KieAppUpgrades{Enabled: true, Minor: true},
+	}
+	cr.Status.Applied = api.KieAppSpec{Environment: api.RhpamTrial, Version: constants.PriorVersion1}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	priorImage := "registry.example.com/rhpam-businesscentral-rhel8@sha256:prior"
+	dc := &oappsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "cr-rhpamcentr", Namespace: "testns"},
+		Spec: oappsv1.DeploymentConfigSpec{Template: &corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "cr-rhpamcentr", Image: priorImage}},
+		}}},
+	}
+	err = controllerutil.SetControllerReference(cr, dc, service.GetScheme())
+	assert.Nil(t, err)
+	err = service.Create(context.TODO(), dc)
+	assert.Nil(t, err)
+	recorder := record.NewFakeRecorder(100)
+	reconciler := Reconciler{Service: service, Recorder: recorder}
+
+	// the upgrade is recorded along with the snapshot of the prior version
+	for i := 0; i < 2; i++ {
+		_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		assert.Nil(t, err)
+	}
+	events := readEvents(recorder)
+	assert.Contains(t, events, "Normal UpgradeStarted Upgrading from version "+constants.PriorVersion1+" to "+constants.CurrentVersion)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version)
+	assert.Len(t, cr.Status.UpgradeHistory, 1)
+	upgrade := cr.Status.UpgradeHistory[0]
+	assert.Equal(t, constants.PriorVersion1, upgrade.FromVersion)
+	assert.Equal(t, constants.CurrentVersion, upgrade.ToVersion)
+	assert.Equal(t, api.UpgradeInProgress, upgrade.Phase)
+	assert.Equal(t, defaults.GetUpgradeSnapshotName(cr, constants.PriorVersion1), upgrade.Snapshot)
+
+	snapshot := &corev1.Secret{}
+	err = service.Get(context.TODO(), types.NamespacedName{Name: upgrade.Snapshot, Namespace: "testns"}, snapshot)
+	assert.Nil(t, err)
+	assert.Equal(t, cr.Name, snapshot.OwnerReferences[0].Name, "The snapshot should be owned by the KieApp")
+	applied := api.KieAppSpec{}
+	err = yaml.Unmarshal(snapshot.Data[constants.UpgradeSnapshotApplied], &applied)
+	assert.Nil(t, err)
+	assert.Equal(t, constants.PriorVersion1, applied.Version)
+	assert.Contains(t, string(snapshot.Data[constants.UpgradeSnapshotResources]), priorImage)
+
+	err = service.Get(context.TODO(), types.NamespacedName{Name: dc.Name, Namespace: "testns"}, dc)
+	assert.Nil(t, err)
+	assert.NotEqual(t, priorImage, dc.Spec.Template.Spec.Containers[0].Image, "The upgraded image should be deployed")
+
+	// the prior version and its images are restored
+	cr.Spec.RollbackTo = constants.PriorVersion1
+	err = service.Update(context.TODO(), cr)
+	assert.Nil(t, err)
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	events = readEvents(recorder)
+	assert.Contains(t, events, "Normal UpgradeRolledBack Rolling back from version "+constants.CurrentVersion+" to "+constants.PriorVersion1)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version)
+	assert.Len(t, cr.Status.UpgradeHistory, 1)
+	assert.Equal(t, api.UpgradeRolledBack, cr.Status.UpgradeHistory[0].Phase)
+	assert.False(t, cr.Status.UpgradeHistory[0].CompletionTime.IsZero())
+
+	err = service.Get(context.TODO(), types.NamespacedName{Name: dc.Name, Namespace: "testns"}, dc)
+	assert.Nil(t, err)
+	assert.Equal(t, priorImage, dc.Spec.Template.Spec.Containers[0].Image, "The image of the prior version should be restored")
+
+	// upgrades are suspended while rolled back
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version)
+	assert.Len(t, cr.Status.UpgradeHistory, 1)
+}
+
+func TestUpgradeFailed(t *testing.T) {
+	cr := getInstance(getNamespacedName("testns", "cr"))
+	cr.Status.Applied.Version = constants.CurrentVersion
+	cr.Status.UpgradeHistory = []api.UpgradeRecord{{FromVersion: constants.PriorVersion1, ToVersion: constants.CurrentVersion, Phase: api.UpgradeInProgress}}
+	recorder := record.NewFakeRecorder(100)
+	reconciler := Reconciler{Service: test.MockService(), Recorder: recorder}
+	components := []api.Condition{
+		{Type: api.ConsoleReadyConditionType, Status: corev1.ConditionTrue},
+		{Type: api.ConditionType("KieServerTestKieserverReady"), Status: corev1.ConditionFalse, Reason: api.RolloutFailedReason, Message: "DeploymentConfig cr-kieserver rollout failed"},
+	}
+
+	assert.True(t, reconciler.checkUpgradePhase(cr, components, false))
+	assert.Equal(t, api.UpgradeFailed, cr.Status.UpgradeHistory[0].Phase)
+	assert.Equal(t, "DeploymentConfig cr-kieserver rollout failed", cr.Status.UpgradeHistory[0].Message)
+	assert.Contains(t, readEvents(recorder), "Warning UpgradeFailed Upgrade from version "+constants.PriorVersion1+" to "+constants.CurrentVersion+
+		" failed, set rollbackTo "+constants.PriorVersion1+" to roll back: DeploymentConfig cr-kieserver rollout failed")
+	assert.False(t, reconciler.checkUpgradePhase(cr, components, false), "The failure should only be recorded once")
+	cr.Status.UpgradeHistory[0].Phase = api.UpgradeInProgress
+	cr.Status.UpgradePath = []string{"7.10.0"}
+	assert.True(t, reconciler.checkUpgradePhase(cr, components, false))
+	assert.Contains(t, readEvents(recorder), "Warning UpgradeFailed Upgrade from version "+constants.PriorVersion1+" to "+constants.CurrentVersion+
+		" failed, set rollbackTo "+constants.PriorVersion1+" to roll back: DeploymentConfig cr-kieserver rollout failed. The upgrade path through 7.10.0 is stopped")
+
+	assert.True(t, reconciler.checkUpgradePhase(cr, components, true), "A failed upgrade should complete once its components recover")
+	assert.Equal(t, api.UpgradeCompleted, cr.Status.UpgradeHistory[0].Phase)
+}
+
+func TestUpgradeAvailable(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+		Upgrades:    api.KieAppUpgrades{Enabled: true, Minor: true, Approval: api.UpgradeApprovalManual},
+	}
+	cr.Status.Applied = api.KieAppSpec{Environment: api.RhpamTrial, Version: constants.PriorVersion1}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	recorder := record.NewFakeRecorder(100)
+	reconciler := Reconciler{Service: service, Recorder: recorder}
+
+	// the pending upgrade is reported once, without upgrading
+	for i := 0; i < 2; i++ {
+		_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		assert.Nil(t, err)
+	}
+	message := "Upgrade from version " + constants.PriorVersion1 + " to " + constants.CurrentVersion + " pending, set the " +
+		constants.ApprovedVersionAnnotation + " annotation to " + constants.CurrentVersion + " to approve the upgrade"
+	events := readEvents(recorder)
+	assert.Contains(t, events, "Normal UpgradeAvailable "+message)
+	assert.NotContains(t, events, "Normal UpgradeStarted Upgrading from version "+constants.PriorVersion1+" to "+constants.CurrentVersion)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version)
+	assert.NotNil(t, cr.Status.UpgradeAvailable)
+	assert.Equal(t, message, cr.Status.UpgradeAvailable.Message)
+
+	// the approved upgrade is applied
+	cr.SetAnnotations(map[string]string{constants.ApprovedVersionAnnotation: constants.CurrentVersion})
+	err = service.Update(context.TODO(), cr)
+	assert.Nil(t, err)
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.Contains(t, readEvents(recorder), "Normal UpgradeStarted Upgrading from version "+constants.PriorVersion1+" to "+constants.CurrentVersion)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version)
+	assert.Nil(t, cr.Status.UpgradeAvailable)
+}
+
+func TestMultiHopUpgrade(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+		Upgrades:    api.KieAppUpgrades{Enabled: true, Minor: true},
+	}
+	cr.Status.Applied = api.KieAppSpec{Environment: api.RhpamTrial, Version: constants.PriorVersion2}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	recorder := record.NewFakeRecorder(100)
+	reconciler := Reconciler{Service: service, Recorder: recorder}
+
+	// the first hop is upgraded to
+	for i := 0; i < 2; i++ {
+		_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		assert.Nil(t, err)
+	}
+	assert.Contains(t, readEvents(recorder), "Normal UpgradeStarted Upgrading from version "+constants.PriorVersion2+" to "+constants.PriorVersion1)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version)
+	assert.Equal(t, []string{constants.CurrentVersion}, cr.Status.UpgradePath)
+	assert.Len(t, cr.Status.UpgradeHistory, 1)
+
+	// the next hop waits for the first one to complete
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.Applied.Version)
+	assert.Len(t, cr.Status.UpgradeHistory, 1)
+
+	cr.Status.UpgradeHistory[0].Phase = api.UpgradeCompleted
+	err = service.Status().Update(context.TODO(), cr)
+	assert.Nil(t, err)
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	assert.Contains(t, readEvents(recorder), "Normal UpgradeStarted Upgrading from version "+constants.PriorVersion1+" to "+constants.CurrentVersion)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, constants.CurrentVersion, cr.Status.Applied.Version)
+	assert.Empty(t, cr.Status.UpgradePath)
+	assert.Len(t, cr.Status.UpgradeHistory, 2)
+	assert.Equal(t, constants.PriorVersion1, cr.Status.UpgradeHistory[1].FromVersion)
+}
+
+func TestDatabaseMigration(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+		Version:     constants.PriorVersion1,
+		Upgrades:    api.KieAppUpgrades{Enabled: true, Minor: true},
+		Objects: api.KieAppObjects{Servers: []api.KieServerSet{{
+			Database: &api.DatabaseObject{InternalDatabaseObject: api.InternalDatabaseObject{Type: api.DatabaseMySQL}},
+		}}},
+	}
+	cr.Status.Applied = api.KieAppSpec{Environment: api.RhpamTrial, Version: constants.PriorVersion1}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	priorImage := "registry.example.com/rhpam-kieserver-rhel8@sha256:prior"
+	dc := &oappsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "cr-kieserver", Namespace: "testns"},
+		Spec: oappsv1.DeploymentConfigSpec{Template: &corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "cr-kieserver", Image: priorImage}},
+		}}},
+	}
+	err = controllerutil.SetControllerReference(cr, dc, service.GetScheme())
+	assert.Nil(t, err)
+	err = service.Create(context.TODO(), dc)
+	assert.Nil(t, err)
+	recorder := record.NewFakeRecorder(100)
+	reconciler := Reconciler{Service: service, Recorder: recorder}
+	reconcileDC := func() {
+		_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		assert.Nil(t, err)
+		err = service.Get(context.TODO(), types.NamespacedName{Name: dc.Name, Namespace: "testns"}, dc)
+		assert.Nil(t, err)
+	}
+
+	// the KIE Server is not rolled out while its database is migrated
+	for i := 0; i < 2; i++ {
+		reconcileDC()
+	}
+	assert.Contains(t, readEvents(recorder), "Normal DatabaseMigrationStarted Migrating the database of cr-kieserver from version "+
+		constants.PriorVersion1+" to "+constants.CurrentVersion)
+	assert.Equal(t, priorImage, dc.Spec.Template.Spec.Containers[0].Image)
+	job := &batchv1.Job{}
+	jobName := types.NamespacedName{Name: "cr-kieserver-migration-" + constants.CurrentVersion, Namespace: "testns"}
+	err = service.Get(context.TODO(), jobName, job)
+	assert.Nil(t, err)
+	assert.Equal(t, cr.Name, job.OwnerReferences[0].Name, "The job should be owned by the KieApp")
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Len(t, cr.Status.UpgradeHistory, 1)
+	migrations := cr.Status.UpgradeHistory[0].DatabaseMigrations
+	assert.Len(t, migrations, 1)
+	assert.Equal(t, api.DatabaseMigrationRunning, migrations[0].Phase)
+	assert.Equal(t, jobName.Name, migrations[0].Job)
+
+	// a failed migration fails the upgrade
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"}}
+	err = service.Status().Update(context.TODO(), job)
+	assert.Nil(t, err)
+	reconcileDC()
+	assert.Contains(t, readEvents(recorder), "Warning UpgradeFailed Upgrade from version "+constants.PriorVersion1+" to "+constants.CurrentVersion+
+		" failed, set rollbackTo "+constants.PriorVersion1+" to roll back: Database migration job "+jobName.Name+" failed: Job has reached the specified backoff limit")
+	assert.Equal(t, priorImage, dc.Spec.Template.Spec.Containers[0].Image)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, api.UpgradeFailed, cr.Status.UpgradeHistory[0].Phase)
+	assert.Equal(t, api.DatabaseMigrationFailed, cr.Status.UpgradeHistory[0].DatabaseMigrations[0].Phase)
+
+	// the KIE Server is rolled out once its database is migrated
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	err = service.Status().Update(context.TODO(), job)
+	assert.Nil(t, err)
+	reconcileDC()
+	assert.NotEqual(t, priorImage, dc.Spec.Template.Spec.Containers[0].Image)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Equal(t, api.DatabaseMigrationSucceeded, cr.Status.UpgradeHistory[0].DatabaseMigrations[0].Phase)
+}
+
+func TestDatabaseMigrationKubernetes(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment:   api.RhpamTrial,
+		Version:       constants.PriorVersion1,
+		Platform:      api.PlatformKubernetes,
+		IngressDomain: "apps.example.com",
+		Upgrades:      api.KieAppUpgrades{Enabled: true, Minor: true},
+		Objects: api.KieAppObjects{Servers: []api.KieServerSet{{
+			Database: &api.DatabaseObject{InternalDatabaseObject: api.InternalDatabaseObject{Type: api.DatabaseMySQL}},
+		}}},
+	}
+	cr.Status.Applied = api.KieAppSpec{Environment: api.RhpamTrial, Version: constants.PriorVersion1, Platform: api.PlatformKubernetes, IngressDomain: "apps.example.com"}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	priorImage := "registry.example.com/rhpam-kieserver-rhel8@sha256:prior"
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "cr-kieserver", Namespace: "testns"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "cr-kieserver", Image: priorImage}},
+		}}},
+	}
+	err = controllerutil.SetControllerReference(cr, deployment, service.GetScheme())
+	assert.Nil(t, err)
+	err = service.Create(context.TODO(), deployment)
+	assert.Nil(t, err)
+	reconciler := Reconciler{Service: service, Recorder: record.NewFakeRecorder(100)}
+
+	// the KIE Server Deployment is not rolled out while its database is migrated
+	for i := 0; i < 2; i++ {
+		_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+		assert.Nil(t, err)
+	}
+	err = service.Get(context.TODO(), types.NamespacedName{Name: deployment.Name, Namespace: "testns"}, deployment)
+	assert.Nil(t, err)
+	assert.Equal(t, priorImage, deployment.Spec.Template.Spec.Containers[0].Image)
+	job := &batchv1.Job{}
+	err = service.Get(context.TODO(), types.NamespacedName{Name: "cr-kieserver-migration-" + constants.CurrentVersion, Namespace: "testns"}, job)
+	assert.Nil(t, err)
+	cr = reloadCR(t, service, crNamespacedName)
+	assert.Len(t, cr.Status.UpgradeHistory, 1)
+	assert.Len(t, cr.Status.UpgradeHistory[0].DatabaseMigrations, 1)
+}
+
+func TestDatabaseMigrationImageStream(t *testing.T) {
+	crNamespacedName := getNamespacedName("testns", "cr")
+	cr := getInstance(crNamespacedName)
+	cr.Spec = api.KieAppSpec{
+		Environment: api.RhpamTrial,
+		Version:     constants.PriorVersion1,
+		Upgrades:    api.KieAppUpgrades{Enabled: true, Minor: true},
+		Objects: api.KieAppObjects{Servers: []api.KieServerSet{{
+			KieAppObject: api.KieAppObject{Image: "custom-kieserver", ImageTag: "1.0"},
+			Database:     &api.DatabaseObject{InternalDatabaseObject: api.InternalDatabaseObject{Type: api.DatabaseMySQL}},
+		}}},
+	}
+	cr.Status.Applied = api.KieAppSpec{Environment: api.RhpamTrial, Version: constants.PriorVersion1}
+	service := test.MockService()
+	err := service.Create(context.TODO(), cr)
+	assert.Nil(t, err)
+	reconciler := Reconciler{Service: service, Recorder: record.NewFakeRecorder(100)}
+	jobName := types.NamespacedName{Name: "cr-kieserver-migration-" + constants.CurrentVersion, Namespace: "testns"}
+
+	// the image of the KIE Server is only known once its ImageStreamTag is imported
+	for i := 0; i < 2; i++ {
+		_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	}
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "has no image yet")
+	assert.True(t, errors.IsNotFound(service.Get(context.TODO(), jobName, &batchv1.Job{})))
+
+	image := "registry.example.com/custom-kieserver@sha256:custom"
+	_, err = service.ImageStreamTags("openshift").Create(context.TODO(), &oimagev1.ImageStreamTag{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom-kieserver:1.0", Namespace: "openshift"},
+		Image:      oimagev1.Image{DockerImageReference: image},
+	}, metav1.CreateOptions{})
+	assert.Nil(t, err)
+	_, err = reconciler.Reconcile(reconcile.Request{NamespacedName: crNamespacedName})
+	assert.Nil(t, err)
+	job := &batchv1.Job{}
+	assert.Nil(t, service.Get(context.TODO(), jobName, job))
+	assert.Equal(t, image, job.Spec.Template.Spec.InitContainers[0].Image, "The image should be resolved from the ImageStreamTag of the trigger")
+}
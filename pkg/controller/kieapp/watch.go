@@ -8,20 +8,36 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	operatorsv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // Add Creates a new controller and starts watching resources
-func Add(mgr manager.Manager, reconciler reconcile.Reconciler) error {
+func Add(mgr manager.Manager, reconciler *Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("kieapp-controller", mgr, controller.Options{Reconciler: reconciler})
+	options := reconciler.Options.withDefaults()
+	selector, err := options.Selector()
+	if err != nil {
+		return err
+	}
+	c, err := controller.New("kieapp-controller", mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: options.MaxConcurrentReconciles,
+		RateLimiter:             options.RateLimiter(),
+	})
 	if err != nil {
 		return err
 	}
@@ -32,8 +48,19 @@ func Add(mgr manager.Manager, reconciler reconcile.Reconciler) error {
 		&appsv1.Deployment{},
 	}
 	objectHandler := &handler.EnqueueRequestForObject{}
+	// Only the KieApps matching the selector are handled, the resources they own are watched regardless
+	selectorPredicate := predicate.NewPredicateFuncs(func(meta metav1.Object, object runtime.Object) bool {
+		return selector.Matches(labels.Set(meta.GetLabels()))
+	})
 	for _, watchObject := range watchObjects {
-		err = c.Watch(&source.Kind{Type: watchObject}, objectHandler)
+		if !isServed(mgr, watchObject) {
+			continue
+		}
+		var predicates []predicate.Predicate
+		if _, isKieApp := watchObject.(*api.KieApp); isKieApp {
+			predicates = append(predicates, selectorPredicate)
+		}
+		err = c.Watch(&source.Kind{Type: watchObject}, objectHandler, predicates...)
 		if err != nil {
 			return err
 		}
@@ -52,6 +79,9 @@ func Add(mgr manager.Manager, reconciler reconcile.Reconciler) error {
 		OwnerType: &operatorsv1alpha1.ClusterServiceVersion{},
 	}
 	for _, watchObject := range watchOwnedObjects {
+		if !isServed(mgr, watchObject) {
+			continue
+		}
 		err = c.Watch(&source.Kind{Type: watchObject}, ownerHandler)
 		if err != nil {
 			return err
@@ -60,6 +90,7 @@ func Add(mgr manager.Manager, reconciler reconcile.Reconciler) error {
 
 	watchOwnedObjects = []runtime.Object{
 		&oappsv1.DeploymentConfig{},
+		&appsv1.Deployment{},
 		&appsv1.StatefulSet{},
 		&corev1.PersistentVolumeClaim{},
 		&rbacv1.RoleBinding{},
@@ -68,14 +99,21 @@ func Add(mgr manager.Manager, reconciler reconcile.Reconciler) error {
 		&corev1.Secret{},
 		&corev1.Service{},
 		&routev1.Route{},
+		&networkingv1beta1.Ingress{},
+		&autoscalingv2beta2.HorizontalPodAutoscaler{},
+		&policyv1beta1.PodDisruptionBudget{},
 		&buildv1.BuildConfig{},
 		&oimagev1.ImageStream{},
+		&batchv1.Job{},
 	}
 	ownerHandler = &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &api.KieApp{},
 	}
 	for _, watchObject := range watchOwnedObjects {
+		if !isServed(mgr, watchObject) {
+			continue
+		}
 		err = c.Watch(&source.Kind{Type: watchObject}, ownerHandler)
 		if err != nil {
 			return err
@@ -85,16 +123,34 @@ func Add(mgr manager.Manager, reconciler reconcile.Reconciler) error {
 	watchOwnedObjects = []runtime.Object{
 		&corev1.ConfigMap{},
 	}
-	ownerHandler = &handler.EnqueueRequestForOwner{
-		IsController: true,
-		OwnerType:    &oappsv1.DeploymentConfig{},
-	}
-	for _, watchObject := range watchOwnedObjects {
-		err = c.Watch(&source.Kind{Type: watchObject}, ownerHandler)
-		if err != nil {
-			return err
+	for _, ownerType := range []runtime.Object{&oappsv1.DeploymentConfig{}, &appsv1.Deployment{}} {
+		ownerHandler = &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    ownerType,
+		}
+		for _, watchObject := range watchOwnedObjects {
+			if !isServed(mgr, watchObject) {
+				continue
+			}
+			err = c.Watch(&source.Kind{Type: watchObject}, ownerHandler)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
+
+// isServed returns false for the kinds the cluster does not serve, such as the OpenShift APIs on vanilla Kubernetes
+func isServed(mgr manager.Manager, obj runtime.Object) bool {
+	gvk, err := apiutil.GVKForObject(obj, mgr.GetScheme())
+	if err != nil {
+		return false
+	}
+	if _, err = mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		log.Infof("Not watching %s, the kind is not served by the cluster", gvk.Kind)
+		return false
+	}
+	return true
+}
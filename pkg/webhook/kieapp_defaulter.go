@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// kieAppDefaulter stores the non-secret defaults in the spec of the KieApp
+type kieAppDefaulter struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &kieAppDefaulter{}
+
+// InjectDecoder injects the decoder used to read the KieApp from the admission request
+func (defaulter *kieAppDefaulter) InjectDecoder(decoder *admission.Decoder) error {
+	defaulter.decoder = decoder
+	return nil
+}
+
+// Handle patches the KieApp being created or updated with the defaults applied to its spec
+func (defaulter *kieAppDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1beta1.Create && req.Operation != admissionv1beta1.Update {
+		return admission.Allowed("")
+	}
+	kieApp := &api.KieApp{}
+	if err := defaulter.decoder.Decode(req, kieApp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if kieApp.GetDeletionTimestamp() != nil {
+		return admission.Allowed("")
+	}
+	defaults.SetSpecDefaults(kieApp)
+	marshaled, err := json.Marshal(kieApp)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
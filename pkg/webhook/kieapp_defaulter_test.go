@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDefaultKieApp(t *testing.T) {
+	defaulter := &kieAppDefaulter{}
+	assert.Nil(t, defaulter.InjectDecoder(getValidator(t).decoder))
+	cr := &api.KieApp{
+		TypeMeta:   metav1.TypeMeta{APIVersion: api.SchemeGroupVersion.String(), Kind: "KieApp"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testns"},
+		Spec:       api.KieAppSpec{Environment: api.RhpamTrial},
+	}
+	response := defaulter.Handle(context.TODO(), getRequest(t, admissionv1beta1.Create, cr))
+	assert.True(t, response.Allowed)
+	paths := map[string]interface{}{}
+	for _, patch := range response.Patches {
+		paths[patch.Path] = patch.Value
+	}
+	assert.Equal(t, "test", paths["/spec/commonConfig/applicationName"])
+	assert.Contains(t, paths, "/spec/objects/servers")
+	assert.Contains(t, paths, "/spec/objects/console/replicas")
+	assert.NotContains(t, paths, "/spec/commonConfig/adminPassword")
+	assert.NotContains(t, paths, "/status")
+
+	response = defaulter.Handle(context.TODO(), getRequest(t, admissionv1beta1.Delete, cr))
+	assert.True(t, response.Allowed)
+	assert.Empty(t, response.Patches)
+}
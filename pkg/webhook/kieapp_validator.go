@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/defaults"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// kieAppValidator rejects KieApps that can't be turned into an environment
+type kieAppValidator struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &kieAppValidator{}
+
+// InjectDecoder injects the decoder used to read the KieApp from the admission request
+func (validator *kieAppValidator) InjectDecoder(decoder *admission.Decoder) error {
+	validator.decoder = decoder
+	return nil
+}
+
+// Handle validates the KieApp being created or updated
+func (validator *kieAppValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1beta1.Create && req.Operation != admissionv1beta1.Update {
+		return admission.Allowed("")
+	}
+	kieApp := &api.KieApp{}
+	if err := validator.decoder.Decode(req, kieApp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if kieApp.GetDeletionTimestamp() != nil {
+		return admission.Allowed("")
+	}
+	if req.Operation == admissionv1beta1.Update {
+		// the operator updates the finalizers and metadata of KieApps that may have been created invalid
+		oldKieApp := &api.KieApp{}
+		if err := validator.decoder.DecodeRaw(req.OldObject, oldKieApp); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(oldKieApp.Spec, kieApp.Spec) {
+			return admission.Allowed("")
+		}
+	}
+	return validationResponse(kieApp)
+}
+
+func validationResponse(kieApp *api.KieApp) admission.Response {
+	errs := defaults.ValidateKieApp(kieApp)
+	if len(errs) == 0 {
+		return admission.Allowed("")
+	}
+	log.Debugf("Rejecting KieApp %s/%s: %v", kieApp.Namespace, kieApp.Name, errs)
+	invalid := errors.NewInvalid(api.SchemeGroupVersion.WithKind("KieApp").GroupKind(), kieApp.Name, errs)
+	response := admission.Denied(invalid.Error())
+	response.Result = &invalid.ErrStatus
+	return response
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	api "github.com/kiegroup/kie-cloud-operator/pkg/apis/app/v2"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestValidateKieApp(t *testing.T) {
+	validator := getValidator(t)
+	cr := &api.KieApp{
+		TypeMeta:   metav1.TypeMeta{APIVersion: api.SchemeGroupVersion.String(), Kind: "KieApp"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testns"},
+		Spec:       api.KieAppSpec{Environment: api.RhpamTrial},
+	}
+	response := validator.Handle(context.TODO(), getRequest(t, admissionv1beta1.Create, cr))
+	assert.True(t, response.Allowed)
+
+	old := cr.DeepCopy()
+	cr.Spec.Version = "7.1.0"
+	response = validator.Handle(context.TODO(), getUpdateRequest(t, old, cr))
+	assert.False(t, response.Allowed)
+	assert.Equal(t, int32(http.StatusUnprocessableEntity), response.Result.Code)
+	assert.Equal(t, metav1.StatusReasonInvalid, response.Result.Reason)
+	assert.Len(t, response.Result.Details.Causes, 1)
+	assert.Equal(t, "spec.version", response.Result.Details.Causes[0].Field)
+
+	response = validator.Handle(context.TODO(), getRequest(t, admissionv1beta1.Delete, cr))
+	assert.True(t, response.Allowed, "Deleting an invalid KieApp should be allowed")
+
+	updated := cr.DeepCopy()
+	updated.Finalizers = []string{constants.KieAppFinalizer}
+	updated.Labels = map[string]string{"app": "test"}
+	response = validator.Handle(context.TODO(), getUpdateRequest(t, cr, updated))
+	assert.True(t, response.Allowed, "Updating the metadata of an invalid KieApp should be allowed")
+}
+
+func getValidator(t *testing.T) *kieAppValidator {
+	scheme := runtime.NewScheme()
+	assert.Nil(t, api.SchemeBuilder.AddToScheme(scheme))
+	decoder, err := admission.NewDecoder(scheme)
+	assert.Nil(t, err)
+	validator := &kieAppValidator{}
+	assert.Nil(t, validator.InjectDecoder(decoder))
+	return validator
+}
+
+func getRequest(t *testing.T, operation admissionv1beta1.Operation, cr *api.KieApp) admission.Request {
+	raw, err := json.Marshal(cr)
+	assert.Nil(t, err)
+	return admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Operation: operation,
+			Name:      cr.Name,
+			Namespace: cr.Namespace,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func getUpdateRequest(t *testing.T, old, cr *api.KieApp) admission.Request {
+	request := getRequest(t, admissionv1beta1.Update, cr)
+	raw, err := json.Marshal(old)
+	assert.Nil(t, err)
+	request.OldObject = runtime.RawExtension{Raw: raw}
+	return request
+}
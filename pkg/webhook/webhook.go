@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/RHsyseng/operator-utils/pkg/logs"
+	"github.com/kiegroup/kie-cloud-operator/pkg/controller/kieapp/constants"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var log = logs.GetLogger("kieapp.webhook")
+
+// AddToManager registers the KieApp admission webhooks on the webhook server of the Manager.
+// The webhooks are skipped when the serving certificate provided by OLM is not mounted, e.g. when running locally.
+func AddToManager(mgr manager.Manager) error {
+	if _, err := os.Stat(filepath.Join(constants.WebhookCertDir, constants.WebhookCertName)); err != nil {
+		log.Info("Webhook serving certificate not found, admission webhooks are disabled.")
+		return nil
+	}
+	server := mgr.GetWebhookServer()
+	server.Port = constants.WebhookPort
+	server.CertDir = constants.WebhookCertDir
+	server.CertName = constants.WebhookCertName
+	server.KeyName = constants.WebhookKeyName
+	server.Register(constants.MutatingWebhookPath, &webhook.Admission{Handler: &kieAppDefaulter{}})
+	server.Register(constants.ValidatingWebhookPath, &webhook.Admission{Handler: &kieAppValidator{}})
+	return nil
+}
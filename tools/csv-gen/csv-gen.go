@@ -30,6 +30,7 @@ import (
 	csvv1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
 	"github.com/tidwall/sjson"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -278,6 +279,48 @@ func main() {
 			},
 		}
 
+		failurePolicy := admissionregistrationv1.Fail
+		sideEffects := admissionregistrationv1.SideEffectClassNone
+		timeoutSeconds := int32(10)
+		validatingPath := constants.ValidatingWebhookPath
+		mutatingPath := constants.MutatingWebhookPath
+		webhookRules := []admissionregistrationv1.RuleWithOperations{
+			{
+				Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{api.SchemeGroupVersion.Group},
+					APIVersions: []string{api.SchemeGroupVersion.Version},
+					Resources:   []string{"kieapps"},
+				},
+			},
+		}
+		templateStruct.Spec.WebhookDefinitions = []csvv1.WebhookDescription{
+			{
+				GenerateName:            "mkieapp.kb.io",
+				Type:                    csvv1.MutatingAdmissionWebhook,
+				DeploymentName:          csv.OperatorName,
+				ContainerPort:           constants.WebhookPort,
+				Rules:                   webhookRules,
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				TimeoutSeconds:          &timeoutSeconds,
+				AdmissionReviewVersions: []string{"v1beta1"},
+				WebhookPath:             &mutatingPath,
+			},
+			{
+				GenerateName:            "vkieapp.kb.io",
+				Type:                    csvv1.ValidatingAdmissionWebhook,
+				DeploymentName:          csv.OperatorName,
+				ContainerPort:           constants.WebhookPort,
+				Rules:                   webhookRules,
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				TimeoutSeconds:          &timeoutSeconds,
+				AdmissionReviewVersions: []string{"v1beta1"},
+				WebhookPath:             &validatingPath,
+			},
+		}
+
 		csvFile := "deploy/olm-catalog/" + operatorName + "/" + version.Version + "/" + "manifests/" + csvVersionedName + ".clusterserviceversion.yaml"
 
 		if csv.OperatorName == "kie-cloud-operator" {